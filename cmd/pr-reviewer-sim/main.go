@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+	_ "time/tzdata"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/simulation"
+)
+
+func main() {
+	var (
+		teamsFile     = flag.String("teams", "", "JSON file with teams; synthetic teams are generated when empty")
+		eventsFile    = flag.String("events", "", "CSV file with events (at,event,pull_request_id,user_id); synthetic events are generated when empty")
+		strategies    = flag.String("strategies", "default,working-hours,pairing,working-hours+pairing", "comma-separated selection strategies to compare")
+		pairingWindow = flag.Duration("pairing-window", 30*24*time.Hour, "window for the pairing strategies")
+		reviewers     = flag.Int("reviewers", 2, "reviewers assigned to each pull request")
+		perUser       = flag.Bool("per-user", false, "print review counts for every user")
+
+		synthetic simulation.SyntheticConfig
+		start     string
+	)
+	flag.IntVar(&synthetic.Teams, "synthetic-teams", 3, "number of synthetic teams")
+	flag.IntVar(&synthetic.TeamSize, "synthetic-team-size", 6, "members per synthetic team")
+	flag.IntVar(&synthetic.ReviewCapacity, "synthetic-capacity", 3, "default review capacity of synthetic teams, 0 = unlimited")
+	flag.IntVar(&synthetic.PullRequests, "synthetic-prs", 500, "number of synthetic pull requests")
+	flag.DurationVar(&synthetic.Interval, "synthetic-interval", 30*time.Minute, "time between synthetic pull requests")
+	flag.IntVar(&synthetic.MergeAfter, "synthetic-merge-after", 12, "synthetic pull requests stay open for this many later creations")
+	flag.Float64Var(&synthetic.DeactivationRate, "synthetic-deactivation-rate", 0.02, "chance per step that a synthetic user is deactivated")
+	flag.IntVar(&synthetic.DeactivationSteps, "synthetic-deactivation-steps", 40, "steps a deactivated synthetic user stays away")
+	flag.Uint64Var(&synthetic.Seed, "seed", 1, "seed for synthetic events")
+	flag.StringVar(&start, "start", "2025-01-06T00:00:00Z", "start of synthetic events (RFC 3339)")
+	flag.Parse()
+
+	var err error
+	if synthetic.Start, err = time.Parse(time.RFC3339, start); err != nil {
+		log.Fatalf("invalid -start: %v", err)
+	}
+
+	teams, err := loadTeams(*teamsFile, synthetic)
+	if err != nil {
+		log.Fatalf("failed to load teams: %v", err)
+	}
+	events, err := loadEvents(*eventsFile, teams, synthetic)
+	if err != nil {
+		log.Fatalf("failed to load events: %v", err)
+	}
+	selected, err := simulation.Strategies(strings.Split(*strategies, ","), *pairingWindow, *reviewers)
+	if err != nil {
+		log.Fatalf("invalid -strategies: %v", err)
+	}
+
+	results := make([]simulation.Result, 0, len(selected))
+	for _, strategy := range selected {
+		result, err := simulation.Run(context.Background(), teams, events, strategy)
+		if err != nil {
+			log.Fatalf("strategy %s: %v", strategy.Name, err)
+		}
+		results = append(results, result)
+	}
+
+	fmt.Printf("%d teams, %d events\n\n", len(teams), len(events))
+	printSummary(results)
+	if *perUser {
+		fmt.Println()
+		printPerUser(results)
+	}
+}
+
+func loadTeams(path string, synthetic simulation.SyntheticConfig) ([]domain.Team, error) {
+	if path == "" {
+		return simulation.SyntheticTeams(synthetic), nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return simulation.ReadTeams(f)
+}
+
+func loadEvents(path string, teams []domain.Team, synthetic simulation.SyntheticConfig) ([]simulation.Event, error) {
+	if path == "" {
+		return simulation.SyntheticEvents(teams, synthetic), nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return simulation.ReadEvents(f)
+}
+
+func printSummary(results []simulation.Result) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STRATEGY\tTEAM\tPRS\tUNDERSTAFFED\tREVIEWS MIN/P50/P90/MAX\tGINI\tNO_CANDIDATE")
+	for _, r := range results {
+		printMetrics(w, r.Strategy, "(all)", r.Metrics)
+		for _, team := range r.Teams() {
+			printMetrics(w, r.Strategy, team, *r.ByTeam[team])
+		}
+	}
+	w.Flush()
+}
+
+func printMetrics(w io.Writer, strategy, team string, m simulation.Metrics) {
+	fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d/%d/%d/%d\t%.3f\t%.1f%% (%d/%d)\n",
+		strategy, team, m.PullRequests, m.Understaffed,
+		m.Percentile(0), m.Percentile(0.5), m.Percentile(0.9), m.Percentile(1),
+		m.Gini(), 100*m.NoCandidateRate(), m.NoCandidate, m.Slots)
+}
+
+func printPerUser(results []simulation.Result) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(w, "USER\t")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t", r.Strategy)
+	}
+	fmt.Fprintln(w)
+	for _, id := range results[0].Members() {
+		fmt.Fprintf(w, "%s\t", id)
+		for _, r := range results {
+			fmt.Fprintf(w, "%d\t", r.Reviews[id])
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+}
@@ -2,9 +2,12 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
+	"os"
 	"os/signal"
 	"syscall"
+	_ "time/tzdata"
 
 	"pr-reviewer/internal/app"
 	"pr-reviewer/internal/config"
@@ -14,12 +17,22 @@ func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	cfg, err := config.Load()
+	configPath := flag.String("config", "", "path to the YAML config file (default $"+config.ConfigFileEnv+")")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
-	if err := app.Run(ctx, cfg); err != nil {
+	if args := flag.Args(); len(args) > 0 && args[0] == "migrate" {
+		if err := app.Migrate(ctx, cfg, args[1:], os.Stdout); err != nil {
+			log.Fatalf("migrate: %v", err)
+		}
+		return
+	}
+
+	if err := app.Run(ctx, config.NewHolder(cfg, *configPath)); err != nil {
 		log.Fatalf("app exited with error: %v", err)
 	}
 }
@@ -260,10 +260,12 @@ func waitForReady(t *testing.T) {
 		case <-timeout:
 			t.Fatalf("server did not become ready")
 		default:
-			resp, err := http.Get(baseURL + "/team/get?team_name=__ping__")
+			resp, err := http.Get(baseURL + "/readyz")
 			if err == nil {
 				resp.Body.Close()
-				return
+				if resp.StatusCode == http.StatusOK {
+					return
+				}
 			}
 			time.Sleep(500 * time.Millisecond)
 		}
@@ -3,74 +3,205 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"pr-reviewer/internal/config"
+	"pr-reviewer/internal/health"
 	httpapi "pr-reviewer/internal/http"
 	"pr-reviewer/internal/logging"
 	"pr-reviewer/internal/metrics"
-	"pr-reviewer/internal/repositorypostgres"
 	"pr-reviewer/internal/service"
+	"pr-reviewer/internal/tracing"
 )
 
-func Run(ctx context.Context, cfg *config.Config) error {
-	logger := logging.StdLogger{}
+// statsMaxAgeIntervals is how many stats intervals may pass without a
+// successful refresh before readiness reports the gauges as stale; a few
+// missed refreshes are tolerated.
+const statsMaxAgeIntervals = 3
 
-	db, err := repositorypostgres.NewDB(ctx, cfg)
+// Run serves the API until ctx is done. The HTTP and storage settings are
+// taken once at start; SIGHUP reloads the rest through cfgs.
+func Run(ctx context.Context, cfgs *config.Holder) error {
+	cfg := cfgs.Current()
+	level := new(slog.LevelVar)
+	setLogLevel(level, cfg.Log.Level)
+	logger := logging.New(os.Stderr, level)
+
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
 	if err != nil {
 		return err
 	}
-	defer db.Close()
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			logger.Error("Tracing shutdown error", "error", err)
+		}
+	}()
 
-	if err := repositorypostgres.ApplyMigrations(ctx, db, "migrations", logger); err != nil {
+	st, err := openStorage(ctx, cfg, logger)
+	if err != nil {
 		return err
 	}
+	defer st.close()
 
-	teamRepo := repositorypostgres.NewTeamRepository(db)
-	userRepo := repositorypostgres.NewUserRepository(db)
-	prRepo := repositorypostgres.NewPullRequestRepository(db)
-	uow := repositorypostgres.NewUnitOfWork(db)
-	httpMetrics, bizMetrics := metrics.New()
-
-	teamService := service.NewTeamService(teamRepo)
-	userService := service.NewUserService(userRepo, prRepo)
-	prService := service.NewPullRequestService(prRepo, userRepo, uow, bizMetrics)
-
-	router := httpapi.NewRouter(teamService, userService, prService, httpMetrics)
-
-	addr := cfg.HTTPPort
-	if !strings.HasPrefix(addr, ":") {
-		addr = ":" + addr
+	probes := st.healthChecker()
+	if interval := cfg.Metrics.StatsInterval; interval > 0 {
+		openPRs := metrics.NewOpenPullRequests(st.stats, func() int { return cfgs.Current().Selection.Reviewers })
+		if err := openPRs.Register(); err != nil {
+			return err
+		}
+		probes.Add("stats_refresh", openPRs.Check(statsMaxAgeIntervals*interval))
+		go openPRs.Run(ctx, interval, logger)
 	}
+	router := newHandler(cfgs, st, probes, logger)
+	go reloadOnSIGHUP(ctx, cfgs, logger, level)
 
 	server := &http.Server{
-		Addr:    addr,
-		Handler: router,
+		Addr:              listenAddr(cfg.HTTP.Port),
+		Handler:           router,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+	}
+	// No write timeout: CPU profiles and traces stream for as long as the
+	// caller asks.
+	adminServer := &http.Server{
+		Addr:              cfg.Admin.Addr,
+		Handler:           newAdminHandler(cfgs, probes),
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
 	}
 
-	errCh := make(chan error, 1)
-	go func() {
-		logger.Info("HTTP server listening on %s", addr)
-		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			errCh <- err
+	errCh := make(chan error, 2)
+	serve := func(name string, s *http.Server) {
+		logger.Info(name+" listening", "addr", s.Addr)
+		if err := s.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- fmt.Errorf("%s: %w", name, err)
 		}
-	}()
+	}
+	go serve("HTTP server", server)
+	go serve("Admin server", adminServer)
 
 	select {
 	case <-ctx.Done():
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		// Fail readiness first so that load balancers stop routing here
+		// while in-flight requests drain.
+		probes.Shutdown()
+		if cfg.HTTP.ShutdownDelay > 0 {
+			logger.Info("Reporting not ready before shutdown", "delay", cfg.HTTP.ShutdownDelay)
+			time.Sleep(cfg.HTTP.ShutdownDelay)
+		}
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
 		defer cancel()
-		logger.Info("Shutting down HTTP server")
-		if err := server.Shutdown(shutdownCtx); err != nil {
-			logger.Error("HTTP server shutdown error: %v", err)
+		logger.Info("Shutting down HTTP servers")
+		if err := shutdown(shutdownCtx, server, adminServer); err != nil {
+			logger.Error("HTTP server shutdown error", "error", err)
 			return err
 		}
-		logger.Info("HTTP server stopped gracefully")
+		logger.Info("HTTP servers stopped gracefully")
 		return nil
 	case err := <-errCh:
-		logger.Error("HTTP server failed: %v", err)
+		logger.Error("HTTP server failed", "error", err)
+		_ = server.Close()
+		_ = adminServer.Close()
 		return err
 	}
 }
+
+func listenAddr(port string) string {
+	if strings.HasPrefix(port, ":") {
+		return port
+	}
+	return ":" + port
+}
+
+// shutdown stops the servers one after another, so that the admin server
+// keeps answering probes and scrapes while the API drains.
+func shutdown(ctx context.Context, servers ...*http.Server) error {
+	var errs []error
+	for _, s := range servers {
+		errs = append(errs, s.Shutdown(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+func reloadOnSIGHUP(ctx context.Context, cfgs *config.Holder, logger logging.Logger, level *slog.LevelVar) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			restartRequired, err := cfgs.Reload()
+			if err != nil {
+				logger.Error("Config reload failed, keeping the active config", "error", err)
+				continue
+			}
+			setLogLevel(level, cfgs.Current().Log.Level)
+			logger.Info("Config reloaded")
+			if len(restartRequired) > 0 {
+				logger.Info("Some changes take effect after a restart", "sections", restartRequired)
+			}
+		}
+	}
+}
+
+// setLogLevel applies a level that config validation has already accepted.
+func setLogLevel(level *slog.LevelVar, name string) {
+	if l, err := logging.ParseLevel(name); err == nil {
+		level.Set(l)
+	}
+}
+
+func newAdminHandler(cfgs *config.Holder, probes *health.Checker) http.Handler {
+	return httpapi.NewAdminRouter(probes, func() (any, error) { return cfgs.Current().Redacted() })
+}
+
+func newHandler(cfgs *config.Holder, st *storage, probes *health.Checker, logger *slog.Logger) http.Handler {
+	cfg := cfgs.Current()
+	httpMetrics, bizMetrics := metrics.New()
+
+	teamService := service.NewTracedTeamService(service.NewTeamService(st.teams, st.users, st.uow))
+	userService := service.NewTracedUserService(service.NewUserService(st.users, st.prs))
+	prOpts := []service.PullRequestServiceOption{
+		service.WithSelectionPolicySource(func() service.SelectionPolicy {
+			selection := cfgs.Current().Selection
+			return service.SelectionPolicy{
+				PreferWorkingHours: selection.PreferWorkingHours,
+				PairingWindow:      selection.PairingWindow,
+				Reviewers:          selection.Reviewers,
+			}
+		}),
+	}
+	if cfg.Features.CodeOwners {
+		prOpts = append(prOpts, service.WithCodeOwners(st.codeOwners))
+	}
+	prService := service.NewTracedPullRequestService(service.NewPullRequestService(st.prs, st.users, st.teams, st.uow, bizMetrics, prOpts...))
+	codeOwnerService := service.NewTracedCodeOwnerService(service.NewCodeOwnerService(st.codeOwners, st.users, st.teams, st.uow))
+
+	routerOpts := []httpapi.RouterOption{
+		httpapi.WithAssignmentPreview(cfg.Features.AssignmentPreview),
+		httpapi.WithRateLimit(func() httpapi.RateLimit {
+			limit := cfgs.Current().RateLimit
+			return httpapi.RateLimit{RequestsPerSecond: limit.RequestsPerSecond, Burst: limit.Burst}
+		}),
+		httpapi.WithLogger(logger),
+		httpapi.WithHealthChecker(probes),
+	}
+	if cfg.Auth.Enabled {
+		routerOpts = append(routerOpts, httpapi.WithAuthTokens(cfg.Auth.Tokens))
+	}
+	return httpapi.NewRouter(teamService, userService, prService, codeOwnerService, httpMetrics, routerOpts...)
+}
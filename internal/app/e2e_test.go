@@ -0,0 +1,634 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	"pr-reviewer/internal/config"
+	"pr-reviewer/internal/health"
+	"pr-reviewer/internal/repositorypostgres"
+	"pr-reviewer/internal/tracing/tracingtest"
+)
+
+// The in-process end-to-end suite drives the real router, services and the
+// in-memory storage over HTTP, without Postgres. The concurrency tests also
+// run against Postgres when TEST_DB_DSN is set.
+
+type e2eClient struct {
+	t   *testing.T
+	url string
+	// admin talks to the admin router of the same instance.
+	admin *e2eClient
+}
+
+func newE2E(t *testing.T) *e2eClient {
+	t.Helper()
+	return newE2EWithConfig(t, config.NewHolder(config.Default(), ""))
+}
+
+func newE2EWithConfig(t *testing.T, cfgs *config.Holder) *e2eClient {
+	t.Helper()
+	st := newMemoryStorage()
+	return newE2EWithStorage(t, cfgs, st, st.healthChecker())
+}
+
+func newE2EWithStorage(t *testing.T, cfgs *config.Holder, st *storage, probes *health.Checker) *e2eClient {
+	t.Helper()
+	server := httptest.NewServer(newHandler(cfgs, st, probes, nil))
+	t.Cleanup(server.Close)
+	admin := httptest.NewServer(newAdminHandler(cfgs, probes))
+	t.Cleanup(admin.Close)
+	return &e2eClient{t: t, url: server.URL, admin: &e2eClient{t: t, url: admin.URL}}
+}
+
+// forEachBackend runs fn against the in-memory storage, a fresh SQLite file
+// and, when TEST_DB_DSN names a disposable database, against Postgres. The
+// Postgres run truncates every table first.
+func forEachBackend(t *testing.T, fn func(t *testing.T, c *e2eClient)) {
+	t.Run("memory", func(t *testing.T) {
+		fn(t, newE2E(t))
+	})
+	t.Run("sqlite", func(t *testing.T) {
+		cfg := config.Default()
+		cfg.DB.DSN = "sqlite://" + filepath.Join(t.TempDir(), "e2e.db")
+		st, err := openStorage(context.Background(), cfg, slog.New(slog.DiscardHandler))
+		if err != nil {
+			t.Fatalf("open storage: %v", err)
+		}
+		t.Cleanup(func() { _ = st.close() })
+
+		fn(t, newE2EWithStorage(t, config.NewHolder(cfg, ""), st, st.healthChecker()))
+	})
+	t.Run("postgres", func(t *testing.T) {
+		dsn := os.Getenv("TEST_DB_DSN")
+		if dsn == "" {
+			t.Skip("TEST_DB_DSN is not set")
+		}
+		ctx := context.Background()
+		cfg := config.Default()
+		cfg.DB.DSN = dsn
+		st, err := openStorage(ctx, cfg, slog.New(slog.DiscardHandler))
+		if err != nil {
+			t.Fatalf("open storage: %v", err)
+		}
+		t.Cleanup(func() { _ = st.close() })
+
+		db, err := repositorypostgres.NewDB(ctx, cfg)
+		if err != nil {
+			t.Fatalf("connect: %v", err)
+		}
+		defer db.Close()
+		if _, err := db.SQL.ExecContext(ctx, `
+			TRUNCATE teams, users, pull_requests, pull_request_reviewers, user_unavailability,
+				team_fallbacks, code_owner_rules, user_tags, pull_request_tags
+			RESTART IDENTITY CASCADE
+		`); err != nil {
+			t.Fatalf("truncate: %v", err)
+		}
+
+		fn(t, newE2EWithStorage(t, config.NewHolder(cfg, ""), st, st.healthChecker()))
+	})
+}
+
+func (c *e2eClient) do(method, path string, body any, out any) int {
+	c.t.Helper()
+	status, _ := c.doWithHeader(method, path, nil, body, out)
+	return status
+}
+
+// doWithHeader sends extra request headers and returns the response headers
+// along with the status.
+func (c *e2eClient) doWithHeader(method, path string, header http.Header, body any, out any) (int, http.Header) {
+	c.t.Helper()
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			c.t.Fatalf("marshal: %v", err)
+		}
+	}
+
+	req, err := http.NewRequest(method, c.url+path, bytes.NewReader(payload))
+	if err != nil {
+		c.t.Fatalf("new request: %v", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			c.t.Fatalf("%s %s: decode: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode, resp.Header
+}
+
+func (c *e2eClient) expect(status int, method, path string, body any, out any) {
+	c.t.Helper()
+	if got := c.do(method, path, body, out); got != status {
+		c.t.Fatalf("%s %s: expected %d, got %d", method, path, status, got)
+	}
+}
+
+func (c *e2eClient) expectError(status int, code, method, path string, body any) {
+	c.t.Helper()
+	var resp struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	c.expect(status, method, path, body, &resp)
+	if resp.Error.Code != code {
+		c.t.Fatalf("%s %s: expected code %s, got %s", method, path, code, resp.Error.Code)
+	}
+}
+
+func (c *e2eClient) addTeam(name string, memberIDs ...string) {
+	c.t.Helper()
+	members := make([]map[string]any, 0, len(memberIDs))
+	for _, id := range memberIDs {
+		members = append(members, map[string]any{"user_id": id, "username": id, "is_active": true})
+	}
+	c.expect(http.StatusCreated, "POST", "/team/add", map[string]any{"team_name": name, "members": members}, nil)
+}
+
+type e2ePR struct {
+	ID                 string            `json:"pull_request_id"`
+	AuthorID           string            `json:"author_id"`
+	Status             string            `json:"status"`
+	AssignedReviewers  []string          `json:"assigned_reviewers"`
+	CrossTeamReviewers map[string]string `json:"cross_team_reviewers"`
+	Understaffed       bool              `json:"understaffed"`
+	Version            int64             `json:"version"`
+}
+
+func (c *e2eClient) createPR(id, author string) e2ePR {
+	c.t.Helper()
+	var resp struct {
+		PR e2ePR `json:"pr"`
+	}
+	c.expect(http.StatusCreated, "POST", "/pullRequest/create", map[string]any{
+		"pull_request_id":   id,
+		"pull_request_name": id,
+		"author_id":         author,
+	}, &resp)
+	return resp.PR
+}
+
+func TestE2E_HappyPathAndErrors(t *testing.T) {
+	c := newE2E(t)
+	c.addTeam("team-a", "u1", "u2", "u3")
+	c.expectError(http.StatusBadRequest, "TEAM_EXISTS", "POST", "/team/add", map[string]any{"team_name": "team-a", "members": []any{}})
+
+	pr := c.createPR("pr-1", "u1")
+	if len(pr.AssignedReviewers) != 2 || slices.Contains(pr.AssignedReviewers, "u1") {
+		t.Fatalf("unexpected reviewers: %v", pr.AssignedReviewers)
+	}
+	c.expectError(http.StatusConflict, "PR_EXISTS", "POST", "/pullRequest/create", map[string]any{
+		"pull_request_id": "pr-1", "pull_request_name": "again", "author_id": "u1",
+	})
+
+	// Both teammates already review pr-1, so there is nobody to take over.
+	c.expectError(http.StatusConflict, "NO_CANDIDATE", "POST", "/pullRequest/reassign", map[string]any{
+		"pull_request_id": "pr-1", "old_user_id": "u2",
+	})
+
+	c.addTeam("team-b", "b1")
+	c.expect(http.StatusOK, "POST", "/team/setFallbackTeams", map[string]any{"team_name": "team-a", "fallback_teams": []string{"team-b"}}, nil)
+
+	var reassigned struct {
+		PR         e2ePR  `json:"pr"`
+		ReplacedBy string `json:"replaced_by"`
+	}
+	c.expect(http.StatusOK, "POST", "/pullRequest/reassign", map[string]any{"pull_request_id": "pr-1", "old_user_id": "u2"}, &reassigned)
+	if reassigned.ReplacedBy != "b1" || reassigned.PR.CrossTeamReviewers["b1"] != "team-b" {
+		t.Fatalf("expected b1 borrowed from team-b, got %+v", reassigned)
+	}
+
+	var reviews struct {
+		PullRequests []struct {
+			ID string `json:"pull_request_id"`
+		} `json:"pull_requests"`
+	}
+	c.expect(http.StatusOK, "GET", "/users/getReview?user_id=b1", nil, &reviews)
+	if len(reviews.PullRequests) != 1 || reviews.PullRequests[0].ID != "pr-1" {
+		t.Fatalf("expected pr-1 in b1 reviews, got %+v", reviews)
+	}
+
+	var merged struct {
+		PR e2ePR `json:"pr"`
+	}
+	c.expect(http.StatusOK, "POST", "/pullRequest/merge", map[string]any{"pull_request_id": "pr-1"}, &merged)
+	c.expect(http.StatusOK, "POST", "/pullRequest/merge", map[string]any{"pull_request_id": "pr-1"}, &merged)
+	if merged.PR.Status != "MERGED" {
+		t.Fatalf("expected idempotent merge, got %+v", merged.PR)
+	}
+	c.expectError(http.StatusConflict, "PR_MERGED", "POST", "/pullRequest/reassign", map[string]any{"pull_request_id": "pr-1", "old_user_id": "b1"})
+	c.expectError(http.StatusNotFound, "NOT_FOUND", "POST", "/pullRequest/create", map[string]any{
+		"pull_request_id": "pr-ghost", "pull_request_name": "ghost", "author_id": "ghost",
+	})
+}
+
+func TestE2E_ConditionalUpdates(t *testing.T) {
+	c := newE2E(t)
+	c.addTeam("team-a", "u1", "u2", "u3", "u4")
+	pr := c.createPR("pr-1", "u1")
+
+	ifMatch := func(etag string) http.Header {
+		return http.Header{"If-Match": {etag}}
+	}
+	var read struct {
+		PR e2ePR `json:"pr"`
+	}
+	status, header := c.doWithHeader("GET", "/pullRequest/get?pull_request_id=pr-1", nil, nil, &read)
+	if status != http.StatusOK || header.Get("ETag") != `"1"` || read.PR.Version != 1 {
+		t.Fatalf("get: expected 200 with version 1, got %d, ETag %q, %+v", status, header.Get("ETag"), read.PR)
+	}
+
+	var reassigned struct {
+		PR e2ePR `json:"pr"`
+	}
+	status, header = c.doWithHeader("POST", "/pullRequest/reassign", ifMatch(header.Get("ETag")), map[string]any{
+		"pull_request_id": "pr-1", "old_user_id": pr.AssignedReviewers[0],
+	}, &reassigned)
+	if status != http.StatusOK || header.Get("ETag") != `"2"` || reassigned.PR.Version != 2 {
+		t.Fatalf("reassign: expected 200 with version 2, got %d, ETag %q, %+v", status, header.Get("ETag"), reassigned.PR)
+	}
+
+	var failed struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+	}
+	status, _ = c.doWithHeader("POST", "/pullRequest/merge", ifMatch(`"1"`), map[string]any{"pull_request_id": "pr-1"}, &failed)
+	if status != http.StatusPreconditionFailed || failed.Error.Code != "VERSION_MISMATCH" {
+		t.Fatalf("stale merge: expected 412 VERSION_MISMATCH, got %d %s", status, failed.Error.Code)
+	}
+
+	// A reviewer's list carries the versions too.
+	var review struct {
+		PullRequests []struct {
+			ID      string `json:"pull_request_id"`
+			Version int64  `json:"version"`
+		} `json:"pull_requests"`
+	}
+	c.expect(http.StatusOK, "GET", "/users/getReview?user_id="+reassigned.PR.AssignedReviewers[0], nil, &review)
+	if len(review.PullRequests) != 1 || review.PullRequests[0].Version != 2 {
+		t.Fatalf("getReview: expected pr-1 at version 2, got %+v", review.PullRequests)
+	}
+
+	status, header = c.doWithHeader("POST", "/pullRequest/merge", ifMatch(header.Get("ETag")), map[string]any{"pull_request_id": "pr-1"}, nil)
+	if status != http.StatusOK || header.Get("ETag") != `"3"` {
+		t.Fatalf("merge: expected 200 with ETag \"3\", got %d, %q", status, header.Get("ETag"))
+	}
+	// A retried merge carrying the new version is still idempotent.
+	status, _ = c.doWithHeader("POST", "/pullRequest/merge", ifMatch(`"3"`), map[string]any{"pull_request_id": "pr-1"}, nil)
+	if status != http.StatusOK {
+		t.Fatalf("repeated merge: expected 200, got %d", status)
+	}
+}
+
+func TestE2E_CapacityAndPreview(t *testing.T) {
+	c := newE2E(t)
+	c.addTeam("team-a", "u1", "u2", "u3", "u4")
+	c.expect(http.StatusOK, "POST", "/team/setDefaultReviewCapacity", map[string]any{"team_name": "team-a", "default_review_capacity": 1}, nil)
+	c.expect(http.StatusOK, "POST", "/users/setIsActive", map[string]any{"user_id": "u4", "is_active": false}, nil)
+
+	first := c.createPR("pr-1", "u1")
+	if first.Understaffed {
+		t.Fatalf("first pull request should be fully staffed: %+v", first)
+	}
+
+	var preview struct {
+		AssignedReviewers []string `json:"assigned_reviewers"`
+		Understaffed      bool     `json:"understaffed"`
+		Rejected          []struct {
+			UserID string `json:"user_id"`
+			Reason string `json:"reason"`
+		} `json:"rejected"`
+	}
+	c.expect(http.StatusOK, "POST", "/pullRequest/previewAssignment", map[string]any{"author_id": "u1"}, &preview)
+	if len(preview.AssignedReviewers) != 0 || !preview.Understaffed {
+		t.Fatalf("expected nobody available, got %+v", preview)
+	}
+	reasons := make(map[string]string)
+	for _, r := range preview.Rejected {
+		reasons[r.UserID] = r.Reason
+	}
+	if reasons["u1"] != "author" || reasons["u2"] != "at_capacity" || reasons["u3"] != "at_capacity" || reasons["u4"] != "inactive" {
+		t.Fatalf("unexpected rejection reasons: %v", reasons)
+	}
+
+	// The preview must not have written anything.
+	var reviews struct {
+		PullRequests []any `json:"pull_requests"`
+	}
+	c.expect(http.StatusOK, "GET", "/users/getReview?user_id=u2", nil, &reviews)
+	if len(reviews.PullRequests) != 1 {
+		t.Fatalf("expected only pr-1 for u2, got %+v", reviews)
+	}
+	second := c.createPR("pr-2", "u1")
+	if !second.Understaffed || len(second.AssignedReviewers) != 0 {
+		t.Fatalf("expected understaffed pull request, got %+v", second)
+	}
+}
+
+func TestE2E_ConcurrentCreatesKeepEveryAssignment(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, c *e2eClient) {
+		c.addTeam("team-a", "u1", "u2", "u3", "u4", "u5")
+
+		const prs = 30
+		var wg sync.WaitGroup
+		for i := 0; i < prs; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.createPR(fmt.Sprintf("pr-%d", i), fmt.Sprintf("u%d", i%5+1))
+			}()
+		}
+		wg.Wait()
+
+		var team struct {
+			Members []struct {
+				ID   string `json:"user_id"`
+				Load struct {
+					OpenReviews int `json:"open_reviews"`
+				} `json:"load"`
+			} `json:"members"`
+		}
+		c.expect(http.StatusOK, "GET", "/team/get?team_name=team-a", nil, &team)
+		total := 0
+		for _, m := range team.Members {
+			total += m.Load.OpenReviews
+		}
+		if total != 2*prs {
+			t.Fatalf("expected %d open reviews across the team, got %d", 2*prs, total)
+		}
+	})
+}
+
+func TestE2E_ConcurrentDuplicateCreates(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, c *e2eClient) {
+		c.addTeam("team-a", "u1", "u2", "u3", "u4")
+
+		const racers = 10
+		statuses := make([]int, racers)
+		codes := make([]string, racers)
+		var wg sync.WaitGroup
+		for i := 0; i < racers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var resp struct {
+					Error struct {
+						Code string `json:"code"`
+					} `json:"error"`
+				}
+				statuses[i] = c.do("POST", "/pullRequest/create", map[string]any{
+					"pull_request_id": "pr-1", "pull_request_name": "pr-1", "author_id": "u1",
+				}, &resp)
+				codes[i] = resp.Error.Code
+			}()
+		}
+		wg.Wait()
+
+		created := 0
+		for i, status := range statuses {
+			switch {
+			case status == http.StatusCreated:
+				created++
+			case status != http.StatusConflict || codes[i] != "PR_EXISTS":
+				t.Fatalf("unexpected response: %d %s", status, codes[i])
+			}
+		}
+		if created != 1 {
+			t.Fatalf("expected exactly one create to succeed, got %d", created)
+		}
+	})
+}
+
+func TestE2E_ConcurrentReassignsKeepInvariants(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, c *e2eClient) {
+		c.addTeam("team-a", "u1", "u2", "u3", "u4", "u5", "u6", "u7", "u8")
+		pr := c.createPR("pr-1", "u1")
+		old := pr.AssignedReviewers[0]
+
+		type reassignResult struct {
+			status     int
+			code       string
+			replacedBy string
+		}
+		reassignAll := func(oldIDs []string) []reassignResult {
+			results := make([]reassignResult, len(oldIDs))
+			var wg sync.WaitGroup
+			for i, oldID := range oldIDs {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					var resp struct {
+						ReplacedBy string `json:"replaced_by"`
+						Error      struct {
+							Code string `json:"code"`
+						} `json:"error"`
+					}
+					status := c.do("POST", "/pullRequest/reassign", map[string]any{"pull_request_id": "pr-1", "old_user_id": oldID}, &resp)
+					results[i] = reassignResult{status: status, code: resp.Error.Code, replacedBy: resp.ReplacedBy}
+				}()
+			}
+			wg.Wait()
+			return results
+		}
+
+		// Only one of many racing requests may take the same reviewer off.
+		const racers = 20
+		succeeded := 0
+		for _, r := range reassignAll(slices.Repeat([]string{old}, racers)) {
+			switch {
+			case r.status == http.StatusOK:
+				succeeded++
+			case r.status != http.StatusConflict || r.code != "NOT_ASSIGNED":
+				t.Fatalf("unexpected response: %+v", r)
+			}
+		}
+		if succeeded != 1 {
+			t.Fatalf("expected exactly one reassignment of %s, got %d", old, succeeded)
+		}
+
+		var oldIDs []string
+		for i := 0; i < racers; i++ {
+			oldIDs = append(oldIDs, fmt.Sprintf("u%d", i%8+1))
+		}
+		for _, r := range reassignAll(oldIDs) {
+			if r.status != http.StatusOK && (r.status != http.StatusConflict || r.code != "NOT_ASSIGNED") {
+				t.Fatalf("unexpected response: %+v", r)
+			}
+		}
+
+		var team struct {
+			Members []struct {
+				ID   string `json:"user_id"`
+				Load struct {
+					OpenReviews int `json:"open_reviews"`
+				} `json:"load"`
+			} `json:"members"`
+		}
+		c.expect(http.StatusOK, "GET", "/team/get?team_name=team-a", nil, &team)
+		var reviewing []string
+		for _, m := range team.Members {
+			switch m.Load.OpenReviews {
+			case 0:
+			case 1:
+				reviewing = append(reviewing, m.ID)
+			default:
+				t.Fatalf("%s reviews pr-1 %d times", m.ID, m.Load.OpenReviews)
+			}
+		}
+		if len(reviewing) != 2 || slices.Contains(reviewing, "u1") {
+			t.Fatalf("expected two distinct reviewers other than the author, got %v", reviewing)
+		}
+	})
+}
+
+func TestE2E_ConfigReload(t *testing.T) {
+	t.Setenv(config.ConfigFileEnv, "")
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+	write("db:\n  dsn: postgres://app:hunter2@db/pr\nselection:\n  reviewers: 2\n")
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	cfgs := config.NewHolder(cfg, path)
+	c := newE2EWithConfig(t, cfgs)
+	c.addTeam("team-a", "u1", "u2", "u3", "u4")
+
+	if pr := c.createPR("pr-1", "u1"); len(pr.AssignedReviewers) != 2 {
+		t.Fatalf("expected two reviewers, got %v", pr.AssignedReviewers)
+	}
+
+	write("db:\n  dsn: postgres://app:hunter2@db/pr\nselection:\n  reviewers: 3\n")
+	if _, err := cfgs.Reload(); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if pr := c.createPR("pr-2", "u1"); len(pr.AssignedReviewers) != 3 {
+		t.Fatalf("expected three reviewers after reload, got %v", pr.AssignedReviewers)
+	}
+
+	var view struct {
+		DB struct {
+			DSN string `json:"dsn"`
+		} `json:"db"`
+		Selection struct {
+			Reviewers int `json:"reviewers"`
+		} `json:"selection"`
+	}
+	c.admin.expect(http.StatusOK, "GET", "/admin/config", nil, &view)
+	if view.Selection.Reviewers != 3 || view.DB.DSN != "postgres://app:REDACTED@db/pr" {
+		t.Fatalf("unexpected config view: %+v", view)
+	}
+	if status := c.do("GET", "/admin/config", nil, nil); status != http.StatusNotFound {
+		t.Fatalf("expected the config view to be served on the admin router only, got %d", status)
+	}
+}
+
+func TestE2E_TraceSpans(t *testing.T) {
+	spans := tracingtest.Record(t)
+	c := newE2E(t)
+	c.addTeam("team-a", "u1", "u2", "u3", "u4")
+	pr := c.createPR("pr-1", "u1")
+	spans.Reset()
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	status, _ := c.doWithHeader("POST", "/pullRequest/reassign", http.Header{
+		"Traceparent": {"00-" + traceID + "-00f067aa0ba902b7-01"},
+	}, map[string]any{"pull_request_id": "pr-1", "old_user_id": pr.AssignedReviewers[0]}, nil)
+	if status != http.StatusOK {
+		t.Fatalf("reassign: expected 200, got %d", status)
+	}
+
+	server := tracingtest.Find(t, spans, "POST /pullRequest/reassign")
+	if server.SpanContext.TraceID().String() != traceID || !server.Parent.IsRemote() {
+		t.Fatalf("expected the server span to continue the caller's trace, got trace %s", server.SpanContext.TraceID())
+	}
+	call := tracingtest.Find(t, spans, "PullRequestService.Reassign")
+	if call.Parent.SpanID() != server.SpanContext.SpanID() {
+		t.Fatalf("expected the service span under the server span")
+	}
+	selection := tracingtest.Find(t, spans, "select replacement")
+	if selection.Parent.SpanID() != call.SpanContext.SpanID() {
+		t.Fatalf("expected reviewer selection under the service span")
+	}
+}
+
+func TestE2E_HealthProbes(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.Default()
+	cfg.DB.DSN = "sqlite://" + filepath.Join(t.TempDir(), "probes.db")
+	cfg.Auth = config.AuthConfig{Enabled: true, Tokens: []string{"secret"}}
+	st, err := openSQLiteStorage(ctx, cfg.DB.DSN, slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatalf("open storage: %v", err)
+	}
+	t.Cleanup(func() { _ = st.close() })
+	probes := st.healthChecker()
+	c := newE2EWithStorage(t, config.NewHolder(cfg, ""), st, probes)
+
+	type report struct {
+		Status string `json:"status"`
+		Checks map[string]struct {
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		} `json:"checks"`
+	}
+
+	// Probes need no token even with authentication on.
+	var live report
+	c.expect(http.StatusOK, "GET", "/healthz", nil, &live)
+	if live.Status != "ok" {
+		t.Fatalf("unexpected liveness %+v", live)
+	}
+	var started report
+	c.expect(http.StatusOK, "GET", "/startupz", nil, &started)
+
+	var ready report
+	c.expect(http.StatusOK, "GET", "/readyz", nil, &ready)
+	if ready.Status != "ok" || ready.Checks["db"].Status != "ok" || ready.Checks["migrations"].Status != "ok" {
+		t.Fatalf("unexpected readiness %+v", ready)
+	}
+
+	probes.Shutdown()
+	c.expect(http.StatusServiceUnavailable, "GET", "/readyz", nil, &ready)
+	if ready.Status != "fail" || ready.Checks["shutdown"].Status != "fail" || ready.Checks["db"].Status != "ok" {
+		t.Fatalf("unexpected readiness during shutdown %+v", ready)
+	}
+	c.expect(http.StatusOK, "GET", "/healthz", nil, nil)
+	c.expect(http.StatusOK, "GET", "/startupz", nil, nil)
+
+	if err := st.close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	c.expect(http.StatusServiceUnavailable, "GET", "/readyz", nil, &ready)
+	if ready.Checks["db"].Status != "fail" || ready.Checks["db"].Error != "" {
+		t.Fatalf("expected the db check to fail once the pool is closed, without the driver error, got %+v", ready)
+	}
+}
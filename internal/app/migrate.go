@@ -0,0 +1,106 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"pr-reviewer/internal/config"
+	"pr-reviewer/internal/logging"
+	"pr-reviewer/internal/repositorypostgres"
+	"pr-reviewer/migrations"
+)
+
+const migrateUsage = "usage: pr-reviewer migrate up|down|status|to <version>"
+
+type migrateCommand struct {
+	action  string
+	version int64
+}
+
+func parseMigrateArgs(args []string) (migrateCommand, error) {
+	if len(args) == 0 {
+		return migrateCommand{}, errors.New(migrateUsage)
+	}
+
+	cmd := migrateCommand{action: args[0]}
+	switch cmd.action {
+	case "up", "down", "status":
+		if len(args) != 1 {
+			return migrateCommand{}, errors.New(migrateUsage)
+		}
+	case "to":
+		if len(args) != 2 {
+			return migrateCommand{}, errors.New(migrateUsage)
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || version < 0 {
+			return migrateCommand{}, fmt.Errorf("invalid version %q: %s", args[1], migrateUsage)
+		}
+		cmd.version = version
+	default:
+		return migrateCommand{}, fmt.Errorf("unknown migrate command %q: %s", cmd.action, migrateUsage)
+	}
+	return cmd, nil
+}
+
+// Migrate runs the migrate subcommand against the PostgreSQL database from
+// cfg without starting the HTTP server.
+func Migrate(ctx context.Context, cfg *config.Config, args []string, out io.Writer) error {
+	cmd, err := parseMigrateArgs(args)
+	if err != nil {
+		return err
+	}
+	if config.DBScheme(cfg.DB.DSN) != config.SchemePostgres {
+		return errors.New("migrate supports PostgreSQL only; other storages migrate themselves on start")
+	}
+
+	level, err := logging.ParseLevel(cfg.Log.Level)
+	if err != nil {
+		return err
+	}
+	logger := logging.New(os.Stderr, level)
+	db, err := repositorypostgres.NewDB(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := repositorypostgres.NewMigrator(db, migrations.FS, logger)
+	if err != nil {
+		return err
+	}
+
+	switch cmd.action {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		return migrator.Down(ctx)
+	case "to":
+		return migrator.To(ctx, cmd.version)
+	default:
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		return printMigrationStatus(out, statuses)
+	}
+}
+
+func printMigrationStatus(out io.Writer, statuses []repositorypostgres.MigrationStatus) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,48 @@
+package app
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"pr-reviewer/internal/repositorypostgres"
+)
+
+func TestParseMigrateArgs(t *testing.T) {
+	valid := map[string]migrateCommand{
+		"up":     {action: "up"},
+		"down":   {action: "down"},
+		"status": {action: "status"},
+		"to 0":   {action: "to"},
+		"to 7":   {action: "to", version: 7},
+	}
+	for args, want := range valid {
+		got, err := parseMigrateArgs(strings.Fields(args))
+		if err != nil || got != want {
+			t.Fatalf("%q: expected %+v, got %+v, %v", args, want, got, err)
+		}
+	}
+
+	for _, args := range []string{"", "sideways", "up 3", "to", "to x", "to -1", "to 1 2"} {
+		if _, err := parseMigrateArgs(strings.Fields(args)); err == nil {
+			t.Fatalf("%q: expected an error", args)
+		}
+	}
+}
+
+func TestPrintMigrationStatus(t *testing.T) {
+	appliedAt := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	if err := printMigrationStatus(&buf, []repositorypostgres.MigrationStatus{
+		{Version: 1, Name: "0001_init.up.sql", AppliedAt: &appliedAt},
+		{Version: 2, Name: "0002_indexes.up.sql"},
+	}); err != nil {
+		t.Fatalf("print: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[1], "2025-03-03T12:00:00Z") || !strings.HasSuffix(lines[2], "pending") {
+		t.Fatalf("unexpected output:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,149 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"pr-reviewer/internal/config"
+	"pr-reviewer/internal/health"
+	"pr-reviewer/internal/logging"
+	"pr-reviewer/internal/metrics"
+	"pr-reviewer/internal/repository"
+	"pr-reviewer/internal/repositorymemory"
+	"pr-reviewer/internal/repositorypostgres"
+	"pr-reviewer/internal/repositorysqlite"
+	"pr-reviewer/migrations"
+)
+
+// storage is one repository backend, chosen by the scheme of DB_DSN.
+type storage struct {
+	teams      repository.TeamRepository
+	users      repository.UserRepository
+	prs        repository.PullRequestRepository
+	codeOwners repository.CodeOwnerRepository
+	stats      repository.StatsRepository
+	uow        repository.UnitOfWork
+	close      func() error
+	// ping and pendingMigrations back the readiness checks; the in-memory
+	// storage has neither.
+	ping              func(ctx context.Context) error
+	pendingMigrations func(ctx context.Context) (int, error)
+}
+
+// healthChecker returns the readiness checks for s.
+func (s *storage) healthChecker() *health.Checker {
+	checker := health.NewChecker()
+	if s.ping != nil {
+		checker.Add("db", s.ping)
+	}
+	if s.pendingMigrations != nil {
+		checker.Add("migrations", func(ctx context.Context) error {
+			pending, err := s.pendingMigrations(ctx)
+			if err != nil {
+				return err
+			}
+			if pending > 0 {
+				return fmt.Errorf("%d migrations pending", pending)
+			}
+			return nil
+		})
+	}
+	return checker
+}
+
+func openStorage(ctx context.Context, cfg *config.Config, logger logging.Logger) (*storage, error) {
+	switch config.DBScheme(cfg.DB.DSN) {
+	case config.SchemeMemory:
+		logger.Info("Using in-memory storage, data is lost on restart")
+		return newMemoryStorage(), nil
+	case config.SchemeSQLite:
+		return openSQLiteStorage(ctx, cfg.DB.DSN, logger)
+	}
+
+	db, err := repositorypostgres.NewDB(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	migrator, err := repositorypostgres.NewMigrator(db, migrations.FS, logger)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	if cfg.DB.AutoMigrate {
+		if err := migrator.Up(ctx); err != nil {
+			db.Close()
+			return nil, err
+		}
+	} else {
+		logger.Info("Automatic migrations are disabled, readiness reports pending ones")
+	}
+	unregisterStats, err := metrics.RegisterDBStats(db.SQL)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &storage{
+		teams:      repositorypostgres.NewTeamRepository(db),
+		users:      repositorypostgres.NewUserRepository(db),
+		prs:        repositorypostgres.NewPullRequestRepository(db),
+		codeOwners: repositorypostgres.NewCodeOwnerRepository(db),
+		stats:      repositorypostgres.NewStatsRepository(db),
+		uow:        repositorypostgres.NewUnitOfWork(db),
+		close: func() error {
+			unregisterStats()
+			return db.Close()
+		},
+		ping: db.SQL.PingContext,
+		pendingMigrations: func(ctx context.Context) (int, error) {
+			pending, err := migrator.Pending(ctx)
+			return len(pending), err
+		},
+	}, nil
+}
+
+func openSQLiteStorage(ctx context.Context, dsn string, logger logging.Logger) (*storage, error) {
+	db, err := repositorysqlite.NewDB(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := repositorysqlite.ApplyMigrations(ctx, db, logger); err != nil {
+		db.Close()
+		return nil, err
+	}
+	unregisterStats, err := metrics.RegisterDBStats(db.SQL)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &storage{
+		teams:      repositorysqlite.NewTeamRepository(db),
+		users:      repositorysqlite.NewUserRepository(db),
+		prs:        repositorysqlite.NewPullRequestRepository(db),
+		codeOwners: repositorysqlite.NewCodeOwnerRepository(db),
+		stats:      repositorysqlite.NewStatsRepository(db),
+		uow:        repositorysqlite.NewUnitOfWork(db),
+		close: func() error {
+			unregisterStats()
+			return db.Close()
+		},
+		ping: db.SQL.PingContext,
+		pendingMigrations: func(ctx context.Context) (int, error) {
+			return repositorysqlite.PendingMigrations(ctx, db)
+		},
+	}, nil
+}
+
+func newMemoryStorage() *storage {
+	store := repositorymemory.NewStore()
+	return &storage{
+		teams:      repositorymemory.NewTeamRepository(store),
+		users:      repositorymemory.NewUserRepository(store),
+		prs:        repositorymemory.NewPullRequestRepository(store),
+		codeOwners: repositorymemory.NewCodeOwnerRepository(store),
+		stats:      repositorymemory.NewStatsRepository(store),
+		uow:        repositorymemory.NewUnitOfWork(store),
+		close:      func() error { return nil },
+	}
+}
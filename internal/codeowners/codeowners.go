@@ -0,0 +1,184 @@
+// Package codeowners parses CODEOWNERS files and resolves which rule owns a
+// changed path.
+//
+// Patterns follow the CODEOWNERS dialect of gitignore: a pattern without a
+// slash matches at any depth, a leading or inner slash anchors it to the
+// repository root, a trailing slash matches directory contents only, "*" and
+// "?" never cross a slash and "**" spans any number of directories. When
+// several rules match a path, the last one wins.
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"pr-reviewer/internal/domain"
+)
+
+// Owner is a single owner token: either "@user_id" or "@org/team_name".
+type Owner struct {
+	UserID   string
+	TeamName string
+}
+
+func ParseOwner(token string) (Owner, error) {
+	name, ok := strings.CutPrefix(token, "@")
+	if !ok || name == "" {
+		return Owner{}, fmt.Errorf("owner %q must start with @", token)
+	}
+	if org, team, isTeam := strings.Cut(name, "/"); isTeam {
+		if org == "" || team == "" || strings.Contains(team, "/") {
+			return Owner{}, fmt.Errorf("team owner %q must look like @org/team", token)
+		}
+		return Owner{TeamName: team}, nil
+	}
+	return Owner{UserID: name}, nil
+}
+
+// Parse reads a CODEOWNERS file. Blank lines and comments are skipped; a rule
+// without owners is kept because it clears ownership for its pattern.
+func Parse(content string) ([]domain.CodeOwnerRule, error) {
+	var rules []domain.CodeOwnerRule
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if _, err := compile(fields[0]); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		for _, token := range fields[1:] {
+			if _, err := ParseOwner(token); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+		}
+		rules = append(rules, domain.CodeOwnerRule{
+			Pattern: fields[0],
+			Owners:  fields[1:],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+type Matcher struct {
+	rules    []domain.CodeOwnerRule
+	patterns []*regexp.Regexp
+}
+
+func NewMatcher(rules []domain.CodeOwnerRule) (*Matcher, error) {
+	m := &Matcher{rules: rules}
+	for _, rule := range rules {
+		re, err := compile(rule.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		m.patterns = append(m.patterns, re)
+	}
+	return m, nil
+}
+
+// Match returns the index of the last rule matching path, or -1.
+func (m *Matcher) Match(path string) int {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(m.patterns) - 1; i >= 0; i-- {
+		if m.patterns[i].MatchString(path) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Areas returns the owned rules touched by paths, in the order they are first
+// touched. Paths without an owner are ignored.
+func (m *Matcher) Areas(paths []string) []domain.CodeOwnerRule {
+	seen := make(map[int]struct{})
+	var areas []domain.CodeOwnerRule
+	for _, path := range paths {
+		i := m.Match(path)
+		if i < 0 || len(m.rules[i].Owners) == 0 {
+			continue
+		}
+		if _, ok := seen[i]; ok {
+			continue
+		}
+		seen[i] = struct{}{}
+		areas = append(areas, m.rules[i])
+	}
+	return areas
+}
+
+func compile(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") {
+		return nil, fmt.Errorf("negated pattern %q is not supported", pattern)
+	}
+	if strings.Contains(pattern, "[") || strings.Contains(pattern, "\\") {
+		return nil, fmt.Errorf("character ranges and escapes are not supported in %q", pattern)
+	}
+
+	dirOnly := strings.HasSuffix(pattern, "/")
+	p := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return nil, fmt.Errorf("empty pattern %q", pattern)
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+
+	segments := strings.Split(p, "/")
+	for i, seg := range segments {
+		last := i == len(segments)-1
+		if seg == "**" {
+			if last {
+				b.WriteString(".*")
+			} else {
+				b.WriteString("(?:[^/]+/)*")
+			}
+			continue
+		}
+		if strings.Contains(seg, "**") {
+			return nil, fmt.Errorf("** must be a whole path segment in %q", pattern)
+		}
+		for _, r := range seg {
+			switch r {
+			case '*':
+				b.WriteString("[^/]*")
+			case '?':
+				b.WriteString("[^/]")
+			default:
+				b.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+		if !last {
+			b.WriteString("/")
+		}
+	}
+
+	// A directory owns everything beneath it. Patterns ending in a wildcard
+	// only match the entries they name, so "docs/*" skips nested files.
+	lastSeg := segments[len(segments)-1]
+	switch {
+	case dirOnly:
+		b.WriteString("/.*")
+	case !strings.ContainsAny(lastSeg, "*?"):
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,127 @@
+package codeowners
+
+import (
+	"testing"
+
+	"pr-reviewer/internal/domain"
+)
+
+func TestMatcher_Match(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{pattern: "*", path: "main.go", match: true},
+		{pattern: "*", path: "internal/http/router.go", match: true},
+		{pattern: "*.go", path: "main.go", match: true},
+		{pattern: "*.go", path: "internal/http/router.go", match: true},
+		{pattern: "*.go", path: "README.md", match: false},
+		{pattern: "*.go", path: "go.mod", match: false},
+		{pattern: "/main.go", path: "main.go", match: true},
+		{pattern: "/main.go", path: "cmd/main.go", match: false},
+		{pattern: "main.go", path: "cmd/main.go", match: true},
+		{pattern: "docs/", path: "docs/index.md", match: true},
+		{pattern: "docs/", path: "api/docs/index.md", match: true},
+		{pattern: "docs/", path: "docs", match: false},
+		{pattern: "/docs/", path: "api/docs/index.md", match: false},
+		{pattern: "docs", path: "docs/guide/intro.md", match: true},
+		{pattern: "docs/*", path: "docs/index.md", match: true},
+		{pattern: "docs/*", path: "docs/guide/intro.md", match: false},
+		{pattern: "docs/*", path: "api/docs/index.md", match: false},
+		{pattern: "internal/http", path: "internal/http/router.go", match: true},
+		{pattern: "internal/http", path: "cmd/internal/http/router.go", match: false},
+		{pattern: "**/logs", path: "logs/app.log", match: true},
+		{pattern: "**/logs", path: "deploy/build/logs/app.log", match: true},
+		{pattern: "migrations/**", path: "migrations/0001_init.sql", match: true},
+		{pattern: "migrations/**", path: "migrations/old/0001_init.sql", match: true},
+		{pattern: "internal/**/repo.go", path: "internal/repo.go", match: true},
+		{pattern: "internal/**/repo.go", path: "internal/a/b/repo.go", match: true},
+		{pattern: "internal/**/repo.go", path: "cmd/repo.go", match: false},
+		{pattern: "0?_init.sql", path: "migrations/01_init.sql", match: true},
+		{pattern: "0?_init.sql", path: "migrations/001_init.sql", match: false},
+		{pattern: "a+b.txt", path: "a+b.txt", match: true},
+		{pattern: "a+b.txt", path: "aab.txt", match: false},
+		{pattern: "*.go", path: "/internal/app.go", match: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			m, err := NewMatcher([]domain.CodeOwnerRule{{Pattern: tt.pattern, Owners: []string{"@u1"}}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := m.Match(tt.path) == 0; got != tt.match {
+				t.Fatalf("pattern %q on %q: expected match=%v", tt.pattern, tt.path, tt.match)
+			}
+		})
+	}
+}
+
+func TestMatcher_LastMatchWins(t *testing.T) {
+	rules, err := Parse(`
+# default owners
+*            @lead
+*.sql        @acme/dba
+/docs/       @writer   # docs team
+/docs/api.md
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m, err := NewMatcher(rules)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		rule int
+	}{
+		{path: "main.go", rule: 0},
+		{path: "migrations/0001_init.sql", rule: 1},
+		{path: "docs/schema.sql", rule: 2},
+		{path: "docs/api.md", rule: 3},
+	}
+	for _, tt := range tests {
+		if got := m.Match(tt.path); got != tt.rule {
+			t.Fatalf("%s: expected rule %d, got %d", tt.path, tt.rule, got)
+		}
+	}
+
+	areas := m.Areas([]string{"docs/api.md", "a.sql", "b.sql", "main.go"})
+	if len(areas) != 2 || areas[0].Pattern != "*.sql" || areas[1].Pattern != "*" {
+		t.Fatalf("unexpected areas: %+v", areas)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "owner without @", content: "*.go lead"},
+		{name: "malformed team", content: "*.go @acme/"},
+		{name: "negation", content: "!*.go @lead"},
+		{name: "partial double star", content: "docs/**.md @lead"},
+		{name: "character class", content: "[ab].go @lead"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.content); err == nil {
+				t.Fatalf("expected error for %q", tt.content)
+			}
+		})
+	}
+}
+
+func TestParseOwner(t *testing.T) {
+	user, err := ParseOwner("@u1")
+	if err != nil || user.UserID != "u1" || user.TeamName != "" {
+		t.Fatalf("unexpected user owner: %+v, %v", user, err)
+	}
+	team, err := ParseOwner("@acme/backend")
+	if err != nil || team.TeamName != "backend" || team.UserID != "" {
+		t.Fatalf("unexpected team owner: %+v, %v", team, err)
+	}
+}
@@ -1,23 +1,200 @@
 package config
 
-import "os"
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
 
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv names the config file when the --config flag is not given.
+const ConfigFileEnv = "CONFIG_FILE"
+
+// Config is built from defaults, then the optional YAML file, then
+// environment variables, and validated before anything connects.
 type Config struct {
-	HTTPPort string
-	DBDSN    string
+	HTTP      HTTPConfig      `yaml:"http"`
+	DB        DBConfig        `yaml:"db"`
+	Selection SelectionConfig `yaml:"selection"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Auth      AuthConfig      `yaml:"auth"`
+	Features  FeaturesConfig  `yaml:"features"`
+	Log       LogConfig       `yaml:"log"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+	Metrics   MetricsConfig   `yaml:"metrics"`
+	Admin     AdminConfig     `yaml:"admin"`
 }
 
-func Load() (*Config, error) {
-	cfg := &Config{
-		HTTPPort: getenvDefault("HTTP_PORT", "8080"),
-		DBDSN:    getenvDefault("DB_DSN", "postgres://user:password@db:5432/pr_review?sslmode=disable"),
+type HTTPConfig struct {
+	Port              string        `yaml:"port"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`
+	// ShutdownDelay is how long /readyz fails before the server stops
+	// accepting connections, so that load balancers notice first.
+	ShutdownDelay time.Duration `yaml:"shutdown_delay"`
+}
+
+type DBConfig struct {
+	DSN             string        `yaml:"dsn"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+	// StatementTimeout makes Postgres cancel longer statements; zero
+	// disables it.
+	StatementTimeout time.Duration `yaml:"statement_timeout"`
+	// AutoMigrate applies pending Postgres migrations on start. Turn it off
+	// when the schema is managed with the migrate subcommand.
+	AutoMigrate bool `yaml:"auto_migrate"`
+}
+
+type SelectionConfig struct {
+	PreferWorkingHours bool `yaml:"prefer_working_hours"`
+	// PairingWindow enables the pairing penalty when positive.
+	PairingWindow time.Duration `yaml:"pairing_window"`
+	Reviewers     int           `yaml:"reviewers"`
+}
+
+// RateLimitConfig is the per-client request budget; zero RequestsPerSecond
+// disables limiting.
+type RateLimitConfig struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+	Burst             int     `yaml:"burst"`
+}
+
+// AuthConfig protects the API with static bearer tokens when enabled.
+type AuthConfig struct {
+	Enabled bool     `yaml:"enabled"`
+	Tokens  []string `yaml:"tokens"`
+}
+
+type FeaturesConfig struct {
+	CodeOwners        bool `yaml:"code_owners"`
+	AssignmentPreview bool `yaml:"assignment_preview"`
+}
+
+type LogConfig struct {
+	// Level is one of debug, info, warn and error.
+	Level string `yaml:"level"`
+}
+
+// Span exporters.
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterOTLP   = "otlp"
+)
+
+type TracingConfig struct {
+	Exporter string `yaml:"exporter"`
+	// Endpoint is the OTLP/HTTP collector as host:port; empty falls back to
+	// OTEL_EXPORTER_OTLP_ENDPOINT and then localhost:4318.
+	Endpoint string `yaml:"endpoint"`
+	Insecure bool   `yaml:"insecure"`
+	// SampleRatio is the share of new traces that are recorded. Requests
+	// whose caller sampled the trace are always recorded.
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+type MetricsConfig struct {
+	// StatsInterval is how often the open pull request gauges are read from
+	// the database; 0 turns them off.
+	StatsInterval time.Duration `yaml:"stats_interval"`
+}
+
+// AdminConfig is the listener for /metrics, pprof, health checks, build
+// info and the config view. It has no authentication, so Addr defaults to
+// the loopback interface.
+type AdminConfig struct {
+	Addr string `yaml:"addr"`
+}
+
+func Default() *Config {
+	return &Config{
+		HTTP: HTTPConfig{
+			Port:              "8080",
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       15 * time.Second,
+			WriteTimeout:      15 * time.Second,
+			IdleTimeout:       60 * time.Second,
+			ShutdownTimeout:   5 * time.Second,
+		},
+		DB: DBConfig{
+			DSN:              "postgres://user:password@db:5432/pr_review?sslmode=disable",
+			MaxOpenConns:     20,
+			MaxIdleConns:     10,
+			ConnMaxLifetime:  30 * time.Minute,
+			ConnMaxIdleTime:  5 * time.Minute,
+			StatementTimeout: 10 * time.Second,
+			AutoMigrate:      true,
+		},
+		Selection: SelectionConfig{
+			Reviewers: 2,
+		},
+		RateLimit: RateLimitConfig{
+			Burst: 20,
+		},
+		Features: FeaturesConfig{
+			CodeOwners:        true,
+			AssignmentPreview: true,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		Tracing: TracingConfig{
+			Exporter:    TracingExporterNone,
+			SampleRatio: 1,
+		},
+		Metrics: MetricsConfig{
+			StatsInterval: 30 * time.Second,
+		},
+		Admin: AdminConfig{
+			Addr: "127.0.0.1:8081",
+		},
+	}
+}
+
+// Load reads the config file at path, or the one named by CONFIG_FILE when
+// path is empty, applies environment overrides and validates the result.
+// Without a file only defaults and the environment are used.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	if path == "" {
+		path = os.Getenv(ConfigFileEnv)
+	}
+	if path != "" {
+		if err := cfg.readFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
 	}
 	return cfg, nil
 }
 
-func getenvDefault(key, def string) string {
-	if v := os.Getenv(key); v != "" {
-		return v
+func (c *Config) readFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parse config file %s: %w", path, err)
 	}
-	return def
+	return nil
 }
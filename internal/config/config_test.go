@@ -0,0 +1,227 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	t.Setenv(ConfigFileEnv, "")
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Fatalf("expected defaults, got %+v", cfg)
+	}
+}
+
+func TestLoad_ExampleFile(t *testing.T) {
+	cfg, err := Load("../../config/app.example.yaml")
+	if err != nil {
+		t.Fatalf("load example: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Fatalf("the example should spell out the defaults, got %+v", cfg)
+	}
+}
+
+func TestLoad_KeywordValueDSN(t *testing.T) {
+	t.Setenv(ConfigFileEnv, "")
+	t.Setenv("DB_DSN", "host=db user=u password=p dbname=x")
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if DBScheme(cfg.DB.DSN) != SchemePostgres {
+		t.Fatalf("expected a keyword/value DSN to select Postgres, got %q", DBScheme(cfg.DB.DSN))
+	}
+}
+
+func TestLoad_FileThenEnv(t *testing.T) {
+	path := writeConfig(t, `
+http:
+  port: 9090
+  write_timeout: 30s
+db:
+  dsn: sqlite://data.db
+  max_open_conns: 4
+  max_idle_conns: 2
+selection:
+  pairing_window: 720h
+auth:
+  enabled: true
+  tokens: [file-token-0123456789]
+features:
+  assignment_preview: false
+`)
+	t.Setenv(ConfigFileEnv, path)
+	t.Setenv("HTTP_PORT", "7070")
+	t.Setenv("AUTH_TOKENS", "env-token-0123456789, second-token-0123456789")
+	t.Setenv("FEATURE_CODE_OWNERS", "false")
+	t.Setenv("DB_AUTO_MIGRATE", "false")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	if cfg.HTTP.Port != "7070" || cfg.HTTP.WriteTimeout != 30*time.Second || cfg.HTTP.ReadTimeout != 15*time.Second {
+		t.Fatalf("unexpected http config: %+v", cfg.HTTP)
+	}
+	if cfg.DB.DSN != "sqlite://data.db" || cfg.DB.MaxOpenConns != 4 || cfg.DB.MaxIdleConns != 2 || cfg.DB.AutoMigrate {
+		t.Fatalf("unexpected db config: %+v", cfg.DB)
+	}
+	if cfg.Selection.PairingWindow != 720*time.Hour {
+		t.Fatalf("unexpected selection config: %+v", cfg.Selection)
+	}
+	if !cfg.Auth.Enabled || !reflect.DeepEqual(cfg.Auth.Tokens, []string{"env-token-0123456789", "second-token-0123456789"}) {
+		t.Fatalf("unexpected auth config: %+v", cfg.Auth)
+	}
+	if cfg.Features.CodeOwners || cfg.Features.AssignmentPreview {
+		t.Fatalf("unexpected features: %+v", cfg.Features)
+	}
+}
+
+func TestLoad_FlagWinsOverEnvFile(t *testing.T) {
+	t.Setenv(ConfigFileEnv, filepath.Join(t.TempDir(), "missing.yaml"))
+	cfg, err := Load(writeConfig(t, "http:\n  port: 9191\n"))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.HTTP.Port != "9191" {
+		t.Fatalf("expected port from the flag file, got %q", cfg.HTTP.Port)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Setenv(ConfigFileEnv, "")
+
+	tests := []struct {
+		name string
+		file string
+		env  map[string]string
+		want []string
+	}{
+		{
+			name: "unknown field",
+			file: "http:\n  prot: 8080\n",
+			want: []string{"field prot not found"},
+		},
+		{
+			name: "bad duration",
+			file: "http:\n  read_timeout: soon\n",
+			want: []string{"parse config file"},
+		},
+		{
+			name: "bad env",
+			env:  map[string]string{"DB_MAX_OPEN_CONNS": "many", "AUTH_ENABLED": "sure"},
+			want: []string{`DB_MAX_OPEN_CONNS: invalid integer "many"`, `AUTH_ENABLED: invalid boolean "sure"`},
+		},
+		{
+			name: "every invalid setting at once",
+			file: `
+http:
+  port: 70000
+  idle_timeout: -1s
+  shutdown_timeout: 0s
+db:
+  dsn: mysql://localhost
+  max_open_conns: 2
+  max_idle_conns: 5
+  statement_timeout: 500us
+selection:
+  pairing_window: -1h
+auth:
+  enabled: true
+log:
+  level: verbose
+tracing:
+  exporter: jaeger
+  sample_ratio: 2
+`,
+			want: []string{
+				"http.port: must be a port number",
+				"http.idle_timeout: must not be negative",
+				"http.shutdown_timeout: must be positive",
+				"db.dsn: must start with postgres://, sqlite:// or memory://",
+				"db.max_idle_conns: must not exceed db.max_open_conns",
+				"db.statement_timeout: must be at least 1ms",
+				"selection.pairing_window: must not be negative",
+				"auth.tokens: at least one token is required",
+				`log.level: must be one of debug, info, warn, error, got "verbose"`,
+				`tracing.exporter: must be one of none, stdout, otlp, got "jaeger"`,
+				"tracing.sample_ratio: must be between 0 and 1",
+			},
+		},
+		{
+			name: "admin port clashes with the API",
+			env:  map[string]string{"HTTP_PORT": "9000", "ADMIN_ADDR": ":9000"},
+			want: []string{"admin.addr: must not use http.port, got 9000"},
+		},
+		{
+			name: "admin address without a port",
+			env:  map[string]string{"ADMIN_ADDR": "127.0.0.1"},
+			want: []string{`admin.addr: must be host:port, got "127.0.0.1"`},
+		},
+		{
+			name: "short token",
+			env:  map[string]string{"AUTH_TOKENS": "short"},
+			want: []string{"auth.tokens[0]: must be at least 16 characters long"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			path := ""
+			if tt.file != "" {
+				path = writeConfig(t, tt.file)
+			}
+
+			_, err := Load(path)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Fatalf("expected %q in error:\n%v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestDBScheme(t *testing.T) {
+	cases := map[string]string{
+		"postgres://u:p@db/x":                SchemePostgres,
+		"postgresql://u:p@db/x":              SchemePostgres,
+		"sqlite://data.db":                   SchemeSQLite,
+		"memory://":                          SchemeMemory,
+		"mysql://db":                         "",
+		"mysql://db?user=u":                  "",
+		"data.db":                            "",
+		"host=db user=u password=p dbname=x": SchemePostgres,
+		"host=db password=a:b dbname=x":      SchemePostgres,
+		"user=u dbname=x sslmode=disable":    SchemePostgres,
+	}
+	for dsn, want := range cases {
+		if got := DBScheme(dsn); got != want {
+			t.Fatalf("%q: expected %q, got %q", dsn, want, got)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// applyEnv overrides the fields whose environment variables are set.
+func (c *Config) applyEnv() error {
+	var errs []error
+	collect := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	envString("HTTP_PORT", &c.HTTP.Port)
+	collect(envDuration("HTTP_READ_HEADER_TIMEOUT", &c.HTTP.ReadHeaderTimeout))
+	collect(envDuration("HTTP_READ_TIMEOUT", &c.HTTP.ReadTimeout))
+	collect(envDuration("HTTP_WRITE_TIMEOUT", &c.HTTP.WriteTimeout))
+	collect(envDuration("HTTP_IDLE_TIMEOUT", &c.HTTP.IdleTimeout))
+	collect(envDuration("HTTP_SHUTDOWN_TIMEOUT", &c.HTTP.ShutdownTimeout))
+	collect(envDuration("HTTP_SHUTDOWN_DELAY", &c.HTTP.ShutdownDelay))
+
+	envString("DB_DSN", &c.DB.DSN)
+	collect(envInt("DB_MAX_OPEN_CONNS", &c.DB.MaxOpenConns))
+	collect(envInt("DB_MAX_IDLE_CONNS", &c.DB.MaxIdleConns))
+	collect(envDuration("DB_CONN_MAX_LIFETIME", &c.DB.ConnMaxLifetime))
+	collect(envDuration("DB_CONN_MAX_IDLE_TIME", &c.DB.ConnMaxIdleTime))
+	collect(envDuration("DB_STATEMENT_TIMEOUT", &c.DB.StatementTimeout))
+	collect(envBool("DB_AUTO_MIGRATE", &c.DB.AutoMigrate))
+
+	collect(envBool("SELECTION_PREFER_WORKING_HOURS", &c.Selection.PreferWorkingHours))
+	collect(envDuration("SELECTION_PAIRING_WINDOW", &c.Selection.PairingWindow))
+	collect(envInt("SELECTION_REVIEWERS", &c.Selection.Reviewers))
+
+	collect(envFloat("RATE_LIMIT_RPS", &c.RateLimit.RequestsPerSecond))
+	collect(envInt("RATE_LIMIT_BURST", &c.RateLimit.Burst))
+
+	collect(envBool("AUTH_ENABLED", &c.Auth.Enabled))
+	envList("AUTH_TOKENS", &c.Auth.Tokens)
+
+	collect(envBool("FEATURE_CODE_OWNERS", &c.Features.CodeOwners))
+	collect(envBool("FEATURE_ASSIGNMENT_PREVIEW", &c.Features.AssignmentPreview))
+
+	envString("LOG_LEVEL", &c.Log.Level)
+
+	envString("TRACING_EXPORTER", &c.Tracing.Exporter)
+	envString("TRACING_ENDPOINT", &c.Tracing.Endpoint)
+	collect(envBool("TRACING_INSECURE", &c.Tracing.Insecure))
+	collect(envFloat("TRACING_SAMPLE_RATIO", &c.Tracing.SampleRatio))
+
+	collect(envDuration("METRICS_STATS_INTERVAL", &c.Metrics.StatsInterval))
+
+	envString("ADMIN_ADDR", &c.Admin.Addr)
+
+	return errors.Join(errs...)
+}
+
+func envString(key string, dst *string) {
+	if v := os.Getenv(key); v != "" {
+		*dst = v
+	}
+}
+
+func envList(key string, dst *[]string) {
+	v := os.Getenv(key)
+	if v == "" {
+		return
+	}
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	*dst = items
+}
+
+func envBool(key string, dst *bool) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return fmt.Errorf("%s: invalid boolean %q", key, v)
+	}
+	*dst = b
+	return nil
+}
+
+func envInt(key string, dst *int) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fmt.Errorf("%s: invalid integer %q", key, v)
+	}
+	*dst = n
+	return nil
+}
+
+func envFloat(key string, dst *float64) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return fmt.Errorf("%s: invalid number %q", key, v)
+	}
+	*dst = f
+	return nil
+}
+
+func envDuration(key string, dst *time.Duration) error {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("%s: invalid duration %q", key, v)
+	}
+	*dst = d
+	return nil
+}
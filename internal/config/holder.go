@@ -0,0 +1,122 @@
+package config
+
+import (
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Holder serves the active config. Reload swaps in the reloadable sections
+// of a freshly loaded config; callers that read Current once per request keep
+// a consistent view for the whole request.
+type Holder struct {
+	path    string
+	current atomic.Pointer[Config]
+}
+
+// NewHolder keeps cfg active; Reload reads path the same way Load does.
+func NewHolder(cfg *Config, path string) *Holder {
+	h := &Holder{path: path}
+	h.current.Store(cfg)
+	return h
+}
+
+func (h *Holder) Current() *Config {
+	return h.current.Load()
+}
+
+// Reload loads and validates the config again and swaps in its selection,
+// rate_limit and log sections. Changes to other sections need a restart and are
+// returned by name without being applied. On error the active config stays.
+func (h *Holder) Reload() (restartRequired []string, err error) {
+	loaded, err := Load(h.path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := h.Current()
+	next := *current
+	next.Selection = loaded.Selection
+	next.RateLimit = loaded.RateLimit
+	next.Log = loaded.Log
+	h.current.Store(&next)
+
+	sections := []struct {
+		name           string
+		active, loaded any
+	}{
+		{"http", current.HTTP, loaded.HTTP},
+		{"db", current.DB, loaded.DB},
+		{"auth", current.Auth, loaded.Auth},
+		{"features", current.Features, loaded.Features},
+		{"tracing", current.Tracing, loaded.Tracing},
+		{"metrics", current.Metrics, loaded.Metrics},
+		{"admin", current.Admin, loaded.Admin},
+	}
+	for _, s := range sections {
+		if !reflect.DeepEqual(s.active, s.loaded) {
+			restartRequired = append(restartRequired, s.name)
+		}
+	}
+	return restartRequired, nil
+}
+
+const redacted = "REDACTED"
+
+// dsnPassword matches password and sslpassword in keyword/value DSNs,
+// including single-quoted values.
+var dsnPassword = regexp.MustCompile(`(?i)(password=)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// dsnSecretParams are URL query parameters that carry secrets.
+var dsnSecretParams = []string{"password", "sslpassword"}
+
+// Redacted returns the config as a YAML-shaped tree with the DSN password and
+// auth tokens masked.
+func (c *Config) Redacted() (map[string]any, error) {
+	masked := *c
+	masked.DB.DSN = redactDSN(c.DB.DSN)
+	masked.Auth.Tokens = make([]string, len(c.Auth.Tokens))
+	for i := range masked.Auth.Tokens {
+		masked.Auth.Tokens[i] = redacted
+	}
+
+	data, err := yaml.Marshal(&masked)
+	if err != nil {
+		return nil, err
+	}
+	var tree map[string]any
+	if err := yaml.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+func redactDSN(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil || u.Scheme == "" {
+		return dsnPassword.ReplaceAllString(dsn, "${1}"+redacted)
+	}
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), redacted)
+		}
+	}
+	query := u.Query()
+	masked := false
+	for key := range query {
+		for _, secret := range dsnSecretParams {
+			if strings.EqualFold(key, secret) {
+				query.Set(key, redacted)
+				masked = true
+			}
+		}
+	}
+	if masked {
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHolder_Reload(t *testing.T) {
+	t.Setenv(ConfigFileEnv, "")
+	path := writeConfig(t, "selection:\n  reviewers: 2\n")
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	h := NewHolder(cfg, path)
+	before := h.Current()
+
+	if err := os.WriteFile(path, []byte(`
+http:
+  port: 9999
+selection:
+  reviewers: 3
+  pairing_window: 24h
+rate_limit:
+  requests_per_second: 5
+  burst: 10
+log:
+  level: debug
+`), 0o600); err != nil {
+		t.Fatalf("rewrite: %v", err)
+	}
+
+	restartRequired, err := h.Reload()
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if !reflect.DeepEqual(restartRequired, []string{"http"}) {
+		t.Fatalf("expected http to need a restart, got %v", restartRequired)
+	}
+
+	after := h.Current()
+	if after.Selection.Reviewers != 3 || after.Selection.PairingWindow != 24*time.Hour || after.RateLimit.RequestsPerSecond != 5 || after.Log.Level != "debug" {
+		t.Fatalf("reloadable sections were not swapped in: %+v", after)
+	}
+	if after.HTTP.Port != "8080" {
+		t.Fatalf("expected the port to stay until restart, got %q", after.HTTP.Port)
+	}
+	// Requests holding the previous config keep seeing it unchanged.
+	if before.Selection.Reviewers != 2 || before.RateLimit.RequestsPerSecond != 0 {
+		t.Fatalf("previous config was mutated: %+v", before)
+	}
+
+	if err := os.WriteFile(path, []byte("selection:\n  reviewers: 0\n"), 0o600); err != nil {
+		t.Fatalf("rewrite: %v", err)
+	}
+	if _, err := h.Reload(); err == nil || !strings.Contains(err.Error(), "selection.reviewers") {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if h.Current() != after {
+		t.Fatalf("an invalid config must not replace the active one")
+	}
+}
+
+func TestConfig_Redacted(t *testing.T) {
+	cfg := Default()
+	cfg.Auth.Tokens = []string{"secret-token-0123456789"}
+
+	tree, err := cfg.Redacted()
+	if err != nil {
+		t.Fatalf("redact: %v", err)
+	}
+	db := tree["db"].(map[string]any)
+	if db["dsn"] != "postgres://user:REDACTED@db:5432/pr_review?sslmode=disable" {
+		t.Fatalf("unexpected dsn: %v", db["dsn"])
+	}
+	if tokens := tree["auth"].(map[string]any)["tokens"].([]any); len(tokens) != 1 || tokens[0] != "REDACTED" {
+		t.Fatalf("unexpected tokens: %v", tokens)
+	}
+	if timeout := tree["http"].(map[string]any)["read_timeout"]; timeout != "15s" {
+		t.Fatalf("expected durations as strings, got %v", timeout)
+	}
+	if cfg.Auth.Tokens[0] != "secret-token-0123456789" {
+		t.Fatalf("redaction must not touch the config")
+	}
+
+	for dsn, want := range map[string]string{
+		"host=db user=u password=hunter2 dbname=x":                 "host=db user=u password=REDACTED dbname=x",
+		"host=db password='hunter 2' dbname=x":                     "host=db password=REDACTED dbname=x",
+		"host=db sslpassword=hunter2 dbname=x":                     "host=db sslpassword=REDACTED dbname=x",
+		"postgres://u@db/x?password=secret":                        "postgres://u@db/x?password=REDACTED",
+		"postgres://u@db/x?sslmode=verify-full&sslpassword=secret": "postgres://u@db/x?sslmode=verify-full&sslpassword=REDACTED",
+		"postgres://u:p@db/x?Password=secret":                      "postgres://u:REDACTED@db/x?Password=REDACTED",
+		"sqlite://data.db":                                         "sqlite://data.db",
+		"postgres://db/x":                                          "postgres://db/x",
+	} {
+		if got := redactDSN(dsn); got != want {
+			t.Fatalf("%q: expected %q, got %q", dsn, want, got)
+		}
+	}
+}
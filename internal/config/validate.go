@@ -0,0 +1,143 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DSN schemes selecting the storage backend.
+const (
+	SchemePostgres = "postgres"
+	SchemeSQLite   = "sqlite"
+	SchemeMemory   = "memory"
+)
+
+const maxReviewers = 10
+
+// DBScheme returns the storage backend selected by dsn, or "" if none is. A
+// DSN without a URL scheme, such as "host=db dbname=x", is a keyword/value
+// Postgres connection string.
+func DBScheme(dsn string) string {
+	scheme, _, ok := strings.Cut(dsn, ":")
+	if ok {
+		switch scheme {
+		case "postgres", "postgresql":
+			return SchemePostgres
+		case SchemeSQLite, SchemeMemory:
+			return scheme
+		}
+	}
+	if !strings.Contains(dsn, "://") && strings.Contains(dsn, "=") {
+		return SchemePostgres
+	}
+	return ""
+}
+
+// Validate reports every invalid setting at once, naming each by its YAML
+// path.
+func (c *Config) Validate() error {
+	var errs []error
+	fail := func(field, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+	}
+	nonNegative := func(field string, d time.Duration) {
+		if d < 0 {
+			fail(field, "must not be negative, got %s", d)
+		}
+	}
+
+	port, ok := parsePort(c.HTTP.Port)
+	if !ok {
+		fail("http.port", "must be a port number between 1 and 65535, got %q", c.HTTP.Port)
+	}
+	nonNegative("http.read_header_timeout", c.HTTP.ReadHeaderTimeout)
+	nonNegative("http.read_timeout", c.HTTP.ReadTimeout)
+	nonNegative("http.write_timeout", c.HTTP.WriteTimeout)
+	nonNegative("http.idle_timeout", c.HTTP.IdleTimeout)
+	if c.HTTP.ShutdownTimeout <= 0 {
+		fail("http.shutdown_timeout", "must be positive, got %s", c.HTTP.ShutdownTimeout)
+	}
+	nonNegative("http.shutdown_delay", c.HTTP.ShutdownDelay)
+
+	switch {
+	case c.DB.DSN == "":
+		fail("db.dsn", "is required")
+	case DBScheme(c.DB.DSN) == "":
+		fail("db.dsn", "must start with postgres://, sqlite:// or memory://, or be a keyword/value Postgres DSN")
+	}
+	if c.DB.MaxOpenConns < 0 {
+		fail("db.max_open_conns", "must not be negative, got %d", c.DB.MaxOpenConns)
+	}
+	if c.DB.MaxIdleConns < 0 {
+		fail("db.max_idle_conns", "must not be negative, got %d", c.DB.MaxIdleConns)
+	}
+	if c.DB.MaxOpenConns > 0 && c.DB.MaxIdleConns > c.DB.MaxOpenConns {
+		fail("db.max_idle_conns", "must not exceed db.max_open_conns (%d), got %d", c.DB.MaxOpenConns, c.DB.MaxIdleConns)
+	}
+	nonNegative("db.conn_max_lifetime", c.DB.ConnMaxLifetime)
+	nonNegative("db.conn_max_idle_time", c.DB.ConnMaxIdleTime)
+	nonNegative("db.statement_timeout", c.DB.StatementTimeout)
+	if c.DB.StatementTimeout > 0 && c.DB.StatementTimeout < time.Millisecond {
+		fail("db.statement_timeout", "must be at least 1ms, got %s", c.DB.StatementTimeout)
+	}
+
+	nonNegative("selection.pairing_window", c.Selection.PairingWindow)
+	if c.Selection.Reviewers < 1 || c.Selection.Reviewers > maxReviewers {
+		fail("selection.reviewers", "must be between 1 and %d, got %d", maxReviewers, c.Selection.Reviewers)
+	}
+
+	if c.RateLimit.RequestsPerSecond < 0 {
+		fail("rate_limit.requests_per_second", "must not be negative, got %g", c.RateLimit.RequestsPerSecond)
+	}
+	if c.RateLimit.RequestsPerSecond > 0 && c.RateLimit.Burst < 1 {
+		fail("rate_limit.burst", "must be at least 1 when rate limiting is on, got %d", c.RateLimit.Burst)
+	}
+
+	if c.Auth.Enabled && len(c.Auth.Tokens) == 0 {
+		fail("auth.tokens", "at least one token is required when auth is enabled")
+	}
+	for i, token := range c.Auth.Tokens {
+		if len(token) < 16 {
+			fail(fmt.Sprintf("auth.tokens[%d]", i), "must be at least 16 characters long")
+		}
+	}
+
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		fail("log.level", "must be one of debug, info, warn, error, got %q", c.Log.Level)
+	}
+
+	switch c.Tracing.Exporter {
+	case TracingExporterNone, TracingExporterStdout, TracingExporterOTLP:
+	default:
+		fail("tracing.exporter", "must be one of none, stdout, otlp, got %q", c.Tracing.Exporter)
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		fail("tracing.sample_ratio", "must be between 0 and 1, got %g", c.Tracing.SampleRatio)
+	}
+
+	nonNegative("metrics.stats_interval", c.Metrics.StatsInterval)
+
+	if _, p, err := net.SplitHostPort(c.Admin.Addr); err != nil {
+		fail("admin.addr", "must be host:port, got %q", c.Admin.Addr)
+	} else if adminPort, ok := parsePort(p); !ok {
+		fail("admin.addr", "must have a port number between 1 and 65535, got %q", c.Admin.Addr)
+	} else if adminPort == port {
+		fail("admin.addr", "must not use http.port, got %d", adminPort)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid config:\n%w", errors.Join(errs...))
+}
+
+func parsePort(s string) (int, bool) {
+	port, err := strconv.Atoi(strings.TrimPrefix(s, ":"))
+	return port, err == nil && port >= 1 && port <= 65535
+}
@@ -12,16 +12,110 @@ const (
 	PullRequestStatusMerged PullRequestStatus = "MERGED"
 )
 
+type Seniority string
+
+const (
+	SeniorityJunior Seniority = "junior"
+	SeniorityMiddle Seniority = "middle"
+	SenioritySenior Seniority = "senior"
+)
+
+func (s Seniority) Valid() bool {
+	switch s {
+	case SeniorityJunior, SeniorityMiddle, SenioritySenior:
+		return true
+	}
+	return false
+}
+
 type User struct {
-	ID       string
-	Username string
-	TeamName string
-	IsActive bool
+	ID           string
+	Username     string
+	TeamName     string
+	IsActive     bool
+	Timezone     string
+	WorkingHours *WorkingHours
+	// ReviewCapacity overrides the team default; nil inherits it.
+	ReviewCapacity *int
+	Seniority      Seniority
+	Tags           []string
+}
+
+// WorkingHours is a daily window in the user's local time, in minutes since
+// midnight. End may be less than Start for windows that cross midnight.
+type WorkingHours struct {
+	StartMinute int
+	EndMinute   int
+}
+
+func (h WorkingHours) Contains(minute int) bool {
+	if h.StartMinute <= h.EndMinute {
+		return minute >= h.StartMinute && minute < h.EndMinute
+	}
+	return minute >= h.StartMinute || minute < h.EndMinute
+}
+
+// InWorkingHours reports whether at falls into the user's working window.
+// Users without configured hours are treated as always available.
+func (u User) InWorkingHours(at time.Time) bool {
+	if u.WorkingHours == nil {
+		return true
+	}
+	loc := time.UTC
+	if u.Timezone != "" {
+		if l, err := time.LoadLocation(u.Timezone); err == nil {
+			loc = l
+		}
+	}
+	local := at.In(loc)
+	return u.WorkingHours.Contains(local.Hour()*60 + local.Minute())
+}
+
+type Unavailability struct {
+	ID       int64
+	UserID   string
+	StartsAt time.Time
+	EndsAt   time.Time
+	Reason   string
+}
+
+// ReviewLoad is the number of OPEN pull requests a user reviews together with
+// the effective capacity (user override or team default, nil = unlimited).
+type ReviewLoad struct {
+	OpenReviews int
+	Capacity    *int
+}
+
+func (l ReviewLoad) HasSpareCapacity() bool {
+	return l.Capacity == nil || l.OpenReviews < *l.Capacity
+}
+
+// OpenPullRequestStats summarises the OPEN pull requests for monitoring.
+type OpenPullRequestStats struct {
+	// ByTeam counts pull requests by the author's team.
+	ByTeam map[string]int
+	// ByReviewerCount counts pull requests by the number of assigned
+	// reviewers.
+	ByReviewerCount map[int]int
+	// ReviewsByUser counts the pull requests each reviewer is assigned to.
+	ReviewsByUser map[string]int
+}
+
+type TeamPolicy struct {
+	// RequireSeniorReviewer makes every pull request get a senior reviewer;
+	// juniors may then join as a second, learning reviewer.
+	RequireSeniorReviewer bool
 }
 
 type Team struct {
-	Name    string
-	Members []User
+	Name                  string
+	Members               []User
+	DefaultReviewCapacity *int
+	Policy                TeamPolicy
+	// FallbackTeams are partner teams ordered by priority that lend reviewers
+	// when the team itself has too few candidates.
+	FallbackTeams []string
+	Load          map[string]ReviewLoad
 }
 
 type PullRequest struct {
@@ -30,8 +124,53 @@ type PullRequest struct {
 	AuthorID          string
 	Status            PullRequestStatus
 	AssignedReviewers []string
-	CreatedAt         time.Time
-	MergedAt          *time.Time
+	// CrossTeamReviewers maps reviewers borrowed from a fallback team to that
+	// team's name.
+	CrossTeamReviewers map[string]string
+	Understaffed       bool
+	Tags               []string
+	// ChangedPaths is only used for assignment and is not stored.
+	ChangedPaths []string
+	CreatedAt    time.Time
+	MergedAt     *time.Time
+	// Version starts at 1 and grows with every change to the pull request.
+	Version int64
+}
+
+// CodeOwnerRule maps a CODEOWNERS pattern to owner tokens ("@user_id" or
+// "@org/team_name").
+type CodeOwnerRule struct {
+	Pattern string
+	Owners  []string
+}
+
+// RejectionReason explains why a team member was not picked as a reviewer.
+type RejectionReason string
+
+const (
+	RejectionAuthor      RejectionReason = "author"
+	RejectionInactive    RejectionReason = "inactive"
+	RejectionOutOfOffice RejectionReason = "out_of_office"
+	RejectionAtCapacity  RejectionReason = "at_capacity"
+	// RejectionNotSelected covers available candidates that lost the ranking
+	// or were skipped by the team policy.
+	RejectionNotSelected RejectionReason = "not_selected"
+)
+
+type RejectedCandidate struct {
+	UserID   string
+	TeamName string
+	Reason   RejectionReason
+}
+
+// AssignmentPreview is the outcome of a reviewer assignment that was not
+// written anywhere.
+type AssignmentPreview struct {
+	AuthorID           string
+	AssignedReviewers  []string
+	CrossTeamReviewers map[string]string
+	Understaffed       bool
+	Rejected           []RejectedCandidate
 }
 
 type PullRequestShort struct {
@@ -39,6 +178,7 @@ type PullRequestShort struct {
 	Name     string
 	AuthorID string
 	Status   PullRequestStatus
+	Version  int64
 }
 
 type ErrorCode string
@@ -50,6 +190,9 @@ const (
 	ErrorCodeNotAssigned ErrorCode = "NOT_ASSIGNED"
 	ErrorCodeNoCandidate ErrorCode = "NO_CANDIDATE"
 	ErrorCodeNotFound    ErrorCode = "NOT_FOUND"
+	// ErrorCodeVersionMismatch means the pull request changed since the
+	// version the caller based its request on.
+	ErrorCodeVersionMismatch ErrorCode = "VERSION_MISMATCH"
 )
 
 type DomainError struct {
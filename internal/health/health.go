@@ -0,0 +1,103 @@
+// Package health tracks whether the service can take traffic, for the
+// liveness, readiness and startup probes.
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CheckTimeout bounds each check so that a hung dependency fails the probe
+// instead of stalling it.
+const CheckTimeout = 2 * time.Second
+
+// ErrShuttingDown fails readiness once the server has begun to drain.
+var ErrShuttingDown = errors.New("shutting down")
+
+// Check reports why a dependency is unusable, or nil.
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker runs the readiness checks. It is safe for concurrent use once the
+// checks are added.
+type Checker struct {
+	checks       []namedCheck
+	started      atomic.Bool
+	shuttingDown atomic.Bool
+}
+
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+// Add registers a readiness check under name. Checks must be added before
+// the checker is used.
+func (c *Checker) Add(name string, check Check) {
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// Result is the outcome of one check; Err is nil when it passed.
+type Result struct {
+	Name string
+	Err  error
+}
+
+// Report is the outcome of every check, in the order they were added.
+type Report struct {
+	Results []Result
+}
+
+func (r Report) OK() bool {
+	for _, res := range r.Results {
+		if res.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Ready runs every check concurrently. After Shutdown the report also carries
+// a failed shutdown check.
+func (c *Checker) Ready(ctx context.Context) Report {
+	report := Report{Results: make([]Result, len(c.checks))}
+	var wg sync.WaitGroup
+	for i, nc := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, CheckTimeout)
+			defer cancel()
+			report.Results[i] = Result{Name: nc.name, Err: nc.check(checkCtx)}
+		}()
+	}
+	wg.Wait()
+
+	if c.shuttingDown.Load() {
+		report.Results = append(report.Results, Result{Name: "shutdown", Err: ErrShuttingDown})
+	}
+	if report.OK() {
+		c.started.Store(true)
+	}
+	return report
+}
+
+// Started reports whether the checks have passed at least once. It runs them
+// until they do and never again after that.
+func (c *Checker) Started(ctx context.Context) Report {
+	if c.started.Load() {
+		return Report{}
+	}
+	return c.Ready(ctx)
+}
+
+// Shutdown makes every later readiness report fail.
+func (c *Checker) Shutdown() {
+	c.shuttingDown.Store(true)
+}
@@ -0,0 +1,84 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChecker_Ready(t *testing.T) {
+	c := NewChecker()
+	dbErr := errors.New("connection refused")
+	c.Add("db", func(context.Context) error { return dbErr })
+	c.Add("migrations", func(context.Context) error { return nil })
+
+	report := c.Ready(context.Background())
+	if report.OK() {
+		t.Fatalf("expected failed report")
+	}
+	if len(report.Results) != 2 || report.Results[0].Name != "db" || report.Results[1].Name != "migrations" {
+		t.Fatalf("unexpected results %+v", report.Results)
+	}
+	if !errors.Is(report.Results[0].Err, dbErr) || report.Results[1].Err != nil {
+		t.Fatalf("unexpected results %+v", report.Results)
+	}
+}
+
+func TestChecker_CheckTimeout(t *testing.T) {
+	c := NewChecker()
+	c.Add("db", func(ctx context.Context) error {
+		deadline, ok := ctx.Deadline()
+		if !ok || time.Until(deadline) > CheckTimeout {
+			t.Errorf("expected a deadline within %s", CheckTimeout)
+		}
+		return nil
+	})
+	if !c.Ready(context.Background()).OK() {
+		t.Fatalf("expected ready")
+	}
+}
+
+func TestChecker_StartedLatches(t *testing.T) {
+	c := NewChecker()
+	var failing bool
+	calls := 0
+	c.Add("db", func(context.Context) error {
+		calls++
+		if failing {
+			return errors.New("down")
+		}
+		return nil
+	})
+
+	failing = true
+	if c.Started(context.Background()).OK() {
+		t.Fatalf("expected not started while the check fails")
+	}
+	failing = false
+	if !c.Started(context.Background()).OK() {
+		t.Fatalf("expected started once the check passes")
+	}
+	failing = true
+	if !c.Started(context.Background()).OK() {
+		t.Fatalf("expected started to stay true")
+	}
+	if calls != 2 {
+		t.Fatalf("expected checks to stop running after start, got %d calls", calls)
+	}
+}
+
+func TestChecker_Shutdown(t *testing.T) {
+	c := NewChecker()
+	c.Add("db", func(context.Context) error { return nil })
+	c.Shutdown()
+
+	report := c.Ready(context.Background())
+	if report.OK() {
+		t.Fatalf("expected not ready after shutdown")
+	}
+	last := report.Results[len(report.Results)-1]
+	if last.Name != "shutdown" || !errors.Is(last.Err, ErrShuttingDown) {
+		t.Fatalf("unexpected results %+v", report.Results)
+	}
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"runtime/debug"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"pr-reviewer/internal/health"
+)
+
+type buildInfoResponse struct {
+	Version      string `json:"version"`
+	GoVersion    string `json:"go_version"`
+	Revision     string `json:"revision,omitempty"`
+	RevisionTime string `json:"revision_time,omitempty"`
+	Modified     bool   `json:"modified"`
+}
+
+// NewAdminRouter serves the operator endpoints: /metrics, the health probes,
+// /buildinfo, net/http/pprof under /debug/pprof/ and, when configView is not
+// nil, the active configuration at /admin/config. It has no authentication,
+// so its address must not be reachable from outside the deployment.
+// configView must not expose secrets.
+func NewAdminRouter(checker *health.Checker, configView func() (any, error)) http.Handler {
+	if checker == nil {
+		checker = health.NewChecker()
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	healthHandlers := newHealthHandlers(checker, true)
+	mux.HandleFunc("/healthz", method("GET", healthHandlers.Live))
+	mux.HandleFunc("/readyz", method("GET", healthHandlers.Ready))
+	mux.HandleFunc("/startupz", method("GET", healthHandlers.Started))
+
+	mux.HandleFunc("/buildinfo", method("GET", writeBuildInfo))
+	if configView != nil {
+		mux.HandleFunc("/admin/config", method("GET", func(w http.ResponseWriter, r *http.Request) {
+			view, err := configView()
+			if err != nil {
+				WriteError(w, err)
+				return
+			}
+			writeJSON(w, http.StatusOK, view)
+		}))
+	}
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	return mux
+}
+
+// writeBuildInfo reports the module version and the VCS revision the binary
+// was built from, as recorded by the Go toolchain.
+func writeBuildInfo(w http.ResponseWriter, r *http.Request) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		writeJSON(w, http.StatusNotFound, ErrorResponse{Error: errorPayload{Message: "build info is not available"}})
+		return
+	}
+
+	resp := buildInfoResponse{Version: info.Main.Version, GoVersion: info.GoVersion}
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			resp.Revision = s.Value
+		case "vcs.time":
+			resp.RevisionTime = s.Value
+		case "vcs.modified":
+			resp.Modified = s.Value == "true"
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
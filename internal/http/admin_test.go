@@ -0,0 +1,84 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"pr-reviewer/internal/health"
+	serviceMocks "pr-reviewer/mocks/service"
+)
+
+func TestAdminRouter(t *testing.T) {
+	checker := health.NewChecker()
+	checker.Add("db", func(context.Context) error { return errors.New("connection refused") })
+	router := NewAdminRouter(checker, func() (any, error) {
+		return map[string]any{"selection": map[string]any{"reviewers": 3}}, nil
+	})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		return rr
+	}
+
+	if rr := get("/metrics"); rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), "go_goroutines") {
+		t.Fatalf("expected Prometheus metrics, got %d", rr.Code)
+	}
+	if rr := get("/healthz"); rr.Code != http.StatusOK {
+		t.Fatalf("expected /healthz 200, got %d", rr.Code)
+	}
+	rr := get("/readyz")
+	if rr.Code != http.StatusServiceUnavailable || !strings.Contains(rr.Body.String(), "connection refused") {
+		t.Fatalf("expected the failed db check, got %d %s", rr.Code, rr.Body.String())
+	}
+	if rr := get("/debug/pprof/"); rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), "goroutine") {
+		t.Fatalf("expected the pprof index, got %d", rr.Code)
+	}
+	if rr := get("/debug/pprof/cmdline"); rr.Code != http.StatusOK {
+		t.Fatalf("expected pprof cmdline, got %d", rr.Code)
+	}
+
+	rr = get("/buildinfo")
+	var info buildInfoResponse
+	if err := json.NewDecoder(rr.Body).Decode(&info); err != nil || rr.Code != http.StatusOK {
+		t.Fatalf("buildinfo: %d, %v", rr.Code, err)
+	}
+	if info.GoVersion == "" || info.Version == "" {
+		t.Fatalf("expected versions in build info, got %+v", info)
+	}
+
+	rr = get("/admin/config")
+	var view map[string]map[string]int
+	if err := json.NewDecoder(rr.Body).Decode(&view); err != nil || rr.Code != http.StatusOK || view["selection"]["reviewers"] != 3 {
+		t.Fatalf("unexpected config view: %d %v, %v", rr.Code, view, err)
+	}
+
+	if rr := get("/team/get"); rr.Code != http.StatusNotFound {
+		t.Fatalf("expected the API not to be served, got %d", rr.Code)
+	}
+}
+
+func TestRouter_ReadyzHidesCheckErrors(t *testing.T) {
+	checker := health.NewChecker()
+	checker.Add("db", func(context.Context) error { return errors.New("dial tcp 10.0.0.5:5432: connection refused") })
+	checker.Add("migrations", func(context.Context) error { return nil })
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{}, WithHealthChecker(checker))
+
+	for _, path := range []string{"/readyz", "/startupz"} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		var resp healthResponse
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil || rr.Code != http.StatusServiceUnavailable {
+			t.Fatalf("%s: %d, %v", path, rr.Code, err)
+		}
+		if resp.Status != healthStatusFail || resp.Checks["db"] != (healthCheckDTO{Status: healthStatusFail}) ||
+			resp.Checks["migrations"] != (healthCheckDTO{Status: healthStatusOK}) {
+			t.Fatalf("%s: expected pass/fail only, got %+v", path, resp)
+		}
+	}
+}
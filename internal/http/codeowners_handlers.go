@@ -0,0 +1,78 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"pr-reviewer/internal/codeowners"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/service"
+)
+
+type codeOwnerHandlers struct {
+	codeOwners service.CodeOwnerService
+}
+
+func newCodeOwnerHandlers(codeOwners service.CodeOwnerService) *codeOwnerHandlers {
+	return &codeOwnerHandlers{codeOwners: codeOwners}
+}
+
+type uploadCodeOwnersRequest struct {
+	Content string `json:"content"`
+}
+
+type codeOwnerRuleDTO struct {
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
+
+type codeOwnersResponse struct {
+	Rules []codeOwnerRuleDTO `json:"rules"`
+}
+
+func (h *codeOwnerHandlers) Upload(w http.ResponseWriter, r *http.Request) {
+	var req uploadCodeOwnersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "invalid request body")
+		return
+	}
+
+	rules, err := codeowners.Parse(req.Content)
+	if err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+
+	saved, err := h.codeOwners.ReplaceRules(r.Context(), rules)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toCodeOwnersResponse(saved))
+}
+
+func (h *codeOwnerHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.codeOwners.ListRules(r.Context())
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toCodeOwnersResponse(rules))
+}
+
+func toCodeOwnersResponse(rules []domain.CodeOwnerRule) codeOwnersResponse {
+	resp := codeOwnersResponse{Rules: make([]codeOwnerRuleDTO, 0, len(rules))}
+	for _, rule := range rules {
+		owners := rule.Owners
+		if owners == nil {
+			owners = []string{}
+		}
+		resp.Rules = append(resp.Rules, codeOwnerRuleDTO{
+			Pattern: rule.Pattern,
+			Owners:  owners,
+		})
+	}
+	return resp
+}
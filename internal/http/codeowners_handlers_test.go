@@ -0,0 +1,66 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"pr-reviewer/internal/domain"
+	serviceMocks "pr-reviewer/mocks/service"
+)
+
+func TestCodeOwnerHandlers_Upload_InvalidFile(t *testing.T) {
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	body, _ := json.Marshal(map[string]any{"content": "*.go lead\n"})
+	req := httptest.NewRequest(http.MethodPost, "/codeOwners/upload", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestCodeOwnerHandlers_Upload_Success(t *testing.T) {
+	expected := []domain.CodeOwnerRule{
+		{Pattern: "*", Owners: []string{"@acme/backend"}},
+		{Pattern: "/migrations/", Owners: []string{"@dba", "@acme/platform"}},
+	}
+	codeOwnerSvc := serviceMocks.NewMockCodeOwnerService(t)
+	codeOwnerSvc.On("ReplaceRules", mock.Anything, expected).Return(expected, nil)
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), codeOwnerSvc, &stubHTTPMetrics{})
+
+	body, _ := json.Marshal(map[string]any{"content": "# owners\n*  @acme/backend\n/migrations/ @dba @acme/platform\n"})
+	req := httptest.NewRequest(http.MethodPost, "/codeOwners/upload", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+
+	var resp codeOwnersResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.Rules) != 2 || resp.Rules[1].Pattern != "/migrations/" || len(resp.Rules[1].Owners) != 2 {
+		t.Fatalf("unexpected rules: %+v", resp.Rules)
+	}
+}
+
+func TestCodeOwnerHandlers_Upload_UnknownOwner(t *testing.T) {
+	codeOwnerSvc := serviceMocks.NewMockCodeOwnerService(t)
+	codeOwnerSvc.On("ReplaceRules", mock.Anything, mock.Anything).Return(nil, domain.NewDomainError(domain.ErrorCodeNotFound, "owner @ghost not found"))
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), codeOwnerSvc, &stubHTTPMetrics{})
+
+	body, _ := json.Marshal(map[string]any{"content": "*.go @ghost"})
+	req := httptest.NewRequest(http.MethodPost, "/codeOwners/upload", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rr.Code)
+	}
+}
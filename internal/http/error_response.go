@@ -16,7 +16,11 @@ type ErrorResponse struct {
 	Error errorPayload `json:"error"`
 }
 
+// WriteError maps err to a response. The request log picks err up from w.
 func WriteError(w http.ResponseWriter, err error) {
+	if rec, ok := w.(*statusRecorder); ok {
+		rec.err = err
+	}
 	if domainErr, ok := domain.AsDomainError(err); ok {
 		writeJSON(w, statusForDomainCode(domainErr.Code), ErrorResponse{
 			Error: errorPayload{
@@ -50,6 +54,8 @@ func statusForDomainCode(code domain.ErrorCode) int {
 		return http.StatusConflict
 	case domain.ErrorCodeNotFound:
 		return http.StatusNotFound
+	case domain.ErrorCodeVersionMismatch:
+		return http.StatusPreconditionFailed
 	default:
 		return http.StatusInternalServerError
 	}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"pr-reviewer/internal/domain"
+)
+
+var errInvalidIfMatch = errors.New(`If-Match must be "*" or a list of pull request versions such as "3", "4"`)
+
+func setETag(w http.ResponseWriter, pr domain.PullRequest) {
+	w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(pr.Version, 10)))
+}
+
+// parseIfMatch returns the versions the request is conditional on, or nil
+// when If-Match is absent or "*". Per RFC 9110 the header is either "*" or a
+// comma-separated list of tags, possibly split across several fields, and
+// the precondition holds when any of them matches. Weak tags are accepted
+// because versions are the only validators the service issues.
+func parseIfMatch(r *http.Request) ([]int64, error) {
+	var (
+		versions []int64
+		anyTag   bool
+	)
+	for _, field := range r.Header.Values("If-Match") {
+		for _, tag := range strings.Split(field, ",") {
+			switch tag = strings.TrimSpace(tag); tag {
+			case "":
+				continue
+			case "*":
+				anyTag = true
+				continue
+			}
+			version, err := parseETag(tag)
+			if err != nil {
+				return nil, err
+			}
+			versions = append(versions, version)
+		}
+	}
+	if anyTag {
+		if len(versions) > 0 {
+			return nil, errInvalidIfMatch
+		}
+		return nil, nil
+	}
+	return versions, nil
+}
+
+func parseETag(tag string) (int64, error) {
+	tag = strings.TrimPrefix(tag, "W/")
+	unquoted, err := strconv.Unquote(tag)
+	if err != nil || !strings.HasPrefix(tag, `"`) {
+		return 0, errInvalidIfMatch
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, errInvalidIfMatch
+	}
+	return version, nil
+}
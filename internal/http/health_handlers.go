@@ -0,0 +1,74 @@
+package http
+
+import (
+	"net/http"
+
+	"pr-reviewer/internal/health"
+)
+
+const (
+	healthStatusOK   = "ok"
+	healthStatusFail = "fail"
+)
+
+type healthCheckDTO struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type healthResponse struct {
+	Status string                    `json:"status"`
+	Checks map[string]healthCheckDTO `json:"checks,omitempty"`
+}
+
+// healthHandlers serves the probes. They bypass authentication, rate limiting
+// and the request log, so that orchestrators need no token and do not flood
+// the logs. Check errors can name hosts or the schema state, so only the admin
+// listener sets detailed; elsewhere each check reports just ok or fail.
+type healthHandlers struct {
+	checker  *health.Checker
+	detailed bool
+}
+
+func newHealthHandlers(checker *health.Checker, detailed bool) *healthHandlers {
+	return &healthHandlers{checker: checker, detailed: detailed}
+}
+
+// Live answers as long as the process serves HTTP. It deliberately checks no
+// dependencies: a database outage should take the instance out of rotation,
+// not restart it.
+func (h *healthHandlers) Live(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, healthResponse{Status: healthStatusOK})
+}
+
+func (h *healthHandlers) Ready(w http.ResponseWriter, r *http.Request) {
+	h.writeReport(w, h.checker.Ready(r.Context()))
+}
+
+func (h *healthHandlers) Started(w http.ResponseWriter, r *http.Request) {
+	h.writeReport(w, h.checker.Started(r.Context()))
+}
+
+func (h *healthHandlers) writeReport(w http.ResponseWriter, report health.Report) {
+	resp := healthResponse{Status: healthStatusOK}
+	status := http.StatusOK
+	if !report.OK() {
+		resp.Status = healthStatusFail
+		status = http.StatusServiceUnavailable
+	}
+	if len(report.Results) > 0 {
+		resp.Checks = make(map[string]healthCheckDTO, len(report.Results))
+		for _, res := range report.Results {
+			check := healthCheckDTO{Status: healthStatusOK}
+			if res.Err != nil {
+				check.Status = healthStatusFail
+				if h.detailed {
+					check.Error = res.Err.Error()
+				}
+			}
+			resp.Checks[res.Name] = check
+		}
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, status, resp)
+}
@@ -1,15 +1,46 @@
 package http
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"pr-reviewer/internal/logging"
 	"pr-reviewer/internal/metrics"
+	"pr-reviewer/internal/tracing"
 )
 
+const requestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client-supplied request IDs so they cannot bloat
+// log lines.
+const maxRequestIDLength = 128
+
+// statusRecorder is shared by the middlewares of one request: the outermost
+// one creates it and the rest reuse it.
 type statusRecorder struct {
 	http.ResponseWriter
 	status int
+	// err is the error WriteError turned into the response, if any.
+	err error
+}
+
+func recordStatus(w http.ResponseWriter) *statusRecorder {
+	if rec, ok := w.(*statusRecorder); ok {
+		return rec
+	}
+	return &statusRecorder{ResponseWriter: w, status: http.StatusOK}
 }
 
 func (w *statusRecorder) WriteHeader(statusCode int) {
@@ -17,17 +48,141 @@ func (w *statusRecorder) WriteHeader(statusCode int) {
 	w.ResponseWriter.WriteHeader(statusCode)
 }
 
-func withHTTPMetrics(next http.Handler, m metrics.HTTPMetrics) http.Handler {
+// otherRoute labels requests that mux does not route, so that stray paths
+// cannot grow the number of metric series.
+const otherRoute = "other"
+
+// withHTTPMetrics labels requests with the route that mux matches rather
+// than the raw path.
+func withHTTPMetrics(next http.Handler, m metrics.HTTPMetrics, mux *http.ServeMux) http.Handler {
 	if m == nil {
 		return next
 	}
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		rec := recordStatus(w)
 		start := time.Now()
 
 		next.ServeHTTP(rec, r)
 
-		m.ObserveRequest(r.Method, r.URL.Path, rec.status, time.Since(start))
+		route := otherRoute
+		if _, pattern := mux.Handler(r); pattern != "" {
+			route = pattern
+		}
+		m.ObserveRequest(r.Method, route, rec.status, time.Since(start))
+	})
+}
+
+// withRequestLog keeps the client's X-Request-ID or assigns a new one, echoes
+// it in the response and puts it in the request context. With a logger it
+// also logs one line per request; server errors are logged at error level
+// together with the error behind them.
+func withRequestLog(next http.Handler, logger *slog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(requestIDHeader, id)
+		ctx := logging.WithRequestID(r.Context(), id)
+
+		rec := recordStatus(w)
+		start := time.Now()
+
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		if logger == nil {
+			return
+		}
+		level := slog.LevelInfo
+		if rec.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", rec.status),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("remote_addr", r.RemoteAddr),
+		}
+		if rec.err != nil {
+			attrs = append(attrs, slog.String("error", rec.err.Error()))
+		}
+		logger.LogAttrs(ctx, level, "HTTP request", attrs...)
+	})
+}
+
+// withTracing continues the caller's W3C trace, if any, with a server span
+// named after the route that mux matches. Requests mux does not route are
+// named by method only, so stray paths do not create new span names.
+func withTracing(next http.Handler, mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		name := r.Method
+		attrs := []attribute.KeyValue{
+			semconv.HTTPRequestMethodKey.String(r.Method),
+			semconv.URLPath(r.URL.Path),
+		}
+		if _, route := mux.Handler(r); route != "" {
+			name += " " + route
+			attrs = append(attrs, semconv.HTTPRoute(route))
+		}
+		ctx, span := tracing.Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+		defer span.End()
+
+		rec := recordStatus(w)
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(rec.status))
+		if rec.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(rec.status))
+			if rec.err != nil {
+				span.RecordError(rec.err)
+			}
+		}
 	})
 }
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+func withAuth(next http.Handler, tokens []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || !validToken(token, tokens) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeJSON(w, http.StatusUnauthorized, ErrorResponse{
+				Error: errorPayload{
+					Message: "unauthorized",
+				},
+			})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func validToken(token string, tokens []string) bool {
+	valid := false
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
@@ -1,12 +1,24 @@
 package http
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+
+	"pr-reviewer/internal/logging"
 	"pr-reviewer/internal/metrics"
+	"pr-reviewer/internal/tracing/tracingtest"
+	serviceMocks "pr-reviewer/mocks/service"
 )
 
 type stubHTTPMetrics struct {
@@ -27,9 +39,11 @@ func (s *stubHTTPMetrics) ObserveRequest(method, path string, status int, dur ti
 
 func TestMiddlewareObservesRequest(t *testing.T) {
 	metricsStub := &stubHTTPMetrics{}
-	handler := withHTTPMetrics(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/team/get", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusCreated)
-	}), metricsStub)
+	})
+	handler := withHTTPMetrics(mux, metricsStub, mux)
 
 	req := httptest.NewRequest(http.MethodPost, "/team/get?foo=bar", nil)
 	rr := httptest.NewRecorder()
@@ -48,6 +62,159 @@ func TestMiddlewareObservesRequest(t *testing.T) {
 	if metricsStub.status != http.StatusCreated {
 		t.Fatalf("expected status %d, got %d", http.StatusCreated, metricsStub.status)
 	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/wp-admin/setup.php", nil))
+	if metricsStub.path != otherRoute || metricsStub.status != http.StatusNotFound {
+		t.Fatalf("expected unrouted requests under %q, got %s with %d", otherRoute, metricsStub.path, metricsStub.status)
+	}
 }
 
 var _ metrics.HTTPMetrics = (*stubHTTPMetrics)(nil)
+
+func TestRequestLog_RequestID(t *testing.T) {
+	var seen string
+	handler := withRequestLog(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = logging.RequestID(r.Context())
+	}), nil)
+
+	tests := []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{"propagated", "client-id-42", true},
+		{"missing", "", false},
+		{"with spaces", "not a valid id", false},
+		{"too long", strings.Repeat("x", maxRequestIDLength+1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+			if tt.header != "" {
+				req.Header.Set(requestIDHeader, tt.header)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			got := rr.Header().Get(requestIDHeader)
+			if got == "" || got != seen {
+				t.Fatalf("expected the response and context to carry the same ID, got %q and %q", got, seen)
+			}
+			if tt.keep != (got == tt.header) {
+				t.Fatalf("header %q: unexpected request ID %q", tt.header, got)
+			}
+		})
+	}
+}
+
+func TestRouter_AccessLog(t *testing.T) {
+	var buf bytes.Buffer
+	prSvc := serviceMocks.NewMockPullRequestService(t)
+	prSvc.On("Merge", mock.Anything, "pr1", []int64(nil)).Return(nil, errors.New("connection refused"))
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{},
+		WithLogger(logging.New(&buf, slog.LevelInfo)))
+
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", strings.NewReader(`{"pull_request_id":"pr1"}`))
+	req.Header.Set(requestIDHeader, "req-1")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rr.Code)
+	}
+
+	var line struct {
+		Level     string `json:"level"`
+		Msg       string `json:"msg"`
+		Method    string `json:"method"`
+		Path      string `json:"path"`
+		Status    int    `json:"status"`
+		Error     string `json:"error"`
+		RequestID string `json:"request_id"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("expected one JSON log line, got %q: %v", buf.String(), err)
+	}
+	if line.Level != "ERROR" || line.Method != http.MethodPost || line.Path != "/pullRequest/merge" || line.Status != http.StatusInternalServerError {
+		t.Fatalf("unexpected access log line: %+v", line)
+	}
+	if line.Error != "connection refused" || line.RequestID != "req-1" {
+		t.Fatalf("expected the error and request ID in the log, got %+v", line)
+	}
+}
+
+func TestRouter_AuthTokens(t *testing.T) {
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{},
+		WithAuthTokens([]string{"0123456789abcdef"}))
+
+	for _, header := range []string{"", "Bearer wrong", "0123456789abcdef", "Basic 0123456789abcdef"} {
+		req := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		if rr.Code != http.StatusUnauthorized || rr.Header().Get("WWW-Authenticate") != "Bearer" {
+			t.Fatalf("%q: expected 401 with a challenge, got %d", header, rr.Code)
+		}
+	}
+
+	// The token passes; the missing team_name is rejected by the handler.
+	req := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+	req.Header.Set("Authorization", "Bearer 0123456789abcdef")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected the request to reach the handler, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected /readyz to stay open, got %d", rr.Code)
+	}
+
+	// /metrics moved to the admin listener.
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected /metrics to be an ordinary API path, got %d", rr.Code)
+	}
+}
+
+func TestRouter_WithoutAssignmentPreview(t *testing.T) {
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{},
+		WithAssignmentPreview(false))
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/pullRequest/previewAssignment", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for a disabled feature, got %d", rr.Code)
+	}
+}
+
+func TestRouter_ServerSpans(t *testing.T) {
+	spans := tracingtest.Record(t)
+	prSvc := serviceMocks.NewMockPullRequestService(t)
+	prSvc.On("Merge", mock.Anything, "pr1", []int64(nil)).Return(nil, errors.New("connection refused"))
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/pullRequest/merge", strings.NewReader(`{"pull_request_id":"pr1"}`)))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/no/such/route", nil))
+
+	merge := tracingtest.Find(t, spans, "POST /pullRequest/merge")
+	if merge.Status.Code != codes.Error || len(merge.Events) != 1 {
+		t.Fatalf("expected a failed span with the error recorded, got %+v", merge)
+	}
+	var statusCode int64
+	for _, attr := range merge.Attributes {
+		if attr.Key == semconv.HTTPResponseStatusCodeKey {
+			statusCode = attr.Value.AsInt64()
+		}
+	}
+	if statusCode != http.StatusInternalServerError {
+		t.Fatalf("expected status code 500 on the span, got %d", statusCode)
+	}
+
+	// Unrouted paths share one span name.
+	tracingtest.Find(t, spans, "GET")
+}
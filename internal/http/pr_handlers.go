@@ -2,6 +2,7 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
@@ -18,9 +19,17 @@ func newPRHandlers(prs service.PullRequestService) *prHandlers {
 }
 
 type createPRRequest struct {
-	ID     string `json:"pull_request_id"`
-	Name   string `json:"pull_request_name"`
-	Author string `json:"author_id"`
+	ID           string   `json:"pull_request_id"`
+	Name         string   `json:"pull_request_name"`
+	Author       string   `json:"author_id"`
+	Tags         []string `json:"tags"`
+	ChangedPaths []string `json:"changed_paths"`
+}
+
+type previewAssignmentRequest struct {
+	Author       string   `json:"author_id"`
+	Tags         []string `json:"tags"`
+	ChangedPaths []string `json:"changed_paths"`
 }
 
 type mergePRRequest struct {
@@ -33,19 +42,27 @@ type reassignPRRequest struct {
 }
 
 type pullRequestDTO struct {
-	ID                string                   `json:"pull_request_id"`
-	Name              string                   `json:"pull_request_name"`
-	AuthorID          string                   `json:"author_id"`
-	Status            domain.PullRequestStatus `json:"status"`
-	AssignedReviewers []string                 `json:"assigned_reviewers"`
-	CreatedAt         *time.Time               `json:"createdAt,omitempty"`
-	MergedAt          *time.Time               `json:"mergedAt,omitempty"`
+	ID                 string                   `json:"pull_request_id"`
+	Name               string                   `json:"pull_request_name"`
+	AuthorID           string                   `json:"author_id"`
+	Status             domain.PullRequestStatus `json:"status"`
+	AssignedReviewers  []string                 `json:"assigned_reviewers"`
+	CrossTeamReviewers map[string]string        `json:"cross_team_reviewers,omitempty"`
+	Tags               []string                 `json:"tags,omitempty"`
+	Understaffed       bool                     `json:"understaffed"`
+	CreatedAt          *time.Time               `json:"createdAt,omitempty"`
+	MergedAt           *time.Time               `json:"mergedAt,omitempty"`
+	Version            int64                    `json:"version"`
 }
 
 type createPRResponse struct {
 	PR pullRequestDTO `json:"pr"`
 }
 
+type getPRResponse struct {
+	PR pullRequestDTO `json:"pr"`
+}
+
 type mergePRResponse struct {
 	PR pullRequestDTO `json:"pr"`
 }
@@ -55,6 +72,20 @@ type reassignPRResponse struct {
 	ReplacedBy string         `json:"replaced_by"`
 }
 
+type rejectedCandidateDTO struct {
+	UserID   string                 `json:"user_id"`
+	TeamName string                 `json:"team_name"`
+	Reason   domain.RejectionReason `json:"reason"`
+}
+
+type previewAssignmentResponse struct {
+	AuthorID           string                 `json:"author_id"`
+	AssignedReviewers  []string               `json:"assigned_reviewers"`
+	CrossTeamReviewers map[string]string      `json:"cross_team_reviewers,omitempty"`
+	Understaffed       bool                   `json:"understaffed"`
+	Rejected           []rejectedCandidateDTO `json:"rejected"`
+}
+
 func (h *prHandlers) Create(w http.ResponseWriter, r *http.Request) {
 	var req createPRRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -66,21 +97,98 @@ func (h *prHandlers) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	tags, err := parseAssignmentHints(req.Tags, req.ChangedPaths)
+	if err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+
 	pr, err := h.prs.Create(r.Context(), domain.PullRequest{
-		ID:       req.ID,
-		Name:     req.Name,
-		AuthorID: req.Author,
+		ID:           req.ID,
+		Name:         req.Name,
+		AuthorID:     req.Author,
+		Tags:         tags,
+		ChangedPaths: req.ChangedPaths,
 	})
 	if err != nil {
 		WriteError(w, err)
 		return
 	}
 
+	setETag(w, *pr)
 	writeJSON(w, http.StatusCreated, createPRResponse{
 		PR: toPullRequestDTO(*pr),
 	})
 }
 
+func (h *prHandlers) PreviewAssignment(w http.ResponseWriter, r *http.Request) {
+	var req previewAssignmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "invalid request body")
+		return
+	}
+	if req.Author == "" {
+		writeBadRequest(w, "author_id is required")
+		return
+	}
+
+	tags, err := parseAssignmentHints(req.Tags, req.ChangedPaths)
+	if err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+
+	preview, err := h.prs.PreviewAssignment(r.Context(), domain.PullRequest{
+		AuthorID:     req.Author,
+		Tags:         tags,
+		ChangedPaths: req.ChangedPaths,
+	})
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	resp := previewAssignmentResponse{
+		AuthorID:           preview.AuthorID,
+		AssignedReviewers:  preview.AssignedReviewers,
+		CrossTeamReviewers: preview.CrossTeamReviewers,
+		Understaffed:       preview.Understaffed,
+		Rejected:           make([]rejectedCandidateDTO, 0, len(preview.Rejected)),
+	}
+	if resp.AssignedReviewers == nil {
+		resp.AssignedReviewers = []string{}
+	}
+	for _, c := range preview.Rejected {
+		resp.Rejected = append(resp.Rejected, rejectedCandidateDTO{
+			UserID:   c.UserID,
+			TeamName: c.TeamName,
+			Reason:   c.Reason,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Get returns the pull request with its version as the ETag, so clients can
+// make a later merge or reassign conditional on the state they read.
+func (h *prHandlers) Get(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		writeBadRequest(w, "pull_request_id is required")
+		return
+	}
+
+	pr, err := h.prs.GetPullRequest(r.Context(), prID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	setETag(w, *pr)
+	writeJSON(w, http.StatusOK, getPRResponse{
+		PR: toPullRequestDTO(*pr),
+	})
+}
+
 func (h *prHandlers) Merge(w http.ResponseWriter, r *http.Request) {
 	var req mergePRRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -91,13 +199,19 @@ func (h *prHandlers) Merge(w http.ResponseWriter, r *http.Request) {
 		writeBadRequest(w, "pull_request_id is required")
 		return
 	}
+	ifVersions, err := parseIfMatch(r)
+	if err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
 
-	pr, err := h.prs.Merge(r.Context(), req.ID)
+	pr, err := h.prs.Merge(r.Context(), req.ID, ifVersions)
 	if err != nil {
 		WriteError(w, err)
 		return
 	}
 
+	setETag(w, *pr)
 	writeJSON(w, http.StatusOK, mergePRResponse{
 		PR: toPullRequestDTO(*pr),
 	})
@@ -113,26 +227,51 @@ func (h *prHandlers) Reassign(w http.ResponseWriter, r *http.Request) {
 		writeBadRequest(w, "pull_request_id and old_user_id are required")
 		return
 	}
+	ifVersions, err := parseIfMatch(r)
+	if err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
 
-	pr, replacedBy, err := h.prs.Reassign(r.Context(), req.ID, req.OldReviewer)
+	pr, replacedBy, err := h.prs.Reassign(r.Context(), req.ID, req.OldReviewer, ifVersions)
 	if err != nil {
 		WriteError(w, err)
 		return
 	}
 
+	setETag(w, *pr)
 	writeJSON(w, http.StatusOK, reassignPRResponse{
 		PR:         toPullRequestDTO(*pr),
 		ReplacedBy: replacedBy,
 	})
 }
 
+// parseAssignmentHints validates the optional tags and changed paths that
+// steer reviewer selection and returns the normalized tags.
+func parseAssignmentHints(tags, changedPaths []string) ([]string, error) {
+	parsed, err := parseTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range changedPaths {
+		if path == "" {
+			return nil, errors.New("changed_paths must not contain empty paths")
+		}
+	}
+	return parsed, nil
+}
+
 func toPullRequestDTO(pr domain.PullRequest) pullRequestDTO {
 	dto := pullRequestDTO{
-		ID:                pr.ID,
-		Name:              pr.Name,
-		AuthorID:          pr.AuthorID,
-		Status:            pr.Status,
-		AssignedReviewers: pr.AssignedReviewers,
+		ID:                 pr.ID,
+		Name:               pr.Name,
+		AuthorID:           pr.AuthorID,
+		Status:             pr.Status,
+		AssignedReviewers:  pr.AssignedReviewers,
+		CrossTeamReviewers: pr.CrossTeamReviewers,
+		Tags:               pr.Tags,
+		Understaffed:       pr.Understaffed,
+		Version:            pr.Version,
 	}
 	if !pr.CreatedAt.IsZero() {
 		dto.CreatedAt = &pr.CreatedAt
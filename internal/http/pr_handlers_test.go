@@ -14,7 +14,7 @@ import (
 )
 
 func TestPRHandlers_Create_BadJSON(t *testing.T) {
-	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), &stubHTTPMetrics{})
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewBufferString("{"))
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -24,7 +24,7 @@ func TestPRHandlers_Create_BadJSON(t *testing.T) {
 }
 
 func TestPRHandlers_Create_MissingFields(t *testing.T) {
-	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), &stubHTTPMetrics{})
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 	body, _ := json.Marshal(map[string]any{"pull_request_id": "pr1"})
 	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewBuffer(body))
 	rr := httptest.NewRecorder()
@@ -43,7 +43,7 @@ func TestPRHandlers_Create_Success(t *testing.T) {
 		Status:            domain.PullRequestStatusOpen,
 		AssignedReviewers: []string{"u2", "u3"},
 	}, nil)
-	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, &stubHTTPMetrics{})
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 
 	body, _ := json.Marshal(map[string]any{
 		"pull_request_id":   "pr1",
@@ -64,7 +64,7 @@ func TestPRHandlers_Create_Success(t *testing.T) {
 func TestPRHandlers_Create_PRExists(t *testing.T) {
 	prSvc := serviceMocks.NewMockPullRequestService(t)
 	prSvc.On("Create", mock.Anything, mock.AnythingOfType("domain.PullRequest")).Return(nil, domain.NewDomainError(domain.ErrorCodePRExists, "exists"))
-	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, &stubHTTPMetrics{})
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 
 	body, _ := json.Marshal(map[string]any{
 		"pull_request_id":   "pr1",
@@ -80,7 +80,7 @@ func TestPRHandlers_Create_PRExists(t *testing.T) {
 }
 
 func TestPRHandlers_Merge_BadRequest(t *testing.T) {
-	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), &stubHTTPMetrics{})
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 	req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", bytes.NewBufferString("{}"))
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -91,8 +91,8 @@ func TestPRHandlers_Merge_BadRequest(t *testing.T) {
 
 func TestPRHandlers_Merge_Success(t *testing.T) {
 	prSvc := serviceMocks.NewMockPullRequestService(t)
-	prSvc.On("Merge", mock.Anything, "pr1").Return(&domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusMerged}, nil)
-	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, &stubHTTPMetrics{})
+	prSvc.On("Merge", mock.Anything, "pr1", []int64(nil)).Return(&domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusMerged}, nil)
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 
 	body, _ := json.Marshal(map[string]any{"pull_request_id": "pr1"})
 	req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", bytes.NewBuffer(body))
@@ -101,13 +101,13 @@ func TestPRHandlers_Merge_Success(t *testing.T) {
 	if rr.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d", rr.Code)
 	}
-	prSvc.AssertCalled(t, "Merge", mock.Anything, "pr1")
+	prSvc.AssertCalled(t, "Merge", mock.Anything, "pr1", []int64(nil))
 }
 
 func TestPRHandlers_Merge_NotFound(t *testing.T) {
 	prSvc := serviceMocks.NewMockPullRequestService(t)
-	prSvc.On("Merge", mock.Anything, "pr1").Return(nil, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
-	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, &stubHTTPMetrics{})
+	prSvc.On("Merge", mock.Anything, "pr1", []int64(nil)).Return(nil, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 
 	body, _ := json.Marshal(map[string]any{"pull_request_id": "pr1"})
 	req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", bytes.NewBuffer(body))
@@ -118,8 +118,103 @@ func TestPRHandlers_Merge_NotFound(t *testing.T) {
 	}
 }
 
+func TestPRHandlers_Get(t *testing.T) {
+	prSvc := serviceMocks.NewMockPullRequestService(t)
+	prSvc.On("GetPullRequest", mock.Anything, "pr1").Return(&domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, Version: 3}, nil)
+	prSvc.On("GetPullRequest", mock.Anything, "ghost").Return(nil, domain.NewDomainError(domain.ErrorCodeNotFound, "pull request not found"))
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, target, nil))
+		return rr
+	}
+
+	rr := get("/pullRequest/get?pull_request_id=pr1")
+	var resp getPRResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil || rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d, %v", rr.Code, err)
+	}
+	if etag := rr.Header().Get("ETag"); etag != `"3"` || resp.PR.Version != 3 {
+		t.Fatalf("expected version 3 as ETag, got %q, %+v", etag, resp.PR)
+	}
+	if rr := get("/pullRequest/get"); rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without pull_request_id, got %d", rr.Code)
+	}
+	if rr := get("/pullRequest/get?pull_request_id=ghost"); rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rr.Code)
+	}
+}
+
+func TestPRHandlers_Merge_IfMatch(t *testing.T) {
+	cases := []struct {
+		ifMatch  []string
+		versions []int64
+	}{
+		{ifMatch: []string{`"3"`}, versions: []int64{3}},
+		{ifMatch: []string{`W/"3"`}, versions: []int64{3}},
+		{ifMatch: []string{`"2", W/"3" ,"5"`}, versions: []int64{2, 3, 5}},
+		{ifMatch: []string{`"2"`, `"3"`}, versions: []int64{2, 3}},
+		{ifMatch: []string{"*"}, versions: nil},
+		{ifMatch: []string{" * "}, versions: nil},
+	}
+	for _, tc := range cases {
+		prSvc := serviceMocks.NewMockPullRequestService(t)
+		prSvc.On("Merge", mock.Anything, "pr1", tc.versions).Return(&domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusMerged, Version: 4}, nil)
+		router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+		body, _ := json.Marshal(map[string]any{"pull_request_id": "pr1"})
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", bytes.NewBuffer(body))
+		for _, field := range tc.ifMatch {
+			req.Header.Add("If-Match", field)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("If-Match %q: expected 200, got %d", tc.ifMatch, rr.Code)
+		}
+		if etag := rr.Header().Get("ETag"); etag != `"4"` {
+			t.Fatalf("If-Match %q: expected ETag \"4\", got %q", tc.ifMatch, etag)
+		}
+	}
+}
+
+func TestPRHandlers_Merge_VersionMismatch(t *testing.T) {
+	prSvc := serviceMocks.NewMockPullRequestService(t)
+	prSvc.On("Merge", mock.Anything, "pr1", []int64{1}).Return(nil, domain.NewDomainError(domain.ErrorCodeVersionMismatch, "pull request has been modified"))
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	body, _ := json.Marshal(map[string]any{"pull_request_id": "pr1"})
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/merge", bytes.NewBuffer(body))
+	req.Header.Set("If-Match", `"1"`)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected 412, got %d", rr.Code)
+	}
+}
+
+func TestPRHandlers_InvalidIfMatch(t *testing.T) {
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	for _, ifMatch := range []string{"3", `"abc"`, `"0"`, `'3'`, `"3", "abc"`, `"3", *`, `"3" "4"`} {
+		for path, body := range map[string]string{
+			"/pullRequest/merge":    `{"pull_request_id":"pr1"}`,
+			"/pullRequest/reassign": `{"pull_request_id":"pr1","old_user_id":"u2"}`,
+		} {
+			req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+			req.Header.Set("If-Match", ifMatch)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			if rr.Code != http.StatusBadRequest {
+				t.Fatalf("%s with If-Match %s: expected 400, got %d", path, ifMatch, rr.Code)
+			}
+		}
+	}
+}
+
 func TestPRHandlers_Reassign_BadRequest(t *testing.T) {
-	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), &stubHTTPMetrics{})
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 	req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", bytes.NewBufferString("{}"))
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -130,8 +225,8 @@ func TestPRHandlers_Reassign_BadRequest(t *testing.T) {
 
 func TestPRHandlers_Reassign_Success(t *testing.T) {
 	prSvc := serviceMocks.NewMockPullRequestService(t)
-	prSvc.On("Reassign", mock.Anything, "pr1", "u2").Return(&domain.PullRequest{ID: "pr1"}, "u3", nil)
-	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, &stubHTTPMetrics{})
+	prSvc.On("Reassign", mock.Anything, "pr1", "u2", []int64(nil)).Return(&domain.PullRequest{ID: "pr1"}, "u3", nil)
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 
 	body, _ := json.Marshal(map[string]any{"pull_request_id": "pr1", "old_user_id": "u2"})
 	req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", bytes.NewBuffer(body))
@@ -140,7 +235,7 @@ func TestPRHandlers_Reassign_Success(t *testing.T) {
 	if rr.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d", rr.Code)
 	}
-	prSvc.AssertCalled(t, "Reassign", mock.Anything, "pr1", "u2")
+	prSvc.AssertCalled(t, "Reassign", mock.Anything, "pr1", "u2", []int64(nil))
 }
 
 func TestPRHandlers_Reassign_DomainErrors(t *testing.T) {
@@ -153,12 +248,13 @@ func TestPRHandlers_Reassign_DomainErrors(t *testing.T) {
 		{"merged", domain.NewDomainError(domain.ErrorCodePRMerged, "merged"), http.StatusConflict},
 		{"notassigned", domain.NewDomainError(domain.ErrorCodeNotAssigned, "no"), http.StatusConflict},
 		{"nocandidate", domain.NewDomainError(domain.ErrorCodeNoCandidate, "none"), http.StatusConflict},
+		{"versionmismatch", domain.NewDomainError(domain.ErrorCodeVersionMismatch, "stale"), http.StatusPreconditionFailed},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			prSvc := serviceMocks.NewMockPullRequestService(t)
-			prSvc.On("Reassign", mock.Anything, "pr1", "u2").Return(nil, "", tc.err)
-			router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, &stubHTTPMetrics{})
+			prSvc.On("Reassign", mock.Anything, "pr1", "u2", []int64(nil)).Return(nil, "", tc.err)
+			router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 			body, _ := json.Marshal(map[string]any{"pull_request_id": "pr1", "old_user_id": "u2"})
 			req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", bytes.NewBuffer(body))
 			rr := httptest.NewRecorder()
@@ -169,3 +265,94 @@ func TestPRHandlers_Reassign_DomainErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestPRHandlers_Reassign_CrossTeamReviewer(t *testing.T) {
+	prSvc := serviceMocks.NewMockPullRequestService(t)
+	prSvc.On("Reassign", mock.Anything, "pr1", "u2", []int64(nil)).Return(&domain.PullRequest{
+		ID:                 "pr1",
+		AssignedReviewers:  []string{"b1"},
+		CrossTeamReviewers: map[string]string{"b1": "payments"},
+	}, "b1", nil)
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	body, _ := json.Marshal(map[string]any{"pull_request_id": "pr1", "old_user_id": "u2"})
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+
+	var resp reassignPRResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.PR.CrossTeamReviewers["b1"] != "payments" {
+		t.Fatalf("expected b1 borrowed from payments, got %v", resp.PR.CrossTeamReviewers)
+	}
+}
+
+func TestPRHandlers_Create_PassesChangedPaths(t *testing.T) {
+	prSvc := serviceMocks.NewMockPullRequestService(t)
+	prSvc.On("Create", mock.Anything, mock.MatchedBy(func(pr domain.PullRequest) bool {
+		return len(pr.ChangedPaths) == 2 && pr.ChangedPaths[0] == "migrations/0001_init.sql" && pr.ChangedPaths[1] == "internal/http/router.go"
+	})).Return(&domain.PullRequest{ID: "pr1", AssignedReviewers: []string{"dba"}}, nil)
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	body, _ := json.Marshal(map[string]any{
+		"pull_request_id":   "pr1",
+		"pull_request_name": "Test",
+		"author_id":         "u1",
+		"changed_paths":     []string{"migrations/0001_init.sql", "internal/http/router.go"},
+	})
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", rr.Code)
+	}
+}
+
+func TestPRHandlers_PreviewAssignment(t *testing.T) {
+	prSvc := serviceMocks.NewMockPullRequestService(t)
+	prSvc.On("PreviewAssignment", mock.Anything, mock.MatchedBy(func(pr domain.PullRequest) bool {
+		return pr.AuthorID == "u1" && len(pr.Tags) == 1 && pr.Tags[0] == "go"
+	})).Return(&domain.AssignmentPreview{
+		AuthorID:          "u1",
+		AssignedReviewers: []string{"u2"},
+		Understaffed:      true,
+		Rejected: []domain.RejectedCandidate{
+			{UserID: "u1", TeamName: "backend", Reason: domain.RejectionAuthor},
+			{UserID: "u3", TeamName: "backend", Reason: domain.RejectionOutOfOffice},
+		},
+	}, nil)
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), prSvc, serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	body, _ := json.Marshal(map[string]any{"author_id": "u1", "tags": []string{"Go"}})
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/previewAssignment", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+
+	var resp previewAssignmentResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.AssignedReviewers) != 1 || !resp.Understaffed || len(resp.Rejected) != 2 || resp.Rejected[1].Reason != domain.RejectionOutOfOffice {
+		t.Fatalf("unexpected preview: %+v", resp)
+	}
+}
+
+func TestPRHandlers_PreviewAssignment_BadRequest(t *testing.T) {
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+	for _, body := range []string{`{`, `{}`, `{"author_id":"u1","changed_paths":[""]}`} {
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/previewAssignment", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", body, rr.Code)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"container/list"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit is the per-client request budget: RequestsPerSecond refills a
+// bucket of Burst requests. Zero RequestsPerSecond disables limiting.
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// maxBuckets bounds the number of tracked clients. Past it the least
+// recently seen client is forgotten, which at worst hands it a fresh burst.
+const maxBuckets = 10000
+
+type bucket struct {
+	key    string
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter keeps a token bucket per client. The limit is read on every
+// request, so it can change at runtime.
+type rateLimiter struct {
+	limit      func() RateLimit
+	now        func() time.Time
+	maxBuckets int
+
+	mu      sync.Mutex
+	buckets map[string]*list.Element
+	// recent orders the buckets by last use, most recent first.
+	recent *list.List
+}
+
+func newRateLimiter(limit func() RateLimit) *rateLimiter {
+	return &rateLimiter{
+		limit:      limit,
+		now:        time.Now,
+		maxBuckets: maxBuckets,
+		buckets:    make(map[string]*list.Element),
+		recent:     list.New(),
+	}
+}
+
+// allow takes a token for key, or reports how long to wait for one.
+func (l *rateLimiter) allow(key string) (bool, time.Duration) {
+	limit := l.limit()
+	if limit.RequestsPerSecond <= 0 {
+		return true, 0
+	}
+	burst := float64(max(limit.Burst, 1))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var b *bucket
+	if e, ok := l.buckets[key]; ok {
+		l.recent.MoveToFront(e)
+		b = e.Value.(*bucket)
+	} else {
+		for len(l.buckets) >= l.maxBuckets {
+			oldest := l.recent.Back()
+			l.recent.Remove(oldest)
+			delete(l.buckets, oldest.Value.(*bucket).key)
+		}
+		b = &bucket{key: key, tokens: burst, last: now}
+		l.buckets[key] = l.recent.PushFront(b)
+	}
+
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*limit.RequestsPerSecond)
+	b.last = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / limit.RequestsPerSecond * float64(time.Second))
+	return false, wait
+}
+
+func withRateLimit(next http.Handler, limiter *rateLimiter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ok, wait := limiter.allow(clientKey(r))
+		if !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			writeJSON(w, http.StatusTooManyRequests, ErrorResponse{
+				Error: errorPayload{
+					Message: "rate limit exceeded",
+				},
+			})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func clientKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"pr-reviewer/internal/domain"
+	serviceMocks "pr-reviewer/mocks/service"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+	limit := RateLimit{RequestsPerSecond: 2, Burst: 3}
+	l := newRateLimiter(func() RateLimit { return limit })
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := l.allow("a"); !ok {
+			t.Fatalf("request %d within the burst was limited", i)
+		}
+	}
+	ok, wait := l.allow("a")
+	if ok || wait != 500*time.Millisecond {
+		t.Fatalf("expected to wait 500ms, got ok=%v wait=%v", ok, wait)
+	}
+	if ok, _ := l.allow("b"); !ok {
+		t.Fatalf("clients must not share a bucket")
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if ok, _ := l.allow("a"); !ok {
+		t.Fatalf("expected a token after refill")
+	}
+
+	// The limit is read on every request.
+	limit = RateLimit{}
+	for i := 0; i < 10; i++ {
+		if ok, _ := l.allow("a"); !ok {
+			t.Fatalf("disabled limit still limits")
+		}
+	}
+}
+
+func TestRateLimiter_BoundsTrackedClients(t *testing.T) {
+	now := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+	l := newRateLimiter(func() RateLimit { return RateLimit{RequestsPerSecond: 0.001, Burst: 2} })
+	l.now = func() time.Time { return now }
+	l.maxBuckets = 3
+
+	// Every client keeps a partly used bucket, so none is safe to forget.
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		now = now.Add(time.Second)
+		if ok, _ := l.allow(key); !ok {
+			t.Fatalf("first request from %s was limited", key)
+		}
+		if len(l.buckets) > l.maxBuckets || l.recent.Len() != len(l.buckets) {
+			t.Fatalf("tracking %d clients, limit is %d", len(l.buckets), l.maxBuckets)
+		}
+	}
+
+	// Using c again moves it ahead of d, so the next new client evicts d
+	// and c keeps its exhausted bucket.
+	if _, ok := l.buckets["a"]; ok {
+		t.Fatalf("expected the oldest client to be evicted")
+	}
+	l.allow("c")
+	l.allow("f")
+	if _, ok := l.buckets["d"]; ok {
+		t.Fatalf("expected d to be evicted before the recently used c")
+	}
+	if ok, _ := l.allow("c"); ok {
+		t.Fatalf("expected c to keep its exhausted bucket")
+	}
+}
+
+func TestRouter_RateLimit(t *testing.T) {
+	teamSvc := serviceMocks.NewMockTeamService(t)
+	teamSvc.On("GetTeam", mock.Anything, "backend").Return(&domain.Team{Name: "backend"}, nil).Once()
+	router := NewRouter(teamSvc, serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{},
+		WithRateLimit(func() RateLimit { return RateLimit{RequestsPerSecond: 0.001, Burst: 1} }),
+	)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil))
+	if rr.Code != http.StatusTooManyRequests || rr.Header().Get("Retry-After") == "" {
+		t.Fatalf("expected 429 with Retry-After, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected /healthz not to be limited, got %d", rr.Code)
+	}
+}
@@ -1,37 +1,129 @@
 package http
 
 import (
+	"log/slog"
 	"net/http"
 
-	"github.com/prometheus/client_golang/prometheus/promhttp"
-
+	"pr-reviewer/internal/health"
 	"pr-reviewer/internal/metrics"
 	"pr-reviewer/internal/service"
 )
 
-func NewRouter(teamSvc service.TeamService, userSvc service.UserService, prSvc service.PullRequestService, httpMetrics metrics.HTTPMetrics) http.Handler {
+type routerOptions struct {
+	authTokens        []string
+	assignmentPreview bool
+	rateLimit         func() RateLimit
+	logger            *slog.Logger
+	health            *health.Checker
+}
+
+type RouterOption func(*routerOptions)
+
+// WithAuthTokens requires every API request to carry one of tokens as a
+// bearer token. The health probes stay open.
+func WithAuthTokens(tokens []string) RouterOption {
+	return func(o *routerOptions) {
+		o.authTokens = tokens
+	}
+}
+
+func WithAssignmentPreview(enabled bool) RouterOption {
+	return func(o *routerOptions) {
+		o.assignmentPreview = enabled
+	}
+}
+
+// WithRateLimit limits API requests per client address. limit is read on
+// every request.
+func WithRateLimit(limit func() RateLimit) RouterOption {
+	return func(o *routerOptions) {
+		o.rateLimit = limit
+	}
+}
+
+// WithLogger logs one line per API request; without it requests only get a
+// request ID.
+func WithLogger(logger *slog.Logger) RouterOption {
+	return func(o *routerOptions) {
+		o.logger = logger
+	}
+}
+
+// WithHealthChecker backs /readyz and /startupz with checker. Without it they
+// always report ready. The API listener reports only whether each check
+// passed; the reasons are served by the admin router.
+func WithHealthChecker(checker *health.Checker) RouterOption {
+	return func(o *routerOptions) {
+		o.health = checker
+	}
+}
+
+func NewRouter(teamSvc service.TeamService, userSvc service.UserService, prSvc service.PullRequestService, codeOwnerSvc service.CodeOwnerService, httpMetrics metrics.HTTPMetrics, opts ...RouterOption) http.Handler {
+	options := routerOptions{assignmentPreview: true}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.health == nil {
+		options.health = health.NewChecker()
+	}
+
 	mux := http.NewServeMux()
 
 	teamHandlers := newTeamHandlers(teamSvc)
 	userHandlers := newUserHandlers(userSvc)
 	prHandlers := newPRHandlers(prSvc)
+	codeOwnerHandlers := newCodeOwnerHandlers(codeOwnerSvc)
 
 	mux.HandleFunc("/team/add", method("POST", teamHandlers.Add))
 	mux.HandleFunc("/team/get", method("GET", teamHandlers.Get))
+	mux.HandleFunc("/team/setDefaultReviewCapacity", method("POST", teamHandlers.SetDefaultReviewCapacity))
+	mux.HandleFunc("/team/setFallbackTeams", method("POST", teamHandlers.SetFallbackTeams))
+	mux.HandleFunc("/team/setPolicy", method("POST", teamHandlers.SetPolicy))
 
 	mux.HandleFunc("/users/setIsActive", method("POST", userHandlers.SetIsActive))
+	mux.HandleFunc("/users/setReviewCapacity", method("POST", userHandlers.SetReviewCapacity))
+	mux.HandleFunc("/users/setWorkingHours", method("POST", userHandlers.SetWorkingHours))
+	mux.HandleFunc("/users/setSeniority", method("POST", userHandlers.SetSeniority))
 	mux.HandleFunc("/users/getReview", method("GET", userHandlers.GetReview))
+	mux.HandleFunc("/users/addUnavailability", method("POST", userHandlers.AddUnavailability))
+	mux.HandleFunc("/users/getUnavailability", method("GET", userHandlers.GetUnavailability))
+	mux.HandleFunc("/users/removeUnavailability", method("POST", userHandlers.RemoveUnavailability))
+	mux.HandleFunc("/users/tags", methods(map[string]http.HandlerFunc{
+		http.MethodGet:  userHandlers.GetTags,
+		http.MethodPost: userHandlers.SetTags,
+	}))
 
 	mux.HandleFunc("/pullRequest/create", method("POST", prHandlers.Create))
+	mux.HandleFunc("/pullRequest/get", method("GET", prHandlers.Get))
 	mux.HandleFunc("/pullRequest/merge", method("POST", prHandlers.Merge))
 	mux.HandleFunc("/pullRequest/reassign", method("POST", prHandlers.Reassign))
+	if options.assignmentPreview {
+		mux.HandleFunc("/pullRequest/previewAssignment", method("POST", prHandlers.PreviewAssignment))
+	}
 
-	metricsHandler := promhttp.Handler()
-	wrapped := withHTTPMetrics(mux, httpMetrics)
+	mux.HandleFunc("/codeOwners/upload", method("POST", codeOwnerHandlers.Upload))
+	mux.HandleFunc("/codeOwners/get", method("GET", codeOwnerHandlers.Get))
+
+	// Probes are served outside the API middleware. /metrics is served by
+	// the admin router only.
+	probes := http.NewServeMux()
+	healthHandlers := newHealthHandlers(options.health, false)
+	probes.HandleFunc("/healthz", method("GET", healthHandlers.Live))
+	probes.HandleFunc("/readyz", method("GET", healthHandlers.Ready))
+	probes.HandleFunc("/startupz", method("GET", healthHandlers.Started))
+
+	var api http.Handler = mux
+	if len(options.authTokens) > 0 {
+		api = withAuth(api, options.authTokens)
+	}
+	if options.rateLimit != nil {
+		api = withRateLimit(api, newRateLimiter(options.rateLimit))
+	}
+	wrapped := withTracing(withRequestLog(withHTTPMetrics(api, httpMetrics, mux), options.logger), mux)
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path == "/metrics" {
-			metricsHandler.ServeHTTP(w, r)
+		if h, route := probes.Handler(r); route != "" {
+			h.ServeHTTP(w, r)
 			return
 		}
 		wrapped.ServeHTTP(w, r)
@@ -47,3 +139,14 @@ func method(expected string, h func(http.ResponseWriter, *http.Request)) func(ht
 		h(w, r)
 	}
 }
+
+func methods(handlers map[string]http.HandlerFunc) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h, ok := handlers[r.Method]
+		if !ok {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		h(w, r)
+	}
+}
@@ -17,19 +17,51 @@ func newTeamHandlers(teams service.TeamService) *teamHandlers {
 }
 
 type teamMemberDTO struct {
-	ID       string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	ID        string `json:"user_id"`
+	Username  string `json:"username"`
+	IsActive  bool   `json:"is_active"`
+	Timezone  string `json:"timezone,omitempty"`
+	WorkStart string `json:"work_start,omitempty"`
+	WorkEnd   string `json:"work_end,omitempty"`
+	// ReviewCapacity is the member's own limit; Load carries the effective one.
+	ReviewCapacity *int           `json:"review_capacity,omitempty"`
+	Seniority      string         `json:"seniority,omitempty"`
+	Load           *memberLoadDTO `json:"load,omitempty"`
+}
+
+type memberLoadDTO struct {
+	OpenReviews int  `json:"open_reviews"`
+	Capacity    *int `json:"capacity"`
 }
 
 type teamDTO struct {
-	Name    string          `json:"team_name"`
-	Members []teamMemberDTO `json:"members"`
+	Name                  string          `json:"team_name"`
+	DefaultReviewCapacity *int            `json:"default_review_capacity,omitempty"`
+	RequireSeniorReviewer bool            `json:"require_senior_reviewer"`
+	FallbackTeams         []string        `json:"fallback_teams,omitempty"`
+	Members               []teamMemberDTO `json:"members"`
 }
 
 type addTeamRequest struct {
-	TeamName string          `json:"team_name"`
-	Members  []teamMemberDTO `json:"members"`
+	TeamName              string          `json:"team_name"`
+	DefaultReviewCapacity *int            `json:"default_review_capacity"`
+	RequireSeniorReviewer bool            `json:"require_senior_reviewer"`
+	Members               []teamMemberDTO `json:"members"`
+}
+
+type setDefaultReviewCapacityRequest struct {
+	TeamName              string `json:"team_name"`
+	DefaultReviewCapacity *int   `json:"default_review_capacity"`
+}
+
+type setTeamPolicyRequest struct {
+	TeamName              string `json:"team_name"`
+	RequireSeniorReviewer bool   `json:"require_senior_reviewer"`
+}
+
+type setFallbackTeamsRequest struct {
+	TeamName      string   `json:"team_name"`
+	FallbackTeams []string `json:"fallback_teams"`
 }
 
 type addTeamResponse struct {
@@ -47,19 +79,43 @@ func (h *teamHandlers) Add(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if req.DefaultReviewCapacity != nil && *req.DefaultReviewCapacity < 0 {
+		writeBadRequest(w, "default_review_capacity must not be negative")
+		return
+	}
+
 	team := domain.Team{
-		Name: req.TeamName,
+		Name:                  req.TeamName,
+		DefaultReviewCapacity: req.DefaultReviewCapacity,
+		Policy:                domain.TeamPolicy{RequireSeniorReviewer: req.RequireSeniorReviewer},
 	}
 	for _, m := range req.Members {
 		if m.ID == "" || m.Username == "" {
 			writeBadRequest(w, "member user_id and username are required")
 			return
 		}
+		hours, err := parseWorkingHours(m.Timezone, m.WorkStart, m.WorkEnd)
+		if err != nil {
+			writeBadRequest(w, "member "+m.ID+": "+err.Error())
+			return
+		}
+		if m.ReviewCapacity != nil && *m.ReviewCapacity < 0 {
+			writeBadRequest(w, "member "+m.ID+": review_capacity must not be negative")
+			return
+		}
+		if m.Seniority != "" && !domain.Seniority(m.Seniority).Valid() {
+			writeBadRequest(w, "member "+m.ID+": seniority must be one of junior, middle, senior")
+			return
+		}
 		team.Members = append(team.Members, domain.User{
-			ID:       m.ID,
-			Username: m.Username,
-			TeamName: req.TeamName,
-			IsActive: m.IsActive,
+			ID:             m.ID,
+			Username:       m.Username,
+			TeamName:       req.TeamName,
+			IsActive:       m.IsActive,
+			Timezone:       m.Timezone,
+			WorkingHours:   hours,
+			ReviewCapacity: m.ReviewCapacity,
+			Seniority:      domain.Seniority(m.Seniority),
 		})
 	}
 
@@ -92,17 +148,114 @@ func (h *teamHandlers) Get(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, toTeamDTO(*team))
 }
 
+func (h *teamHandlers) SetDefaultReviewCapacity(w http.ResponseWriter, r *http.Request) {
+	var req setDefaultReviewCapacityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "invalid request body")
+		return
+	}
+	if req.TeamName == "" {
+		writeBadRequest(w, "team_name is required")
+		return
+	}
+	if req.DefaultReviewCapacity != nil && *req.DefaultReviewCapacity < 0 {
+		writeBadRequest(w, "default_review_capacity must not be negative")
+		return
+	}
+
+	team, err := h.teams.SetDefaultReviewCapacity(r.Context(), req.TeamName, req.DefaultReviewCapacity)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toTeamDTO(*team))
+}
+
+func (h *teamHandlers) SetPolicy(w http.ResponseWriter, r *http.Request) {
+	var req setTeamPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "invalid request body")
+		return
+	}
+	if req.TeamName == "" {
+		writeBadRequest(w, "team_name is required")
+		return
+	}
+
+	team, err := h.teams.SetPolicy(r.Context(), req.TeamName, domain.TeamPolicy{
+		RequireSeniorReviewer: req.RequireSeniorReviewer,
+	})
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toTeamDTO(*team))
+}
+
+func (h *teamHandlers) SetFallbackTeams(w http.ResponseWriter, r *http.Request) {
+	var req setFallbackTeamsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "invalid request body")
+		return
+	}
+	if req.TeamName == "" {
+		writeBadRequest(w, "team_name is required")
+		return
+	}
+
+	seen := make(map[string]struct{}, len(req.FallbackTeams))
+	for _, fallback := range req.FallbackTeams {
+		if fallback == "" {
+			writeBadRequest(w, "fallback team name must not be empty")
+			return
+		}
+		if fallback == req.TeamName {
+			writeBadRequest(w, "team cannot fall back to itself")
+			return
+		}
+		if _, dup := seen[fallback]; dup {
+			writeBadRequest(w, "duplicate fallback team "+fallback)
+			return
+		}
+		seen[fallback] = struct{}{}
+	}
+
+	team, err := h.teams.SetFallbackTeams(r.Context(), req.TeamName, req.FallbackTeams)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toTeamDTO(*team))
+}
+
 func toTeamDTO(team domain.Team) teamDTO {
 	members := make([]teamMemberDTO, 0, len(team.Members))
 	for _, m := range team.Members {
-		members = append(members, teamMemberDTO{
-			ID:       m.ID,
-			Username: m.Username,
-			IsActive: m.IsActive,
-		})
+		member := teamMemberDTO{
+			ID:             m.ID,
+			Username:       m.Username,
+			IsActive:       m.IsActive,
+			Timezone:       m.Timezone,
+			ReviewCapacity: m.ReviewCapacity,
+			Seniority:      string(m.Seniority),
+		}
+		member.WorkStart, member.WorkEnd = formatWorkingHours(m.WorkingHours)
+		if l, ok := team.Load[m.ID]; ok {
+			member.Load = &memberLoadDTO{
+				OpenReviews: l.OpenReviews,
+				Capacity:    l.Capacity,
+			}
+		}
+		members = append(members, member)
 	}
 	return teamDTO{
-		Name:    team.Name,
-		Members: members,
+		Name:                  team.Name,
+		DefaultReviewCapacity: team.DefaultReviewCapacity,
+		RequireSeniorReviewer: team.Policy.RequireSeniorReviewer,
+		FallbackTeams:         team.FallbackTeams,
+		Members:               members,
 	}
 }
@@ -14,7 +14,7 @@ import (
 )
 
 func TestTeamHandlers_Add_BadJSON(t *testing.T) {
-	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), &stubHTTPMetrics{})
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 
 	req := httptest.NewRequest(http.MethodPost, "/team/add", bytes.NewBufferString("{"))
 	rr := httptest.NewRecorder()
@@ -26,7 +26,7 @@ func TestTeamHandlers_Add_BadJSON(t *testing.T) {
 }
 
 func TestTeamHandlers_Add_MissingName(t *testing.T) {
-	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), &stubHTTPMetrics{})
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 
 	body, _ := json.Marshal(map[string]any{
 		"members": []map[string]any{},
@@ -47,7 +47,7 @@ func TestTeamHandlers_Add_Success(t *testing.T) {
 		Members: []domain.User{{ID: "u1", Username: "Alice"}},
 	}, nil)
 
-	router := NewRouter(teamSvc, serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), &stubHTTPMetrics{})
+	router := NewRouter(teamSvc, serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 
 	body, _ := json.Marshal(map[string]any{
 		"team_name": "backend",
@@ -71,7 +71,7 @@ func TestTeamHandlers_Add_DomainError(t *testing.T) {
 	teamSvc := serviceMocks.NewMockTeamService(t)
 	teamSvc.On("AddTeam", mock.Anything, mock.Anything).Return(nil, domain.NewDomainError(domain.ErrorCodeTeamExists, "exists"))
 
-	router := NewRouter(teamSvc, serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), &stubHTTPMetrics{})
+	router := NewRouter(teamSvc, serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 
 	body, _ := json.Marshal(map[string]any{
 		"team_name": "backend",
@@ -86,7 +86,7 @@ func TestTeamHandlers_Add_DomainError(t *testing.T) {
 }
 
 func TestTeamHandlers_Get_BadRequest(t *testing.T) {
-	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), &stubHTTPMetrics{})
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 
 	req := httptest.NewRequest(http.MethodGet, "/team/get", nil)
 	rr := httptest.NewRecorder()
@@ -100,7 +100,7 @@ func TestTeamHandlers_Get_BadRequest(t *testing.T) {
 func TestTeamHandlers_Get_Success(t *testing.T) {
 	teamSvc := serviceMocks.NewMockTeamService(t)
 	teamSvc.On("GetTeam", mock.Anything, "backend").Return(&domain.Team{Name: "backend"}, nil)
-	router := NewRouter(teamSvc, serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), &stubHTTPMetrics{})
+	router := NewRouter(teamSvc, serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 
 	req := httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil)
 	rr := httptest.NewRecorder()
@@ -114,7 +114,7 @@ func TestTeamHandlers_Get_Success(t *testing.T) {
 func TestTeamHandlers_Get_NotFound(t *testing.T) {
 	teamSvc := serviceMocks.NewMockTeamService(t)
 	teamSvc.On("GetTeam", mock.Anything, "backend").Return(nil, domain.NewDomainError(domain.ErrorCodeNotFound, "missing"))
-	router := NewRouter(teamSvc, serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), &stubHTTPMetrics{})
+	router := NewRouter(teamSvc, serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 
 	req := httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil)
 	rr := httptest.NewRecorder()
@@ -124,3 +124,117 @@ func TestTeamHandlers_Get_NotFound(t *testing.T) {
 		t.Fatalf("expected 404, got %d", rr.Code)
 	}
 }
+
+func TestTeamHandlers_Get_IncludesLoad(t *testing.T) {
+	capacity := 2
+	teamSvc := serviceMocks.NewMockTeamService(t)
+	teamSvc.On("GetTeam", mock.Anything, "backend").Return(&domain.Team{
+		Name:    "backend",
+		Members: []domain.User{{ID: "u1", Username: "Alice", IsActive: true}},
+		Load:    map[string]domain.ReviewLoad{"u1": {OpenReviews: 1, Capacity: &capacity}},
+	}, nil)
+	router := NewRouter(teamSvc, serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	req := httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+
+	var resp getTeamResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	load := resp.Members[0].Load
+	if load == nil || load.OpenReviews != 1 || load.Capacity == nil || *load.Capacity != 2 {
+		t.Fatalf("unexpected member load: %+v", load)
+	}
+}
+
+func TestTeamHandlers_SetDefaultReviewCapacity_Negative(t *testing.T) {
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	body, _ := json.Marshal(map[string]any{"team_name": "backend", "default_review_capacity": -1})
+	req := httptest.NewRequest(http.MethodPost, "/team/setDefaultReviewCapacity", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestTeamHandlers_SetFallbackTeams_Validation(t *testing.T) {
+	tests := []struct {
+		name      string
+		fallbacks []string
+	}{
+		{name: "self reference", fallbacks: []string{"backend"}},
+		{name: "duplicate", fallbacks: []string{"payments", "payments"}},
+		{name: "empty name", fallbacks: []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+			body, _ := json.Marshal(map[string]any{"team_name": "backend", "fallback_teams": tt.fallbacks})
+			req := httptest.NewRequest(http.MethodPost, "/team/setFallbackTeams", bytes.NewBuffer(body))
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			if rr.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d", rr.Code)
+			}
+		})
+	}
+}
+
+func TestTeamHandlers_SetFallbackTeams_Success(t *testing.T) {
+	teamSvc := serviceMocks.NewMockTeamService(t)
+	teamSvc.On("SetFallbackTeams", mock.Anything, "backend", []string{"payments", "platform"}).Return(&domain.Team{
+		Name:          "backend",
+		FallbackTeams: []string{"payments", "platform"},
+	}, nil)
+	router := NewRouter(teamSvc, serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	body, _ := json.Marshal(map[string]any{"team_name": "backend", "fallback_teams": []string{"payments", "platform"}})
+	req := httptest.NewRequest(http.MethodPost, "/team/setFallbackTeams", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+
+	var resp teamDTO
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(resp.FallbackTeams) != 2 || resp.FallbackTeams[0] != "payments" {
+		t.Fatalf("unexpected fallback teams: %v", resp.FallbackTeams)
+	}
+}
+
+func TestTeamHandlers_SetPolicy_Success(t *testing.T) {
+	teamSvc := serviceMocks.NewMockTeamService(t)
+	teamSvc.On("SetPolicy", mock.Anything, "backend", domain.TeamPolicy{RequireSeniorReviewer: true}).Return(&domain.Team{
+		Name:   "backend",
+		Policy: domain.TeamPolicy{RequireSeniorReviewer: true},
+	}, nil)
+	router := NewRouter(teamSvc, serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	body, _ := json.Marshal(map[string]any{"team_name": "backend", "require_senior_reviewer": true})
+	req := httptest.NewRequest(http.MethodPost, "/team/setPolicy", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+
+	var resp teamDTO
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if !resp.RequireSeniorReviewer {
+		t.Fatalf("expected require_senior_reviewer to be set")
+	}
+}
@@ -2,7 +2,13 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"pr-reviewer/internal/domain"
 	"pr-reviewer/internal/service"
@@ -22,10 +28,48 @@ type setActiveRequest struct {
 }
 
 type userDTO struct {
-	ID       string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	ID             string `json:"user_id"`
+	Username       string `json:"username"`
+	TeamName       string `json:"team_name"`
+	IsActive       bool   `json:"is_active"`
+	Timezone       string `json:"timezone,omitempty"`
+	WorkStart      string `json:"work_start,omitempty"`
+	WorkEnd        string `json:"work_end,omitempty"`
+	ReviewCapacity *int   `json:"review_capacity,omitempty"`
+	Seniority      string `json:"seniority,omitempty"`
+}
+
+type setReviewCapacityRequest struct {
+	UserID         string `json:"user_id"`
+	ReviewCapacity *int   `json:"review_capacity"`
+}
+
+type setReviewCapacityResponse = setActiveResponse
+
+type setSeniorityRequest struct {
+	UserID    string `json:"user_id"`
+	Seniority string `json:"seniority"`
+}
+
+type setSeniorityResponse = setActiveResponse
+
+type setWorkingHoursRequest struct {
+	UserID    string `json:"user_id"`
+	Timezone  string `json:"timezone"`
+	WorkStart string `json:"work_start"`
+	WorkEnd   string `json:"work_end"`
+}
+
+type setWorkingHoursResponse = setActiveResponse
+
+type userTagsRequest struct {
+	UserID string   `json:"user_id"`
+	Tags   []string `json:"tags"`
+}
+
+type userTagsResponse struct {
+	UserID string   `json:"user_id"`
+	Tags   []string `json:"tags"`
 }
 
 type setActiveResponse struct {
@@ -54,6 +98,87 @@ func (h *userHandlers) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *userHandlers) SetWorkingHours(w http.ResponseWriter, r *http.Request) {
+	var req setWorkingHoursRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "invalid request body")
+		return
+	}
+	if req.UserID == "" {
+		writeBadRequest(w, "user_id is required")
+		return
+	}
+
+	hours, err := parseWorkingHours(req.Timezone, req.WorkStart, req.WorkEnd)
+	if err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+
+	user, err := h.users.SetWorkingHours(r.Context(), req.UserID, req.Timezone, hours)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, setWorkingHoursResponse{
+		User: toUserDTO(*user),
+	})
+}
+
+func (h *userHandlers) SetReviewCapacity(w http.ResponseWriter, r *http.Request) {
+	var req setReviewCapacityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "invalid request body")
+		return
+	}
+	if req.UserID == "" {
+		writeBadRequest(w, "user_id is required")
+		return
+	}
+	if req.ReviewCapacity != nil && *req.ReviewCapacity < 0 {
+		writeBadRequest(w, "review_capacity must not be negative")
+		return
+	}
+
+	user, err := h.users.SetReviewCapacity(r.Context(), req.UserID, req.ReviewCapacity)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, setReviewCapacityResponse{
+		User: toUserDTO(*user),
+	})
+}
+
+func (h *userHandlers) SetSeniority(w http.ResponseWriter, r *http.Request) {
+	var req setSeniorityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "invalid request body")
+		return
+	}
+	if req.UserID == "" {
+		writeBadRequest(w, "user_id is required")
+		return
+	}
+	seniority := domain.Seniority(req.Seniority)
+	if !seniority.Valid() {
+		writeBadRequest(w, "seniority must be one of junior, middle, senior")
+		return
+	}
+
+	user, err := h.users.SetSeniority(r.Context(), req.UserID, seniority)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, setSeniorityResponse{
+		User: toUserDTO(*user),
+	})
+}
+
 type getReviewResponse struct {
 	UserID       string                `json:"user_id"`
 	PullRequests []pullRequestShortDTO `json:"pull_requests"`
@@ -64,6 +189,7 @@ type pullRequestShortDTO struct {
 	Name     string                   `json:"pull_request_name"`
 	AuthorID string                   `json:"author_id"`
 	Status   domain.PullRequestStatus `json:"status"`
+	Version  int64                    `json:"version"`
 }
 
 func (h *userHandlers) GetReview(w http.ResponseWriter, r *http.Request) {
@@ -88,17 +214,259 @@ func (h *userHandlers) GetReview(w http.ResponseWriter, r *http.Request) {
 			Name:     pr.Name,
 			AuthorID: pr.AuthorID,
 			Status:   pr.Status,
+			Version:  pr.Version,
 		})
 	}
 
 	writeJSON(w, http.StatusOK, resp)
 }
 
+type addUnavailabilityRequest struct {
+	UserID string    `json:"user_id"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Reason string    `json:"reason"`
+}
+
+type removeUnavailabilityRequest struct {
+	UserID   string `json:"user_id"`
+	PeriodID int64  `json:"period_id"`
+}
+
+type unavailabilityDTO struct {
+	ID     int64     `json:"period_id"`
+	UserID string    `json:"user_id"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+type addUnavailabilityResponse struct {
+	Period unavailabilityDTO `json:"period"`
+}
+
+type getUnavailabilityResponse struct {
+	UserID  string              `json:"user_id"`
+	Periods []unavailabilityDTO `json:"periods"`
+}
+
+type removeUnavailabilityResponse struct {
+	UserID   string `json:"user_id"`
+	PeriodID int64  `json:"period_id"`
+}
+
+func (h *userHandlers) AddUnavailability(w http.ResponseWriter, r *http.Request) {
+	var req addUnavailabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "invalid request body")
+		return
+	}
+	if req.UserID == "" || req.Start.IsZero() || req.End.IsZero() {
+		writeBadRequest(w, "user_id, start and end are required")
+		return
+	}
+	if !req.End.After(req.Start) {
+		writeBadRequest(w, "end must be after start")
+		return
+	}
+
+	period, err := h.users.AddUnavailability(r.Context(), domain.Unavailability{
+		UserID:   req.UserID,
+		StartsAt: req.Start.UTC(),
+		EndsAt:   req.End.UTC(),
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, addUnavailabilityResponse{
+		Period: toUnavailabilityDTO(*period),
+	})
+}
+
+func (h *userHandlers) GetUnavailability(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		writeBadRequest(w, "user_id is required")
+		return
+	}
+
+	periods, err := h.users.ListUnavailability(r.Context(), userID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	resp := getUnavailabilityResponse{
+		UserID:  userID,
+		Periods: make([]unavailabilityDTO, 0, len(periods)),
+	}
+	for _, p := range periods {
+		resp.Periods = append(resp.Periods, toUnavailabilityDTO(p))
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *userHandlers) RemoveUnavailability(w http.ResponseWriter, r *http.Request) {
+	var req removeUnavailabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "invalid request body")
+		return
+	}
+	if req.UserID == "" || req.PeriodID == 0 {
+		writeBadRequest(w, "user_id and period_id are required")
+		return
+	}
+
+	if err := h.users.DeleteUnavailability(r.Context(), req.UserID, req.PeriodID); err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, removeUnavailabilityResponse{
+		UserID:   req.UserID,
+		PeriodID: req.PeriodID,
+	})
+}
+
+func (h *userHandlers) GetTags(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		writeBadRequest(w, "user_id is required")
+		return
+	}
+
+	tags, err := h.users.GetTags(r.Context(), userID)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toUserTagsResponse(userID, tags))
+}
+
+func (h *userHandlers) SetTags(w http.ResponseWriter, r *http.Request) {
+	var req userTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeBadRequest(w, "invalid request body")
+		return
+	}
+	if req.UserID == "" {
+		writeBadRequest(w, "user_id is required")
+		return
+	}
+
+	tags, err := parseTags(req.Tags)
+	if err != nil {
+		writeBadRequest(w, err.Error())
+		return
+	}
+
+	stored, err := h.users.SetTags(r.Context(), req.UserID, tags)
+	if err != nil {
+		WriteError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toUserTagsResponse(req.UserID, stored))
+}
+
+func toUserTagsResponse(userID string, tags []string) userTagsResponse {
+	if tags == nil {
+		tags = []string{}
+	}
+	return userTagsResponse{UserID: userID, Tags: tags}
+}
+
+func toUnavailabilityDTO(p domain.Unavailability) unavailabilityDTO {
+	return unavailabilityDTO{
+		ID:     p.ID,
+		UserID: p.UserID,
+		Start:  p.StartsAt,
+		End:    p.EndsAt,
+		Reason: p.Reason,
+	}
+}
+
 func toUserDTO(u domain.User) userDTO {
-	return userDTO{
-		ID:       u.ID,
-		Username: u.Username,
-		TeamName: u.TeamName,
-		IsActive: u.IsActive,
+	dto := userDTO{
+		ID:             u.ID,
+		Username:       u.Username,
+		TeamName:       u.TeamName,
+		IsActive:       u.IsActive,
+		Timezone:       u.Timezone,
+		ReviewCapacity: u.ReviewCapacity,
+		Seniority:      string(u.Seniority),
+	}
+	dto.WorkStart, dto.WorkEnd = formatWorkingHours(u.WorkingHours)
+	return dto
+}
+
+// parseWorkingHours validates an IANA timezone and an "HH:MM" window. Both
+// bounds empty means the user has no working hours configured.
+func parseWorkingHours(timezone, start, end string) (*domain.WorkingHours, error) {
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return nil, fmt.Errorf("unknown timezone %q", timezone)
+		}
+	}
+	if start == "" && end == "" {
+		return nil, nil
+	}
+	if start == "" || end == "" {
+		return nil, errors.New("work_start and work_end must be set together")
+	}
+
+	startMinute, err := parseClock(start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid work_start: %w", err)
+	}
+	endMinute, err := parseClock(end)
+	if err != nil {
+		return nil, fmt.Errorf("invalid work_end: %w", err)
+	}
+	if startMinute == endMinute {
+		return nil, errors.New("work_start and work_end must differ")
+	}
+	return &domain.WorkingHours{StartMinute: startMinute, EndMinute: endMinute}, nil
+}
+
+func parseClock(v string) (int, error) {
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, errors.New("expected HH:MM")
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatWorkingHours(h *domain.WorkingHours) (string, string) {
+	if h == nil {
+		return "", ""
+	}
+	return formatClock(h.StartMinute), formatClock(h.EndMinute)
+}
+
+func formatClock(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9+#._-]{0,31}$`)
+
+// parseTags lowercases and deduplicates tags, keeping the first occurrence
+// order.
+func parseTags(raw []string) ([]string, error) {
+	tags := make([]string, 0, len(raw))
+	for _, t := range raw {
+		tag := strings.ToLower(strings.TrimSpace(t))
+		if !tagPattern.MatchString(tag) {
+			return nil, fmt.Errorf("invalid tag %q", t)
+		}
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
 	}
+	return tags, nil
 }
@@ -2,10 +2,12 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -14,7 +16,7 @@ import (
 )
 
 func TestUserHandlers_SetIsActive_BadJSON(t *testing.T) {
-	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), &stubHTTPMetrics{})
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 	req := httptest.NewRequest(http.MethodPost, "/users/setIsActive", bytes.NewBufferString("{"))
 	rr := httptest.NewRecorder()
 
@@ -25,7 +27,7 @@ func TestUserHandlers_SetIsActive_BadJSON(t *testing.T) {
 }
 
 func TestUserHandlers_SetIsActive_MissingUser(t *testing.T) {
-	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), &stubHTTPMetrics{})
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 	body, _ := json.Marshal(map[string]any{"is_active": true})
 	req := httptest.NewRequest(http.MethodPost, "/users/setIsActive", bytes.NewBuffer(body))
 	rr := httptest.NewRecorder()
@@ -38,7 +40,7 @@ func TestUserHandlers_SetIsActive_MissingUser(t *testing.T) {
 func TestUserHandlers_SetIsActive_Success(t *testing.T) {
 	userSvc := serviceMocks.NewMockUserService(t)
 	userSvc.On("SetActive", mock.Anything, "u1", true).Return(&domain.User{ID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}, nil)
-	router := NewRouter(serviceMocks.NewMockTeamService(t), userSvc, serviceMocks.NewMockPullRequestService(t), &stubHTTPMetrics{})
+	router := NewRouter(serviceMocks.NewMockTeamService(t), userSvc, serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 
 	body, _ := json.Marshal(map[string]any{"user_id": "u1", "is_active": true})
 	req := httptest.NewRequest(http.MethodPost, "/users/setIsActive", bytes.NewBuffer(body))
@@ -54,7 +56,7 @@ func TestUserHandlers_SetIsActive_Success(t *testing.T) {
 func TestUserHandlers_SetIsActive_NotFound(t *testing.T) {
 	userSvc := serviceMocks.NewMockUserService(t)
 	userSvc.On("SetActive", mock.Anything, "u1", true).Return(nil, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found"))
-	router := NewRouter(serviceMocks.NewMockTeamService(t), userSvc, serviceMocks.NewMockPullRequestService(t), &stubHTTPMetrics{})
+	router := NewRouter(serviceMocks.NewMockTeamService(t), userSvc, serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 
 	body, _ := json.Marshal(map[string]any{"user_id": "u1", "is_active": true})
 	req := httptest.NewRequest(http.MethodPost, "/users/setIsActive", bytes.NewBuffer(body))
@@ -66,7 +68,7 @@ func TestUserHandlers_SetIsActive_NotFound(t *testing.T) {
 }
 
 func TestUserHandlers_GetReview_BadRequest(t *testing.T) {
-	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), &stubHTTPMetrics{})
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 	req := httptest.NewRequest(http.MethodGet, "/users/getReview", nil)
 	rr := httptest.NewRecorder()
 	router.ServeHTTP(rr, req)
@@ -78,7 +80,7 @@ func TestUserHandlers_GetReview_BadRequest(t *testing.T) {
 func TestUserHandlers_GetReview_Success(t *testing.T) {
 	userSvc := serviceMocks.NewMockUserService(t)
 	userSvc.On("GetReviewPullRequests", mock.Anything, "u1").Return([]domain.PullRequestShort{{ID: "pr1"}, {ID: "pr2"}}, nil)
-	router := NewRouter(serviceMocks.NewMockTeamService(t), userSvc, serviceMocks.NewMockPullRequestService(t), &stubHTTPMetrics{})
+	router := NewRouter(serviceMocks.NewMockTeamService(t), userSvc, serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 
 	req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1", nil)
 	rr := httptest.NewRecorder()
@@ -92,7 +94,7 @@ func TestUserHandlers_GetReview_Success(t *testing.T) {
 func TestUserHandlers_GetReview_NotFound(t *testing.T) {
 	userSvc := serviceMocks.NewMockUserService(t)
 	userSvc.On("GetReviewPullRequests", mock.Anything, "u1").Return(nil, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found"))
-	router := NewRouter(serviceMocks.NewMockTeamService(t), userSvc, serviceMocks.NewMockPullRequestService(t), &stubHTTPMetrics{})
+	router := NewRouter(serviceMocks.NewMockTeamService(t), userSvc, serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
 
 	req := httptest.NewRequest(http.MethodGet, "/users/getReview?user_id=u1", nil)
 	rr := httptest.NewRecorder()
@@ -101,3 +103,217 @@ func TestUserHandlers_GetReview_NotFound(t *testing.T) {
 		t.Fatalf("expected 404, got %d", rr.Code)
 	}
 }
+
+func TestUserHandlers_AddUnavailability_InvalidRange(t *testing.T) {
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+	body, _ := json.Marshal(map[string]any{
+		"user_id": "u1",
+		"start":   "2025-11-10T00:00:00Z",
+		"end":     "2025-11-01T00:00:00Z",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/users/addUnavailability", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestUserHandlers_AddUnavailability_Success(t *testing.T) {
+	userSvc := serviceMocks.NewMockUserService(t)
+	userSvc.On("AddUnavailability", mock.Anything, mock.AnythingOfType("domain.Unavailability")).Return(func(_ context.Context, p domain.Unavailability) *domain.Unavailability {
+		p.ID = 7
+		return &p
+	}, nil)
+	router := NewRouter(serviceMocks.NewMockTeamService(t), userSvc, serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	body, _ := json.Marshal(map[string]any{
+		"user_id": "u1",
+		"start":   "2025-11-01T00:00:00Z",
+		"end":     "2025-11-10T00:00:00Z",
+		"reason":  "vacation",
+	})
+	req := httptest.NewRequest(http.MethodPost, "/users/addUnavailability", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", rr.Code)
+	}
+
+	var resp addUnavailabilityResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Period.ID != 7 || resp.Period.Reason != "vacation" {
+		t.Fatalf("unexpected period: %+v", resp.Period)
+	}
+	userSvc.AssertCalled(t, "AddUnavailability", mock.Anything, mock.MatchedBy(func(p domain.Unavailability) bool {
+		return p.UserID == "u1" && p.EndsAt.Sub(p.StartsAt) == 9*24*time.Hour
+	}))
+}
+
+func TestUserHandlers_GetUnavailability_Success(t *testing.T) {
+	userSvc := serviceMocks.NewMockUserService(t)
+	userSvc.On("ListUnavailability", mock.Anything, "u1").Return([]domain.Unavailability{{ID: 1, UserID: "u1"}}, nil)
+	router := NewRouter(serviceMocks.NewMockTeamService(t), userSvc, serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/getUnavailability?user_id=u1", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+}
+
+func TestUserHandlers_RemoveUnavailability_NotFound(t *testing.T) {
+	userSvc := serviceMocks.NewMockUserService(t)
+	userSvc.On("DeleteUnavailability", mock.Anything, "u1", int64(3)).Return(domain.NewDomainError(domain.ErrorCodeNotFound, "unavailability period not found"))
+	router := NewRouter(serviceMocks.NewMockTeamService(t), userSvc, serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	body, _ := json.Marshal(map[string]any{"user_id": "u1", "period_id": 3})
+	req := httptest.NewRequest(http.MethodPost, "/users/removeUnavailability", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rr.Code)
+	}
+}
+
+func TestUserHandlers_SetWorkingHours_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		body map[string]any
+	}{
+		{"unknown timezone", map[string]any{"user_id": "u1", "timezone": "Mars/Olympus", "work_start": "09:00", "work_end": "17:00"}},
+		{"half window", map[string]any{"user_id": "u1", "timezone": "UTC", "work_start": "09:00"}},
+		{"bad clock", map[string]any{"user_id": "u1", "timezone": "UTC", "work_start": "9am", "work_end": "17:00"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+			body, _ := json.Marshal(tt.body)
+			req := httptest.NewRequest(http.MethodPost, "/users/setWorkingHours", bytes.NewBuffer(body))
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			if rr.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d", rr.Code)
+			}
+		})
+	}
+}
+
+func TestUserHandlers_SetWorkingHours_Success(t *testing.T) {
+	userSvc := serviceMocks.NewMockUserService(t)
+	userSvc.On("SetWorkingHours", mock.Anything, "u1", "Europe/Berlin", &domain.WorkingHours{StartMinute: 540, EndMinute: 1050}).
+		Return(&domain.User{ID: "u1", Timezone: "Europe/Berlin", WorkingHours: &domain.WorkingHours{StartMinute: 540, EndMinute: 1050}}, nil)
+	router := NewRouter(serviceMocks.NewMockTeamService(t), userSvc, serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	body, _ := json.Marshal(map[string]any{"user_id": "u1", "timezone": "Europe/Berlin", "work_start": "09:00", "work_end": "17:30"})
+	req := httptest.NewRequest(http.MethodPost, "/users/setWorkingHours", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+
+	var resp setWorkingHoursResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.User.WorkStart != "09:00" || resp.User.WorkEnd != "17:30" {
+		t.Fatalf("unexpected working hours in response: %+v", resp.User)
+	}
+}
+
+func TestUserHandlers_SetTags_Normalizes(t *testing.T) {
+	userSvc := serviceMocks.NewMockUserService(t)
+	userSvc.On("SetTags", mock.Anything, "u1", []string{"go", "c++"}).Return([]string{"c++", "go"}, nil)
+	router := NewRouter(serviceMocks.NewMockTeamService(t), userSvc, serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	body, _ := json.Marshal(map[string]any{"user_id": "u1", "tags": []string{" Go ", "c++", "go"}})
+	req := httptest.NewRequest(http.MethodPost, "/users/tags", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+}
+
+func TestUserHandlers_SetTags_Invalid(t *testing.T) {
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	body, _ := json.Marshal(map[string]any{"user_id": "u1", "tags": []string{"has space"}})
+	req := httptest.NewRequest(http.MethodPost, "/users/tags", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestUserHandlers_GetTags_Success(t *testing.T) {
+	userSvc := serviceMocks.NewMockUserService(t)
+	userSvc.On("GetTags", mock.Anything, "u1").Return(nil, nil)
+	router := NewRouter(serviceMocks.NewMockTeamService(t), userSvc, serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/tags?user_id=u1", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+
+	var resp userTagsResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Tags == nil {
+		t.Fatalf("expected empty tags array, got null")
+	}
+}
+
+func TestUserHandlers_Tags_MethodNotAllowed(t *testing.T) {
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	req := httptest.NewRequest(http.MethodDelete, "/users/tags", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", rr.Code)
+	}
+}
+
+func TestUserHandlers_SetSeniority_Invalid(t *testing.T) {
+	router := NewRouter(serviceMocks.NewMockTeamService(t), serviceMocks.NewMockUserService(t), serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	body, _ := json.Marshal(map[string]any{"user_id": "u1", "seniority": "principal"})
+	req := httptest.NewRequest(http.MethodPost, "/users/setSeniority", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestUserHandlers_SetSeniority_Success(t *testing.T) {
+	userSvc := serviceMocks.NewMockUserService(t)
+	userSvc.On("SetSeniority", mock.Anything, "u1", domain.SenioritySenior).Return(&domain.User{ID: "u1", Seniority: domain.SenioritySenior}, nil)
+	router := NewRouter(serviceMocks.NewMockTeamService(t), userSvc, serviceMocks.NewMockPullRequestService(t), serviceMocks.NewMockCodeOwnerService(t), &stubHTTPMetrics{})
+
+	body, _ := json.Marshal(map[string]any{"user_id": "u1", "seniority": "senior"})
+	req := httptest.NewRequest(http.MethodPost, "/users/setSeniority", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+
+	var resp setSeniorityResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.User.Seniority != "senior" {
+		t.Fatalf("expected senior, got %q", resp.User.Seniority)
+	}
+}
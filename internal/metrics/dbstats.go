@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// DBName labels the connection pool statistics of the service database.
+const DBName = "pr_reviewer"
+
+// RegisterDBStats exports the pool statistics of db as go_sql_* metrics until
+// unregister is called. Only one pool is reported at a time: the storage that
+// registered the previous one must unregister it when it closes that pool.
+func RegisterDBStats(db *sql.DB) (unregister func(), err error) {
+	collector := collectors.NewDBStatsCollector(db, DBName)
+	if err := prometheus.Register(collector); err != nil {
+		return nil, err
+	}
+	return func() { prometheus.Unregister(collector) }, nil
+}
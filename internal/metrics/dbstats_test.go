@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// stubConnector backs a *sql.DB that is never connected; the collector only
+// reads its pool statistics.
+type stubConnector struct{}
+
+func (stubConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, errors.New("not connected")
+}
+
+func (stubConnector) Driver() driver.Driver { return nil }
+
+func TestRegisterDBStats_ReplacesClosedPool(t *testing.T) {
+	first := sql.OpenDB(stubConnector{})
+	unregister, err := RegisterDBStats(first)
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	// A reopened storage must not silently keep reporting the old pool.
+	second := sql.OpenDB(stubConnector{})
+	second.SetMaxOpenConns(7)
+	var are prometheus.AlreadyRegisteredError
+	if _, err := RegisterDBStats(second); !errors.As(err, &are) {
+		t.Fatalf("expected AlreadyRegisteredError while the first pool is registered, got %v", err)
+	}
+
+	unregister()
+	_ = first.Close()
+	unregister, err = RegisterDBStats(second)
+	if err != nil {
+		t.Fatalf("register after unregister: %v", err)
+	}
+	defer unregister()
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("gather: %v", err)
+	}
+	for _, f := range families {
+		if f.GetName() != "go_sql_max_open_connections" {
+			continue
+		}
+		if got := f.GetMetric()[0].GetGauge().GetValue(); got != 7 {
+			t.Fatalf("expected the second pool to be reported, got max open %v", got)
+		}
+		return
+	}
+	t.Fatal("expected go_sql_max_open_connections to be exported")
+}
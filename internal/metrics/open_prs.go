@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/health"
+	"pr-reviewer/internal/logging"
+	"pr-reviewer/internal/repository"
+)
+
+// OpenPullRequests publishes gauges about the open pull requests. Run reads
+// the statistics on an interval and scrapes report the latest snapshot, so
+// neither scrapes nor API requests wait for the aggregate queries.
+type OpenPullRequests struct {
+	stats    repository.StatsRepository
+	required func() int
+	now      func() time.Time
+	created  time.Time
+
+	mu          sync.RWMutex
+	snapshot    *domain.OpenPullRequestStats
+	refreshedAt time.Time
+
+	byTeam          *prometheus.Desc
+	byReviewerCount *prometheus.Desc
+	understaffed    *prometheus.Desc
+	reviewsByUser   *prometheus.Desc
+}
+
+// NewOpenPullRequests reads statistics from stats. required returns the
+// number of reviewers a pull request should have; it is called on every
+// scrape, so it may follow config reloads.
+func NewOpenPullRequests(stats repository.StatsRepository, required func() int) *OpenPullRequests {
+	return &OpenPullRequests{
+		stats:    stats,
+		required: required,
+		now:      time.Now,
+		created:  time.Now(),
+		byTeam: prometheus.NewDesc("pr_open",
+			"Open pull requests by the author's team.", []string{"team"}, nil),
+		byReviewerCount: prometheus.NewDesc("pr_open_by_reviewers",
+			"Open pull requests by the number of assigned reviewers.", []string{"reviewers"}, nil),
+		understaffed: prometheus.NewDesc("pr_open_understaffed",
+			"Open pull requests with fewer reviewers than required.", nil, nil),
+		reviewsByUser: prometheus.NewDesc("pr_open_reviews",
+			"Open pull requests each user reviews.", []string{"user_id"}, nil),
+	}
+}
+
+func (c *OpenPullRequests) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.byTeam
+	ch <- c.byReviewerCount
+	ch <- c.understaffed
+	ch <- c.reviewsByUser
+}
+
+// Collect reports nothing until the first refresh succeeds.
+func (c *OpenPullRequests) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	snapshot := c.snapshot
+	c.mu.RUnlock()
+	if snapshot == nil {
+		return
+	}
+
+	for team, n := range snapshot.ByTeam {
+		ch <- prometheus.MustNewConstMetric(c.byTeam, prometheus.GaugeValue, float64(n), team)
+	}
+	required := c.required()
+	understaffed := 0
+	for reviewers, n := range snapshot.ByReviewerCount {
+		ch <- prometheus.MustNewConstMetric(c.byReviewerCount, prometheus.GaugeValue, float64(n), strconv.Itoa(reviewers))
+		if reviewers < required {
+			understaffed += n
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(c.understaffed, prometheus.GaugeValue, float64(understaffed))
+	for userID, n := range snapshot.ReviewsByUser {
+		ch <- prometheus.MustNewConstMetric(c.reviewsByUser, prometheus.GaugeValue, float64(n), userID)
+	}
+}
+
+// Register adds c to the default registry that /metrics serves.
+func (c *OpenPullRequests) Register() error {
+	return prometheus.Register(c)
+}
+
+// Refresh replaces the snapshot. On error the previous one is kept.
+func (c *OpenPullRequests) Refresh(ctx context.Context) error {
+	stats, err := c.stats.OpenPullRequestStats(ctx)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.snapshot = &stats
+	c.refreshedAt = c.now()
+	c.mu.Unlock()
+	return nil
+}
+
+// Check fails once no refresh has succeeded for maxAge. Until the first
+// success the age counts from the collector's creation, so a starting
+// instance is not reported stale before Run has had a chance.
+func (c *OpenPullRequests) Check(maxAge time.Duration) health.Check {
+	return func(context.Context) error {
+		c.mu.RLock()
+		last := c.refreshedAt
+		c.mu.RUnlock()
+		if last.IsZero() {
+			if age := c.now().Sub(c.created); age > maxAge {
+				return fmt.Errorf("no successful refresh in %s", age.Round(time.Second))
+			}
+			return nil
+		}
+		if age := c.now().Sub(last); age > maxAge {
+			return fmt.Errorf("last successful refresh %s ago", age.Round(time.Second))
+		}
+		return nil
+	}
+}
+
+// Run refreshes the snapshot at once and then every interval until ctx is
+// done. A refresh may take at most one interval.
+func (c *OpenPullRequests) Run(ctx context.Context, interval time.Duration, logger logging.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		refreshCtx, cancel := context.WithTimeout(ctx, interval)
+		if err := c.Refresh(refreshCtx); err != nil && ctx.Err() == nil {
+			logger.Error("Failed to refresh open pull request metrics", "error", err)
+		}
+		cancel()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
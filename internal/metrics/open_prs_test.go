@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+
+	"pr-reviewer/internal/domain"
+	repoMocks "pr-reviewer/mocks/repository"
+)
+
+func TestOpenPullRequests(t *testing.T) {
+	stats := repoMocks.NewMockStatsRepository(t)
+	stats.On("OpenPullRequestStats", mock.Anything).Return(domain.OpenPullRequestStats{
+		ByTeam:          map[string]int{"backend": 3},
+		ByReviewerCount: map[int]int{0: 1, 1: 1, 2: 1},
+		ReviewsByUser:   map[string]int{"u2": 2, "u3": 1},
+	}, nil).Once()
+	stats.On("OpenPullRequestStats", mock.Anything).Return(domain.OpenPullRequestStats{}, errors.New("db down")).Once()
+
+	required := 2
+	c := NewOpenPullRequests(stats, func() int { return required })
+	if n := testutil.CollectAndCount(c); n != 0 {
+		t.Fatalf("expected no metrics before the first refresh, got %d", n)
+	}
+
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	expected := `
+# HELP pr_open Open pull requests by the author's team.
+# TYPE pr_open gauge
+pr_open{team="backend"} 3
+# HELP pr_open_by_reviewers Open pull requests by the number of assigned reviewers.
+# TYPE pr_open_by_reviewers gauge
+pr_open_by_reviewers{reviewers="0"} 1
+pr_open_by_reviewers{reviewers="1"} 1
+pr_open_by_reviewers{reviewers="2"} 1
+# HELP pr_open_reviews Open pull requests each user reviews.
+# TYPE pr_open_reviews gauge
+pr_open_reviews{user_id="u2"} 2
+pr_open_reviews{user_id="u3"} 1
+# HELP pr_open_understaffed Open pull requests with fewer reviewers than required.
+# TYPE pr_open_understaffed gauge
+pr_open_understaffed 2
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected)); err != nil {
+		t.Fatal(err)
+	}
+
+	// A failed refresh keeps the last snapshot; the requirement is read on
+	// every scrape.
+	if err := c.Refresh(context.Background()); err == nil {
+		t.Fatalf("expected refresh error")
+	}
+	required = 3
+	if err := testutil.CollectAndCompare(c, strings.NewReader(`
+# HELP pr_open_understaffed Open pull requests with fewer reviewers than required.
+# TYPE pr_open_understaffed gauge
+pr_open_understaffed 3
+`), "pr_open_understaffed"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOpenPullRequests_Check(t *testing.T) {
+	stats := repoMocks.NewMockStatsRepository(t)
+	stats.On("OpenPullRequestStats", mock.Anything).Return(domain.OpenPullRequestStats{}, nil).Once()
+	stats.On("OpenPullRequestStats", mock.Anything).Return(domain.OpenPullRequestStats{}, errors.New("db down")).Once()
+
+	c := NewOpenPullRequests(stats, func() int { return 2 })
+	now := c.created
+	c.now = func() time.Time { return now }
+	check := c.Check(time.Minute)
+	ctx := context.Background()
+
+	if err := check(ctx); err != nil {
+		t.Fatalf("expected a fresh collector to pass, got %v", err)
+	}
+	now = now.Add(2 * time.Minute)
+	if err := check(ctx); err == nil {
+		t.Fatalf("expected an error with no successful refresh")
+	}
+
+	if err := c.Refresh(ctx); err != nil {
+		t.Fatalf("refresh: %v", err)
+	}
+	if err := check(ctx); err != nil {
+		t.Fatalf("expected pass after a refresh, got %v", err)
+	}
+
+	// A failed refresh does not reset the age.
+	now = now.Add(30 * time.Second)
+	if err := c.Refresh(ctx); err == nil {
+		t.Fatalf("expected refresh error")
+	}
+	if err := check(ctx); err != nil {
+		t.Fatalf("expected pass within max age, got %v", err)
+	}
+	now = now.Add(time.Minute)
+	if err := check(ctx); err == nil || !strings.Contains(err.Error(), "1m30s ago") {
+		t.Fatalf("expected a stale refresh error, got %v", err)
+	}
+}
@@ -10,31 +10,85 @@ import (
 type TeamRepository interface {
 	UpsertTeam(ctx context.Context, team domain.Team) (domain.Team, error)
 	GetTeamByName(ctx context.Context, teamName string) (domain.Team, error)
+	SetDefaultReviewCapacity(ctx context.Context, teamName string, capacity *int) (domain.Team, error)
+	SetFallbackTeams(ctx context.Context, teamName string, fallbacks []string) (domain.Team, error)
+	ListFallbackTeams(ctx context.Context, teamName string) ([]string, error)
+	GetTeamPolicy(ctx context.Context, teamName string) (domain.TeamPolicy, error)
+	SetTeamPolicy(ctx context.Context, teamName string, policy domain.TeamPolicy) (domain.Team, error)
 }
 
 type UserRepository interface {
 	GetUserByID(ctx context.Context, userID string) (domain.User, error)
 	SetActive(ctx context.Context, userID string, isActive bool) (domain.User, error)
-	ListActiveByTeam(ctx context.Context, teamName string) ([]domain.User, error)
+	SetWorkingHours(ctx context.Context, userID, timezone string, hours *domain.WorkingHours) (domain.User, error)
+	SetReviewCapacity(ctx context.Context, userID string, capacity *int) (domain.User, error)
+	SetSeniority(ctx context.Context, userID string, seniority domain.Seniority) (domain.User, error)
+	ListActiveByTeam(ctx context.Context, teamName string, at time.Time) ([]domain.User, error)
+	GetReviewLoad(ctx context.Context, userIDs []string) (map[string]domain.ReviewLoad, error)
+	AddUnavailability(ctx context.Context, period domain.Unavailability) (domain.Unavailability, error)
+	ListUnavailability(ctx context.Context, userID string) ([]domain.Unavailability, error)
+	DeleteUnavailability(ctx context.Context, userID string, periodID int64) error
+	SetUserTags(ctx context.Context, userID string, tags []string) error
+	ListUserTags(ctx context.Context, userIDs []string) (map[string][]string, error)
 }
 
 type PullRequestRepository interface {
+	// CreatePullRequest stores pr.CrossTeamReviewers as given: only the
+	// caller knows which reviewers it borrowed from a fallback team.
 	CreatePullRequest(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error)
 	GetPullRequestByID(ctx context.Context, prID string) (domain.PullRequest, error)
 	MergePullRequest(ctx context.Context, prID string, mergedAt time.Time) (domain.PullRequest, error)
 	UpdateStatus(ctx context.Context, prID string, status domain.PullRequestStatus) (domain.PullRequest, error)
-	ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) (domain.PullRequest, error)
+	// ReassignReviewer replaces oldReviewerID with newReviewerID, recording
+	// sourceTeam as the fallback team the new reviewer was borrowed from, or
+	// none when it is empty.
+	ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID, sourceTeam string) (domain.PullRequest, error)
 	ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error)
+	CountRecentPairings(ctx context.Context, authorID string, reviewerIDs []string, since time.Time) (map[string]int, error)
+}
+
+// StatsRepository aggregates data across the whole storage. It is not part of
+// Tx: the results feed monitoring and need no transactional consistency.
+type StatsRepository interface {
+	OpenPullRequestStats(ctx context.Context) (domain.OpenPullRequestStats, error)
+}
+
+type CodeOwnerRepository interface {
+	ReplaceCodeOwnerRules(ctx context.Context, rules []domain.CodeOwnerRule) error
+	ListCodeOwnerRules(ctx context.Context) ([]domain.CodeOwnerRule, error)
 }
 
 type Tx interface {
 	TeamRepository
 	UserRepository
 	PullRequestRepository
+	CodeOwnerRepository
+	// GetPullRequestForUpdate reads a pull request and keeps other
+	// transactions from changing it until this one ends.
+	GetPullRequestForUpdate(ctx context.Context, prID string) (domain.PullRequest, error)
 	Commit(ctx context.Context) error
 	Rollback(ctx context.Context) error
 }
 
 type UnitOfWork interface {
 	Begin(ctx context.Context) (Tx, error)
+	// Do runs fn in a transaction and commits it if fn succeeds. Backends
+	// may run fn again when the transaction fails transiently, so fn must
+	// not have side effects outside of tx.
+	Do(ctx context.Context, fn func(tx Tx) error) error
+}
+
+// RunTx runs fn in one transaction from uow, rolling back unless fn and the
+// commit succeed.
+func RunTx(ctx context.Context, uow UnitOfWork, fn func(tx Tx) error) error {
+	tx, err := uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
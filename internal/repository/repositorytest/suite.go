@@ -0,0 +1,706 @@
+// Package repositorytest is a conformance suite for implementations of the
+// repository interfaces. Every backend runs it, so their behaviour cannot
+// drift apart.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/repository"
+)
+
+// Backend is one set of repositories sharing the same storage.
+type Backend struct {
+	Teams      repository.TeamRepository
+	Users      repository.UserRepository
+	PRs        repository.PullRequestRepository
+	CodeOwners repository.CodeOwnerRepository
+	Stats      repository.StatsRepository
+	UoW        repository.UnitOfWork
+}
+
+// Run runs the suite. newBackend must return a backend with empty storage
+// every time it is called.
+func Run(t *testing.T, newBackend func(t *testing.T) Backend) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, b Backend)
+	}{
+		{"NotFound", testNotFound},
+		{"UpsertTeam", testUpsertTeam},
+		{"TeamSettings", testTeamSettings},
+		{"ActiveByTeam", testActiveByTeam},
+		{"UserTags", testUserTags},
+		{"ReviewerOrdering", testReviewerOrdering},
+		{"ReassignReviewer", testReassignReviewer},
+		{"MergeAndLoad", testMergeAndLoad},
+		{"VersionGrowsOnEveryChange", testVersion},
+		{"CreateExistingPullRequest", testCreateExisting},
+		{"ListByReviewer", testListByReviewer},
+		{"CountRecentPairings", testCountRecentPairings},
+		{"OpenPullRequestStats", testOpenPullRequestStats},
+		{"CodeOwnerRules", testCodeOwnerRules},
+		{"CommitPublishesWrites", testCommit},
+		{"RollbackDiscardsWrites", testRollback},
+		{"DoCommitsOrRollsBack", testDo},
+		{"GetForUpdateWaitsForLockHolder", testGetForUpdate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newBackend(t))
+		})
+	}
+}
+
+var base = time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+
+func seedTeam(t *testing.T, b Backend, name string, memberIDs ...string) {
+	t.Helper()
+	team := domain.Team{Name: name}
+	for _, id := range memberIDs {
+		team.Members = append(team.Members, domain.User{ID: id, Username: id, IsActive: true})
+	}
+	if _, err := b.Teams.UpsertTeam(context.Background(), team); err != nil {
+		t.Fatalf("upsert team %s: %v", name, err)
+	}
+}
+
+func createPR(t *testing.T, prs repository.PullRequestRepository, id, author string, at time.Time, reviewers ...string) domain.PullRequest {
+	t.Helper()
+	pr, err := prs.CreatePullRequest(context.Background(), domain.PullRequest{
+		ID:                id,
+		Name:              id,
+		AuthorID:          author,
+		Status:            domain.PullRequestStatusOpen,
+		AssignedReviewers: reviewers,
+		CreatedAt:         at,
+	})
+	if err != nil {
+		t.Fatalf("create pull request %s: %v", id, err)
+	}
+	return pr
+}
+
+func expectNotFound(t *testing.T, what string, err error) {
+	t.Helper()
+	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeNotFound {
+		t.Fatalf("%s: expected NOT_FOUND, got %v", what, err)
+	}
+}
+
+func testNotFound(t *testing.T, b Backend) {
+	ctx := context.Background()
+	capacity := 1
+
+	_, err := b.Teams.GetTeamByName(ctx, "ghost")
+	expectNotFound(t, "GetTeamByName", err)
+	_, err = b.Teams.GetTeamPolicy(ctx, "ghost")
+	expectNotFound(t, "GetTeamPolicy", err)
+	_, err = b.Teams.SetDefaultReviewCapacity(ctx, "ghost", &capacity)
+	expectNotFound(t, "SetDefaultReviewCapacity", err)
+	_, err = b.Teams.SetTeamPolicy(ctx, "ghost", domain.TeamPolicy{RequireSeniorReviewer: true})
+	expectNotFound(t, "SetTeamPolicy", err)
+
+	_, err = b.Users.GetUserByID(ctx, "ghost")
+	expectNotFound(t, "GetUserByID", err)
+	_, err = b.Users.SetActive(ctx, "ghost", false)
+	expectNotFound(t, "SetActive", err)
+	_, err = b.Users.SetReviewCapacity(ctx, "ghost", &capacity)
+	expectNotFound(t, "SetReviewCapacity", err)
+	_, err = b.Users.SetSeniority(ctx, "ghost", domain.SenioritySenior)
+	expectNotFound(t, "SetSeniority", err)
+	_, err = b.Users.SetWorkingHours(ctx, "ghost", "UTC", nil)
+	expectNotFound(t, "SetWorkingHours", err)
+	expectNotFound(t, "DeleteUnavailability", b.Users.DeleteUnavailability(ctx, "ghost", 1))
+
+	_, err = b.PRs.GetPullRequestByID(ctx, "ghost")
+	expectNotFound(t, "GetPullRequestByID", err)
+	_, err = b.PRs.MergePullRequest(ctx, "ghost", base)
+	expectNotFound(t, "MergePullRequest", err)
+	_, err = b.PRs.UpdateStatus(ctx, "ghost", domain.PullRequestStatusMerged)
+	expectNotFound(t, "UpdateStatus", err)
+
+	if prs, err := b.PRs.ListByReviewer(ctx, "ghost"); err != nil || len(prs) != 0 {
+		t.Fatalf("ListByReviewer for unknown user: expected nothing, got %v, %v", prs, err)
+	}
+}
+
+func testUpsertTeam(t *testing.T, b Backend) {
+	ctx := context.Background()
+	capacity, bobCapacity, aliceCapacity := 2, 4, 3
+	hours := &domain.WorkingHours{StartMinute: 9 * 60, EndMinute: 17 * 60}
+	created, err := b.Teams.UpsertTeam(ctx, domain.Team{
+		Name:                  "backend",
+		DefaultReviewCapacity: &capacity,
+		Members: []domain.User{
+			{
+				ID: "u2", Username: "Bob", IsActive: true, Seniority: domain.SenioritySenior,
+				Timezone: "Europe/Berlin", WorkingHours: hours, ReviewCapacity: &bobCapacity,
+			},
+			{ID: "u1", Username: "Alice", IsActive: false},
+		},
+	})
+	if err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	if len(created.Members) != 2 || created.Members[1].Seniority != domain.SeniorityMiddle {
+		t.Fatalf("expected members with default seniority middle, got %+v", created.Members)
+	}
+	seedTeam(t, b, "frontend")
+
+	// A second upsert updates members, may move them between teams and keeps
+	// the existing team settings and any member settings it leaves unset.
+	other := 5
+	if _, err := b.Teams.UpsertTeam(ctx, domain.Team{
+		Name:                  "backend",
+		DefaultReviewCapacity: &other,
+		Members:               []domain.User{{ID: "u1", Username: "Alice B.", IsActive: true, ReviewCapacity: &aliceCapacity}},
+	}); err != nil {
+		t.Fatalf("second upsert: %v", err)
+	}
+	if _, err := b.Teams.UpsertTeam(ctx, domain.Team{
+		Name:    "frontend",
+		Members: []domain.User{{ID: "u2", Username: "Bob", IsActive: true}},
+	}); err != nil {
+		t.Fatalf("move member: %v", err)
+	}
+
+	team, err := b.Teams.GetTeamByName(ctx, "backend")
+	if err != nil {
+		t.Fatalf("get team: %v", err)
+	}
+	if team.DefaultReviewCapacity == nil || *team.DefaultReviewCapacity != 2 {
+		t.Fatalf("expected team settings to be kept, got capacity %v", team.DefaultReviewCapacity)
+	}
+	if len(team.Members) != 1 || team.Members[0].ID != "u1" || team.Members[0].Username != "Alice B." || !team.Members[0].IsActive {
+		t.Fatalf("unexpected backend members: %+v", team.Members)
+	}
+	if c := team.Members[0].ReviewCapacity; c == nil || *c != aliceCapacity {
+		t.Fatalf("expected explicit capacity to be stored, got %v", c)
+	}
+
+	moved, err := b.Users.GetUserByID(ctx, "u2")
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	if moved.TeamName != "frontend" {
+		t.Fatalf("expected u2 to move to frontend, got %q", moved.TeamName)
+	}
+	if moved.ReviewCapacity == nil || *moved.ReviewCapacity != bobCapacity {
+		t.Fatalf("expected review capacity to be kept, got %v", moved.ReviewCapacity)
+	}
+	if moved.Seniority != domain.SenioritySenior || moved.Timezone != "Europe/Berlin" ||
+		moved.WorkingHours == nil || *moved.WorkingHours != *hours {
+		t.Fatalf("expected member settings to be kept, got %+v", moved)
+	}
+}
+
+func testTeamSettings(t *testing.T, b Backend) {
+	ctx := context.Background()
+	seedTeam(t, b, "backend", "u3", "u1", "u2")
+	seedTeam(t, b, "platform")
+	seedTeam(t, b, "infra")
+
+	capacity := 3
+	team, err := b.Teams.SetDefaultReviewCapacity(ctx, "backend", &capacity)
+	if err != nil || team.DefaultReviewCapacity == nil || *team.DefaultReviewCapacity != 3 {
+		t.Fatalf("set capacity: %+v, %v", team, err)
+	}
+	if ids := memberIDs(team.Members); !slices.Equal(ids, []string{"u1", "u2", "u3"}) {
+		t.Fatalf("expected members ordered by id, got %v", ids)
+	}
+
+	if _, err := b.Teams.SetTeamPolicy(ctx, "backend", domain.TeamPolicy{RequireSeniorReviewer: true}); err != nil {
+		t.Fatalf("set policy: %v", err)
+	}
+	policy, err := b.Teams.GetTeamPolicy(ctx, "backend")
+	if err != nil || !policy.RequireSeniorReviewer {
+		t.Fatalf("get policy: %+v, %v", policy, err)
+	}
+
+	if _, err := b.Teams.SetFallbackTeams(ctx, "backend", []string{"platform", "infra"}); err != nil {
+		t.Fatalf("set fallbacks: %v", err)
+	}
+	fallbacks, err := b.Teams.ListFallbackTeams(ctx, "backend")
+	if err != nil || !slices.Equal(fallbacks, []string{"platform", "infra"}) {
+		t.Fatalf("expected fallbacks in priority order, got %v, %v", fallbacks, err)
+	}
+	team, err = b.Teams.SetFallbackTeams(ctx, "backend", []string{"infra"})
+	if err != nil || !slices.Equal(team.FallbackTeams, []string{"infra"}) {
+		t.Fatalf("expected fallbacks to be replaced, got %+v, %v", team.FallbackTeams, err)
+	}
+	if fallbacks, err := b.Teams.ListFallbackTeams(ctx, "platform"); err != nil || len(fallbacks) != 0 {
+		t.Fatalf("expected no fallbacks for platform, got %v, %v", fallbacks, err)
+	}
+}
+
+func testActiveByTeam(t *testing.T, b Backend) {
+	ctx := context.Background()
+	seedTeam(t, b, "backend", "u4", "u1", "u2", "u3")
+	seedTeam(t, b, "frontend", "f1")
+
+	if _, err := b.Users.SetActive(ctx, "u2", false); err != nil {
+		t.Fatalf("set active: %v", err)
+	}
+	period, err := b.Users.AddUnavailability(ctx, domain.Unavailability{
+		UserID:   "u3",
+		StartsAt: base.Add(-time.Hour),
+		EndsAt:   base.Add(time.Hour),
+		Reason:   "vacation",
+	})
+	if err != nil {
+		t.Fatalf("add unavailability: %v", err)
+	}
+
+	active, err := b.Users.ListActiveByTeam(ctx, "backend", base)
+	if err != nil {
+		t.Fatalf("list active: %v", err)
+	}
+	if ids := memberIDs(active); !slices.Equal(ids, []string{"u1", "u4"}) {
+		t.Fatalf("expected u1 and u4, got %v", ids)
+	}
+	// The period ends exclusively.
+	active, err = b.Users.ListActiveByTeam(ctx, "backend", base.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("list active: %v", err)
+	}
+	if ids := memberIDs(active); !slices.Equal(ids, []string{"u1", "u3", "u4"}) {
+		t.Fatalf("expected u3 back after the period, got %v", ids)
+	}
+
+	periods, err := b.Users.ListUnavailability(ctx, "u3")
+	if err != nil || len(periods) != 1 || periods[0].ID != period.ID || periods[0].Reason != "vacation" {
+		t.Fatalf("unexpected periods: %+v, %v", periods, err)
+	}
+	expectNotFound(t, "DeleteUnavailability of another user", b.Users.DeleteUnavailability(ctx, "u1", period.ID))
+	if err := b.Users.DeleteUnavailability(ctx, "u3", period.ID); err != nil {
+		t.Fatalf("delete unavailability: %v", err)
+	}
+	if periods, err := b.Users.ListUnavailability(ctx, "u3"); err != nil || len(periods) != 0 {
+		t.Fatalf("expected no periods, got %+v, %v", periods, err)
+	}
+}
+
+func testUserTags(t *testing.T, b Backend) {
+	ctx := context.Background()
+	seedTeam(t, b, "backend", "u1", "u2", "u3")
+
+	if err := b.Users.SetUserTags(ctx, "u1", []string{"sql", "go"}); err != nil {
+		t.Fatalf("set tags: %v", err)
+	}
+	if err := b.Users.SetUserTags(ctx, "u2", []string{"frontend"}); err != nil {
+		t.Fatalf("set tags: %v", err)
+	}
+	if err := b.Users.SetUserTags(ctx, "u2", nil); err != nil {
+		t.Fatalf("clear tags: %v", err)
+	}
+	// Replacing with an overlapping list keeps the shared tags.
+	if err := b.Users.SetUserTags(ctx, "u3", []string{"go"}); err != nil {
+		t.Fatalf("set tags: %v", err)
+	}
+	if err := b.Users.SetUserTags(ctx, "u3", []string{"go", "sql"}); err != nil {
+		t.Fatalf("replace tags: %v", err)
+	}
+
+	tags, err := b.Users.ListUserTags(ctx, []string{"u1", "u2", "u3"})
+	if err != nil {
+		t.Fatalf("list tags: %v", err)
+	}
+	if len(tags) != 2 || !slices.Equal(tags["u1"], []string{"go", "sql"}) || !slices.Equal(tags["u3"], []string{"go", "sql"}) {
+		t.Fatalf("expected u1 and u3 with sorted tags, got %v", tags)
+	}
+}
+
+func testReviewerOrdering(t *testing.T, b Backend) {
+	ctx := context.Background()
+	seedTeam(t, b, "backend", "u1", "u2", "u3")
+	seedTeam(t, b, "platform", "p1")
+
+	created, err := b.PRs.CreatePullRequest(ctx, domain.PullRequest{
+		ID:                 "pr1",
+		Name:               "pr1",
+		AuthorID:           "u1",
+		Status:             domain.PullRequestStatusOpen,
+		AssignedReviewers:  []string{"u3", "p1", "u2"},
+		CrossTeamReviewers: map[string]string{"p1": "platform"},
+		CreatedAt:          base,
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if !slices.Equal(created.AssignedReviewers, []string{"u3", "p1", "u2"}) {
+		t.Fatalf("create must keep the selection order, got %v", created.AssignedReviewers)
+	}
+	if created.CrossTeamReviewers["p1"] != "platform" || len(created.CrossTeamReviewers) != 1 {
+		t.Fatalf("expected p1 borrowed from platform, got %v", created.CrossTeamReviewers)
+	}
+
+	stored, err := b.PRs.GetPullRequestByID(ctx, "pr1")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if !slices.Equal(stored.AssignedReviewers, []string{"p1", "u2", "u3"}) {
+		t.Fatalf("stored reviewers must be ordered by id, got %v", stored.AssignedReviewers)
+	}
+	if stored.Status != domain.PullRequestStatusOpen || !stored.CreatedAt.Equal(base) || stored.MergedAt != nil {
+		t.Fatalf("unexpected stored pull request: %+v", stored)
+	}
+
+	// Moving the reviewer later does not change where they were borrowed from.
+	if _, err := b.Teams.UpsertTeam(ctx, domain.Team{Name: "backend", Members: []domain.User{{ID: "p1", Username: "p1", IsActive: true}}}); err != nil {
+		t.Fatalf("move p1: %v", err)
+	}
+	stored, err = b.PRs.GetPullRequestByID(ctx, "pr1")
+	if err != nil || stored.CrossTeamReviewers["p1"] != "platform" {
+		t.Fatalf("expected source team to be kept, got %v, %v", stored.CrossTeamReviewers, err)
+	}
+}
+
+func testReassignReviewer(t *testing.T, b Backend) {
+	ctx := context.Background()
+	seedTeam(t, b, "backend", "u1", "u2", "u3")
+	seedTeam(t, b, "platform", "p1")
+	seedTeam(t, b, "qa", "q1")
+	createPR(t, b.PRs, "pr1", "u1", base, "u2", "u3")
+
+	updated, err := b.PRs.ReassignReviewer(ctx, "pr1", "u2", "p1", "platform")
+	if err != nil {
+		t.Fatalf("reassign: %v", err)
+	}
+	if !slices.Equal(updated.AssignedReviewers, []string{"p1", "u3"}) || updated.CrossTeamReviewers["p1"] != "platform" {
+		t.Fatalf("unexpected reviewers after reassign: %v, %v", updated.AssignedReviewers, updated.CrossTeamReviewers)
+	}
+
+	// A reviewer from another team that was not borrowed, e.g. a code owner,
+	// has no source team.
+	updated, err = b.PRs.ReassignReviewer(ctx, "pr1", "p1", "q1", "")
+	if err != nil {
+		t.Fatalf("reassign: %v", err)
+	}
+	if !slices.Equal(updated.AssignedReviewers, []string{"q1", "u3"}) || len(updated.CrossTeamReviewers) != 0 {
+		t.Fatalf("unexpected reviewers after reassign: %v, %v", updated.AssignedReviewers, updated.CrossTeamReviewers)
+	}
+
+	_, err = b.PRs.ReassignReviewer(ctx, "ghost", "u2", "u3", "")
+	expectNotFound(t, "ReassignReviewer", err)
+}
+
+func testMergeAndLoad(t *testing.T, b Backend) {
+	ctx := context.Background()
+	teamCapacity, userCapacity := 2, 5
+	if _, err := b.Teams.UpsertTeam(ctx, domain.Team{
+		Name:                  "backend",
+		DefaultReviewCapacity: &teamCapacity,
+		Members: []domain.User{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true, ReviewCapacity: &userCapacity},
+			{ID: "u3", IsActive: true},
+		},
+	}); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	createPR(t, b.PRs, "pr1", "u1", base, "u2", "u3")
+	createPR(t, b.PRs, "pr2", "u1", base, "u2")
+
+	merged, err := b.PRs.MergePullRequest(ctx, "pr1", base.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if merged.Status != domain.PullRequestStatusMerged || merged.MergedAt == nil || !merged.MergedAt.Equal(base.Add(time.Hour)) {
+		t.Fatalf("unexpected merged pull request: %+v", merged)
+	}
+	if len(merged.AssignedReviewers) != 2 {
+		t.Fatalf("merge must keep reviewers, got %v", merged.AssignedReviewers)
+	}
+
+	load, err := b.Users.GetReviewLoad(ctx, []string{"u1", "u2", "u3", "ghost"})
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if _, ok := load["ghost"]; ok || len(load) != 3 {
+		t.Fatalf("expected load for known users only, got %v", load)
+	}
+	if l := load["u2"]; l.OpenReviews != 1 || l.Capacity == nil || *l.Capacity != 5 {
+		t.Fatalf("u2: expected 1 open review with own capacity 5, got %+v", l)
+	}
+	if l := load["u3"]; l.OpenReviews != 0 || l.Capacity == nil || *l.Capacity != 2 {
+		t.Fatalf("u3: expected no open reviews with team capacity 2, got %+v", l)
+	}
+
+	reopened, err := b.PRs.UpdateStatus(ctx, "pr1", domain.PullRequestStatusOpen)
+	if err != nil || reopened.Status != domain.PullRequestStatusOpen {
+		t.Fatalf("update status: %+v, %v", reopened, err)
+	}
+}
+
+func testVersion(t *testing.T, b Backend) {
+	ctx := context.Background()
+	seedTeam(t, b, "backend", "u1", "u2", "u3")
+	created := createPR(t, b.PRs, "pr1", "u1", base, "u2")
+	if created.Version != 1 {
+		t.Fatalf("expected a new pull request at version 1, got %d", created.Version)
+	}
+
+	reassigned, err := b.PRs.ReassignReviewer(ctx, "pr1", "u2", "u3", "")
+	if err != nil || reassigned.Version != 2 {
+		t.Fatalf("reassign: expected version 2, got %d, %v", reassigned.Version, err)
+	}
+	merged, err := b.PRs.MergePullRequest(ctx, "pr1", base.Add(time.Hour))
+	if err != nil || merged.Version != 3 {
+		t.Fatalf("merge: expected version 3, got %d, %v", merged.Version, err)
+	}
+	reopened, err := b.PRs.UpdateStatus(ctx, "pr1", domain.PullRequestStatusOpen)
+	if err != nil || reopened.Version != 4 {
+		t.Fatalf("update status: expected version 4, got %d, %v", reopened.Version, err)
+	}
+
+	got, err := b.PRs.GetPullRequestByID(ctx, "pr1")
+	if err != nil || got.Version != 4 {
+		t.Fatalf("get: expected version 4, got %d, %v", got.Version, err)
+	}
+}
+
+func testCreateExisting(t *testing.T, b Backend) {
+	ctx := context.Background()
+	seedTeam(t, b, "backend", "u1", "u2", "u3")
+	createPR(t, b.PRs, "pr1", "u1", base, "u2")
+
+	_, err := b.PRs.CreatePullRequest(ctx, domain.PullRequest{
+		ID: "pr1", Name: "again", AuthorID: "u3", Status: domain.PullRequestStatusOpen, AssignedReviewers: []string{"u1"},
+	})
+	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodePRExists {
+		t.Fatalf("expected PR_EXISTS, got %v", err)
+	}
+	pr, err := b.PRs.GetPullRequestByID(ctx, "pr1")
+	if err != nil || pr.AuthorID != "u1" || !slices.Equal(pr.AssignedReviewers, []string{"u2"}) {
+		t.Fatalf("expected the original pull request to stay, got %+v, %v", pr, err)
+	}
+}
+
+func testListByReviewer(t *testing.T, b Backend) {
+	ctx := context.Background()
+	seedTeam(t, b, "backend", "u1", "u2", "u3")
+	createPR(t, b.PRs, "pr-old", "u1", base, "u2")
+	createPR(t, b.PRs, "pr-new", "u3", base.Add(time.Hour), "u2")
+	createPR(t, b.PRs, "pr-other", "u1", base, "u3")
+
+	prs, err := b.PRs.ListByReviewer(ctx, "u2")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(prs) != 2 || prs[0].ID != "pr-new" || prs[1].ID != "pr-old" {
+		t.Fatalf("expected newest first, got %+v", prs)
+	}
+	if prs[0].AuthorID != "u3" || prs[0].Status != domain.PullRequestStatusOpen || prs[0].Version != 1 {
+		t.Fatalf("unexpected short pull request: %+v", prs[0])
+	}
+}
+
+func testCountRecentPairings(t *testing.T, b Backend) {
+	ctx := context.Background()
+	seedTeam(t, b, "backend", "u1", "u2", "u3", "u4")
+	createPR(t, b.PRs, "pr1", "u1", base.Add(-48*time.Hour), "u2")
+	createPR(t, b.PRs, "pr2", "u1", base, "u2", "u3")
+	createPR(t, b.PRs, "pr3", "u1", base.Add(time.Hour), "u2")
+	createPR(t, b.PRs, "pr4", "u4", base, "u2")
+
+	counts, err := b.PRs.CountRecentPairings(ctx, "u1", []string{"u2", "u3", "u4"}, base)
+	if err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if counts["u2"] != 2 || counts["u3"] != 1 || counts["u4"] != 0 {
+		t.Fatalf("unexpected counts: %v", counts)
+	}
+}
+
+func testOpenPullRequestStats(t *testing.T, b Backend) {
+	ctx := context.Background()
+	seedTeam(t, b, "backend", "u1", "u2", "u3")
+	seedTeam(t, b, "frontend", "u4", "u5")
+	createPR(t, b.PRs, "pr1", "u1", base, "u2", "u3")
+	createPR(t, b.PRs, "pr2", "u1", base, "u2")
+	createPR(t, b.PRs, "pr3", "u4", base)
+	createPR(t, b.PRs, "pr-merged", "u4", base, "u5")
+	if _, err := b.PRs.MergePullRequest(ctx, "pr-merged", base); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+
+	stats, err := b.Stats.OpenPullRequestStats(ctx)
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if len(stats.ByTeam) != 2 || stats.ByTeam["backend"] != 2 || stats.ByTeam["frontend"] != 1 {
+		t.Fatalf("unexpected open pull requests by team: %v", stats.ByTeam)
+	}
+	if len(stats.ByReviewerCount) != 3 || stats.ByReviewerCount[0] != 1 || stats.ByReviewerCount[1] != 1 || stats.ByReviewerCount[2] != 1 {
+		t.Fatalf("unexpected open pull requests by reviewer count: %v", stats.ByReviewerCount)
+	}
+	if len(stats.ReviewsByUser) != 2 || stats.ReviewsByUser["u2"] != 2 || stats.ReviewsByUser["u3"] != 1 {
+		t.Fatalf("unexpected open reviews by user: %v", stats.ReviewsByUser)
+	}
+}
+
+func testCodeOwnerRules(t *testing.T, b Backend) {
+	ctx := context.Background()
+	rules := []domain.CodeOwnerRule{
+		{Pattern: "*", Owners: []string{"@lead"}},
+		{Pattern: "*.sql", Owners: []string{"@acme/dba", "@u1"}},
+		{Pattern: "/docs/"},
+	}
+	if err := b.CodeOwners.ReplaceCodeOwnerRules(ctx, rules); err != nil {
+		t.Fatalf("replace: %v", err)
+	}
+	if err := b.CodeOwners.ReplaceCodeOwnerRules(ctx, rules[1:]); err != nil {
+		t.Fatalf("replace: %v", err)
+	}
+
+	stored, err := b.CodeOwners.ListCodeOwnerRules(ctx)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(stored) != 2 || stored[0].Pattern != "*.sql" || !slices.Equal(stored[0].Owners, []string{"@acme/dba", "@u1"}) || stored[1].Pattern != "/docs/" || len(stored[1].Owners) != 0 {
+		t.Fatalf("unexpected rules: %+v", stored)
+	}
+}
+
+func testCommit(t *testing.T, b Backend) {
+	ctx := context.Background()
+	seedTeam(t, b, "backend", "u1", "u2")
+
+	tx, err := b.UoW.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	createPR(t, tx, "pr1", "u1", base, "u2")
+	if _, err := tx.GetPullRequestByID(ctx, "pr1"); err != nil {
+		t.Fatalf("transaction must see its own writes: %v", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	if _, err := b.PRs.GetPullRequestByID(ctx, "pr1"); err != nil {
+		t.Fatalf("committed pull request not visible: %v", err)
+	}
+}
+
+func testRollback(t *testing.T, b Backend) {
+	ctx := context.Background()
+	seedTeam(t, b, "backend", "u1", "u2")
+
+	tx, err := b.UoW.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	createPR(t, tx, "pr1", "u1", base, "u2")
+	if _, err := tx.SetActive(ctx, "u2", false); err != nil {
+		t.Fatalf("set active: %v", err)
+	}
+	if err := tx.ReplaceCodeOwnerRules(ctx, []domain.CodeOwnerRule{{Pattern: "*", Owners: []string{"@u1"}}}); err != nil {
+		t.Fatalf("replace rules: %v", err)
+	}
+	if err := tx.Rollback(ctx); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+
+	_, err = b.PRs.GetPullRequestByID(ctx, "pr1")
+	expectNotFound(t, "rolled back pull request", err)
+	if u, err := b.Users.GetUserByID(ctx, "u2"); err != nil || !u.IsActive {
+		t.Fatalf("rolled back user change is visible: %+v, %v", u, err)
+	}
+	if rules, err := b.CodeOwners.ListCodeOwnerRules(ctx); err != nil || len(rules) != 0 {
+		t.Fatalf("rolled back rules are visible: %+v, %v", rules, err)
+	}
+}
+
+func testDo(t *testing.T, b Backend) {
+	ctx := context.Background()
+	seedTeam(t, b, "backend", "u1", "u2")
+
+	if err := b.UoW.Do(ctx, func(tx repository.Tx) error {
+		createPR(t, tx, "pr1", "u1", base, "u2")
+		return nil
+	}); err != nil {
+		t.Fatalf("do: %v", err)
+	}
+	if _, err := b.PRs.GetPullRequestByID(ctx, "pr1"); err != nil {
+		t.Fatalf("committed pull request not visible: %v", err)
+	}
+
+	failed := errors.New("boom")
+	err := b.UoW.Do(ctx, func(tx repository.Tx) error {
+		createPR(t, tx, "pr2", "u1", base, "u2")
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Fatalf("expected the callback error, got %v", err)
+	}
+	_, err = b.PRs.GetPullRequestByID(ctx, "pr2")
+	expectNotFound(t, "pull request from a failed transaction", err)
+}
+
+func testGetForUpdate(t *testing.T, b Backend) {
+	ctx := context.Background()
+	seedTeam(t, b, "backend", "u1", "u2", "u3")
+	createPR(t, b.PRs, "pr1", "u1", base, "u2")
+
+	holder, err := b.UoW.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer holder.Rollback(ctx)
+	if _, err := holder.GetPullRequestForUpdate(ctx, "pr1"); err != nil {
+		t.Fatalf("lock: %v", err)
+	}
+
+	type result struct {
+		pr  domain.PullRequest
+		err error
+	}
+	waiter := make(chan result, 1)
+	go func() {
+		var r result
+		r.err = b.UoW.Do(ctx, func(tx repository.Tx) error {
+			var err error
+			r.pr, err = tx.GetPullRequestForUpdate(ctx, "pr1")
+			return err
+		})
+		waiter <- r
+	}()
+
+	select {
+	case r := <-waiter:
+		t.Fatalf("read a locked pull request: %+v, %v", r.pr, r.err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if _, err := holder.ReassignReviewer(ctx, "pr1", "u2", "u3", ""); err != nil {
+		t.Fatalf("reassign: %v", err)
+	}
+	if err := holder.Commit(ctx); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	r := <-waiter
+	if r.err != nil {
+		t.Fatalf("waiting transaction: %v", r.err)
+	}
+	if !slices.Equal(r.pr.AssignedReviewers, []string{"u3"}) {
+		t.Fatalf("expected the waiting transaction to see the committed reassignment, got %v", r.pr.AssignedReviewers)
+	}
+}
+
+func memberIDs(users []domain.User) []string {
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	return ids
+}
@@ -0,0 +1,44 @@
+package repositorymemory
+
+import (
+	"context"
+	"slices"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/repository"
+)
+
+type codeOwnerRepo struct {
+	src source
+}
+
+func NewCodeOwnerRepository(store *Store) repository.CodeOwnerRepository {
+	return &codeOwnerRepo{src: store}
+}
+
+func (r *codeOwnerRepo) ReplaceCodeOwnerRules(_ context.Context, rules []domain.CodeOwnerRule) error {
+	return r.src.update(func(st *state) error {
+		st.setCodeOwnerRules(copyRules(rules))
+		return nil
+	})
+}
+
+func (r *codeOwnerRepo) ListCodeOwnerRules(_ context.Context) ([]domain.CodeOwnerRule, error) {
+	var rules []domain.CodeOwnerRule
+	err := r.src.view(func(st *state) error {
+		rules = copyRules(st.codeOwnerRules())
+		return nil
+	})
+	return rules, err
+}
+
+func copyRules(rules []domain.CodeOwnerRule) []domain.CodeOwnerRule {
+	var copied []domain.CodeOwnerRule
+	for _, rule := range rules {
+		copied = append(copied, domain.CodeOwnerRule{
+			Pattern: rule.Pattern,
+			Owners:  slices.Clone(rule.Owners),
+		})
+	}
+	return copied
+}
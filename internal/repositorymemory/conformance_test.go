@@ -0,0 +1,21 @@
+package repositorymemory
+
+import (
+	"testing"
+
+	"pr-reviewer/internal/repository/repositorytest"
+)
+
+func TestConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Backend {
+		store := NewStore()
+		return repositorytest.Backend{
+			Teams:      NewTeamRepository(store),
+			Users:      NewUserRepository(store),
+			PRs:        NewPullRequestRepository(store),
+			CodeOwners: NewCodeOwnerRepository(store),
+			Stats:      NewStatsRepository(store),
+			UoW:        NewUnitOfWork(store),
+		}
+	})
+}
@@ -0,0 +1,222 @@
+package repositorymemory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/repository"
+)
+
+type prRepo struct {
+	src source
+}
+
+func NewPullRequestRepository(store *Store) repository.PullRequestRepository {
+	return &prRepo{src: store}
+}
+
+func (r *prRepo) CreatePullRequest(_ context.Context, pr domain.PullRequest) (domain.PullRequest, error) {
+	var created domain.PullRequest
+	err := r.src.update(func(st *state) error {
+		if _, ok := lookup(st, prsOf, pr.ID); ok {
+			return domain.NewDomainError(domain.ErrorCodePRExists, "pull request already exists")
+		}
+		if _, err := st.user(pr.AuthorID); err != nil {
+			return err
+		}
+
+		stored := &pullRequest{
+			id:           pr.ID,
+			name:         pr.Name,
+			authorID:     pr.AuthorID,
+			status:       pr.Status,
+			createdAt:    pr.CreatedAt,
+			mergedAt:     copyTime(pr.MergedAt),
+			reviewers:    make(map[string]string),
+			tags:         sortedSet(pr.Tags),
+			understaffed: pr.Understaffed,
+			version:      1,
+		}
+		if stored.createdAt.IsZero() {
+			stored.createdAt = time.Now()
+		}
+		for _, reviewerID := range pr.AssignedReviewers {
+			if err := st.addReviewer(stored, reviewerID, pr.CrossTeamReviewers[reviewerID]); err != nil {
+				return err
+			}
+		}
+		st.putPR(stored)
+
+		created = stored.view()
+		// Keep the selection order rather than the stored one.
+		created.AssignedReviewers = slices.Clone(pr.AssignedReviewers)
+		return nil
+	})
+	return created, err
+}
+
+func (r *prRepo) GetPullRequestByID(_ context.Context, prID string) (domain.PullRequest, error) {
+	var result domain.PullRequest
+	err := r.src.view(func(st *state) error {
+		pr, err := st.pullRequest(prID)
+		if err != nil {
+			return err
+		}
+		result = pr.view()
+		return nil
+	})
+	return result, err
+}
+
+func (r *prRepo) MergePullRequest(_ context.Context, prID string, mergedAt time.Time) (domain.PullRequest, error) {
+	return r.modify(prID, func(pr *pullRequest) error {
+		pr.status = domain.PullRequestStatusMerged
+		pr.mergedAt = &mergedAt
+		return nil
+	})
+}
+
+func (r *prRepo) UpdateStatus(_ context.Context, prID string, status domain.PullRequestStatus) (domain.PullRequest, error) {
+	return r.modify(prID, func(pr *pullRequest) error {
+		pr.status = status
+		return nil
+	})
+}
+
+func (r *prRepo) ReassignReviewer(_ context.Context, prID, oldReviewerID, newReviewerID, sourceTeam string) (domain.PullRequest, error) {
+	var result domain.PullRequest
+	err := r.src.update(func(st *state) error {
+		stored, err := st.pullRequest(prID)
+		if err != nil {
+			return err
+		}
+		pr := stored.clone()
+		delete(pr.reviewers, oldReviewerID)
+		if err := st.addReviewer(pr, newReviewerID, sourceTeam); err != nil {
+			return err
+		}
+		pr.version++
+		st.putPR(pr)
+		result = pr.view()
+		return nil
+	})
+	return result, err
+}
+
+func (r *prRepo) ListByReviewer(_ context.Context, reviewerID string) ([]domain.PullRequestShort, error) {
+	var matched []*pullRequest
+	err := r.src.view(func(st *state) error {
+		each(st, prsOf, func(pr *pullRequest) {
+			if _, ok := pr.reviewers[reviewerID]; ok {
+				matched = append(matched, pr)
+			}
+		})
+		slices.SortFunc(matched, func(a, b *pullRequest) int {
+			return b.createdAt.Compare(a.createdAt)
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []domain.PullRequestShort
+	for _, pr := range matched {
+		result = append(result, domain.PullRequestShort{
+			ID:       pr.id,
+			Name:     pr.name,
+			AuthorID: pr.authorID,
+			Status:   pr.status,
+			Version:  pr.version,
+		})
+	}
+	return result, nil
+}
+
+func (r *prRepo) CountRecentPairings(_ context.Context, authorID string, reviewerIDs []string, since time.Time) (map[string]int, error) {
+	counts := make(map[string]int, len(reviewerIDs))
+	err := r.src.view(func(st *state) error {
+		each(st, prsOf, func(pr *pullRequest) {
+			if pr.authorID != authorID || pr.createdAt.Before(since) {
+				return
+			}
+			for _, id := range reviewerIDs {
+				if _, ok := pr.reviewers[id]; ok {
+					counts[id]++
+				}
+			}
+		})
+		return nil
+	})
+	return counts, err
+}
+
+func (r *prRepo) modify(prID string, fn func(*pullRequest) error) (domain.PullRequest, error) {
+	var result domain.PullRequest
+	err := r.src.update(func(st *state) error {
+		stored, err := st.pullRequest(prID)
+		if err != nil {
+			return err
+		}
+		pr := stored.clone()
+		if err := fn(pr); err != nil {
+			return err
+		}
+		pr.version++
+		st.putPR(pr)
+		result = pr.view()
+		return nil
+	})
+	return result, err
+}
+
+// pullRequest returns the stored pull request; writers change a clone and
+// store it with putPR.
+func (st *state) pullRequest(id string) (*pullRequest, error) {
+	pr, ok := lookup(st, prsOf, id)
+	if !ok {
+		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "pull request not found")
+	}
+	return pr, nil
+}
+
+// addReviewer records sourceTeam, the fallback team the reviewer was borrowed
+// from, or "" for none.
+func (st *state) addReviewer(pr *pullRequest, reviewerID, sourceTeam string) error {
+	if _, err := st.user(reviewerID); err != nil {
+		return err
+	}
+	if _, ok := pr.reviewers[reviewerID]; ok {
+		return nil
+	}
+	pr.reviewers[reviewerID] = sourceTeam
+	return nil
+}
+
+func (pr *pullRequest) view() domain.PullRequest {
+	result := domain.PullRequest{
+		ID:           pr.id,
+		Name:         pr.name,
+		AuthorID:     pr.authorID,
+		Status:       pr.status,
+		Understaffed: pr.understaffed,
+		Tags:         slices.Clone(pr.tags),
+		CreatedAt:    pr.createdAt,
+		Version:      pr.version,
+	}
+	result.MergedAt = copyTime(pr.mergedAt)
+	for id, source := range pr.reviewers {
+		result.AssignedReviewers = append(result.AssignedReviewers, id)
+		if source != "" {
+			if result.CrossTeamReviewers == nil {
+				result.CrossTeamReviewers = make(map[string]string)
+			}
+			result.CrossTeamReviewers[id] = source
+		}
+	}
+	slices.SortFunc(result.AssignedReviewers, strings.Compare)
+	return result
+}
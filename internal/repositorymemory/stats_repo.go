@@ -0,0 +1,39 @@
+package repositorymemory
+
+import (
+	"context"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/repository"
+)
+
+type statsRepo struct {
+	src source
+}
+
+func NewStatsRepository(store *Store) repository.StatsRepository {
+	return &statsRepo{src: store}
+}
+
+func (r *statsRepo) OpenPullRequestStats(_ context.Context) (domain.OpenPullRequestStats, error) {
+	stats := domain.OpenPullRequestStats{
+		ByTeam:          make(map[string]int),
+		ByReviewerCount: make(map[int]int),
+		ReviewsByUser:   make(map[string]int),
+	}
+	err := r.src.view(func(st *state) error {
+		each(st, prsOf, func(pr *pullRequest) {
+			if pr.status != domain.PullRequestStatusOpen {
+				return
+			}
+			author, _ := lookup(st, usersOf, pr.authorID)
+			stats.ByTeam[author.TeamName]++
+			stats.ByReviewerCount[len(pr.reviewers)]++
+			for id := range pr.reviewers {
+				stats.ReviewsByUser[id]++
+			}
+		})
+		return nil
+	})
+	return stats, err
+}
@@ -0,0 +1,284 @@
+// Package repositorymemory keeps repository data in process memory. It mirrors
+// the behaviour of repositorypostgres, so the service layer can run without a
+// database, e.g. in simulations.
+package repositorymemory
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"pr-reviewer/internal/domain"
+)
+
+// Store holds the committed state. Reads see committed data only; writers,
+// both transactions and single writes outside of one, are serialized, so a
+// transaction behaves as if it ran alone.
+type Store struct {
+	mu     sync.RWMutex
+	state  *state
+	writer chan struct{}
+}
+
+func NewStore() *Store {
+	return &Store{
+		state:  newState(),
+		writer: make(chan struct{}, 1),
+	}
+}
+
+// source gives repositories access to the state they work on.
+type source interface {
+	view(fn func(*state) error) error
+	update(fn func(*state) error) error
+}
+
+func (s *Store) view(fn func(*state) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return fn(s.state)
+}
+
+// update applies a single write outside of a transaction. A failed write
+// leaves the committed state untouched.
+func (s *Store) update(fn func(*state) error) error {
+	s.writer <- struct{}{}
+	defer func() { <-s.writer }()
+
+	// Only the writer changes the committed state, so it can be read without
+	// the lock while the write is built.
+	next := s.state.layer()
+	if err := fn(next); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	next.merge()
+	s.mu.Unlock()
+	return nil
+}
+
+// state is either the committed state or a layer of uncommitted writes on top
+// of a parent. A layer holds only the entities written through it, so starting
+// a transaction or a statement costs nothing and committing costs only what was
+// written. Entities are never changed in place: writers store a modified copy.
+type state struct {
+	parent *state
+
+	teams    map[string]*team
+	users    map[string]domain.User
+	userTags map[string][]string
+	prs      map[string]*pullRequest
+	// openReviews counts the open pull requests each user reviews. In a layer
+	// it holds the change against the parent.
+	openReviews map[string]int
+
+	// periods and rules are replaced as a whole; a layer that has not written
+	// them leaves them nil and reads the parent's.
+	periods      *[]domain.Unavailability
+	nextPeriodID int64
+	rules        *[]domain.CodeOwnerRule
+}
+
+type team struct {
+	name            string
+	defaultCapacity *int
+	policy          domain.TeamPolicy
+	fallbacks       []string
+}
+
+type pullRequest struct {
+	id        string
+	name      string
+	authorID  string
+	status    domain.PullRequestStatus
+	createdAt time.Time
+	mergedAt  *time.Time
+	// reviewers maps reviewer IDs to the fallback team they were borrowed
+	// from, or "" for reviewers that were not borrowed.
+	reviewers    map[string]string
+	tags         []string
+	understaffed bool
+	version      int64
+}
+
+func newState() *state {
+	return &state{
+		teams:       make(map[string]*team),
+		users:       make(map[string]domain.User),
+		userTags:    make(map[string][]string),
+		prs:         make(map[string]*pullRequest),
+		openReviews: make(map[string]int),
+		periods:     new([]domain.Unavailability),
+		rules:       new([]domain.CodeOwnerRule),
+	}
+}
+
+// layer starts an empty set of writes on top of st.
+func (st *state) layer() *state {
+	l := newState()
+	l.parent = st
+	l.periods = nil
+	l.nextPeriodID = st.nextPeriodID
+	l.rules = nil
+	return l
+}
+
+// merge applies the layer's writes to its parent.
+func (st *state) merge() {
+	p := st.parent
+	maps.Copy(p.teams, st.teams)
+	maps.Copy(p.users, st.users)
+	maps.Copy(p.userTags, st.userTags)
+	maps.Copy(p.prs, st.prs)
+	for id, n := range st.openReviews {
+		if p.openReviews[id] += n; p.openReviews[id] == 0 {
+			delete(p.openReviews, id)
+		}
+	}
+	if st.periods != nil {
+		p.periods = st.periods
+	}
+	p.nextPeriodID = st.nextPeriodID
+	if st.rules != nil {
+		p.rules = st.rules
+	}
+}
+
+// lookup finds key in the nearest layer that has written it.
+func lookup[V any](st *state, entities func(*state) map[string]V, key string) (V, bool) {
+	for s := st; s != nil; s = s.parent {
+		if v, ok := entities(s)[key]; ok {
+			return v, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// each calls fn for the latest version of every entity.
+func each[V any](st *state, entities func(*state) map[string]V, fn func(V)) {
+	for s := st; s != nil; s = s.parent {
+		for key, v := range entities(s) {
+			if !shadowed(st, s, entities, key) {
+				fn(v)
+			}
+		}
+	}
+}
+
+// shadowed reports whether a layer between top and s has rewritten key.
+func shadowed[V any](top, s *state, entities func(*state) map[string]V, key string) bool {
+	for l := top; l != s; l = l.parent {
+		if _, ok := entities(l)[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func teamsOf(st *state) map[string]*team       { return st.teams }
+func usersOf(st *state) map[string]domain.User { return st.users }
+func userTagsOf(st *state) map[string][]string { return st.userTags }
+func prsOf(st *state) map[string]*pullRequest  { return st.prs }
+
+func (st *state) putTeam(t *team) {
+	st.teams[t.name] = t
+}
+
+func (st *state) putUser(u domain.User) {
+	st.users[u.ID] = u
+}
+
+// putPR stores pr and keeps the open review counts in step with it.
+func (st *state) putPR(pr *pullRequest) {
+	if old, ok := lookup(st, prsOf, pr.id); ok {
+		st.countOpenReviews(old, -1)
+	}
+	st.countOpenReviews(pr, 1)
+	st.prs[pr.id] = pr
+}
+
+func (st *state) countOpenReviews(pr *pullRequest, delta int) {
+	if pr.status != domain.PullRequestStatusOpen {
+		return
+	}
+	for id := range pr.reviewers {
+		st.openReviews[id] += delta
+	}
+}
+
+// openReviewCount sums the counts of every layer.
+func (st *state) openReviewCount(userID string) int {
+	n := 0
+	for s := st; s != nil; s = s.parent {
+		n += s.openReviews[userID]
+	}
+	return n
+}
+
+func (st *state) allPeriods() []domain.Unavailability {
+	s := st
+	for s.periods == nil {
+		s = s.parent
+	}
+	return *s.periods
+}
+
+func (st *state) setPeriods(periods []domain.Unavailability) {
+	st.periods = &periods
+}
+
+func (st *state) codeOwnerRules() []domain.CodeOwnerRule {
+	s := st
+	for s.rules == nil {
+		s = s.parent
+	}
+	return *s.rules
+}
+
+func (st *state) setCodeOwnerRules(rules []domain.CodeOwnerRule) {
+	st.rules = &rules
+}
+
+func (t *team) clone() *team {
+	c := *t
+	c.defaultCapacity = copyInt(t.defaultCapacity)
+	c.fallbacks = slices.Clone(t.fallbacks)
+	return &c
+}
+
+func (pr *pullRequest) clone() *pullRequest {
+	c := *pr
+	c.mergedAt = copyTime(pr.mergedAt)
+	c.reviewers = maps.Clone(pr.reviewers)
+	c.tags = slices.Clone(pr.tags)
+	return &c
+}
+
+func copyUser(u domain.User) domain.User {
+	if u.WorkingHours != nil {
+		h := *u.WorkingHours
+		u.WorkingHours = &h
+	}
+	u.ReviewCapacity = copyInt(u.ReviewCapacity)
+	u.Tags = nil
+	return u
+}
+
+func copyInt(v *int) *int {
+	if v == nil {
+		return nil
+	}
+	c := *v
+	return &c
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	c := *t
+	return &c
+}
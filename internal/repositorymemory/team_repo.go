@@ -0,0 +1,167 @@
+package repositorymemory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/repository"
+)
+
+type teamRepo struct {
+	src source
+}
+
+func NewTeamRepository(store *Store) repository.TeamRepository {
+	return &teamRepo{src: store}
+}
+
+func (r *teamRepo) UpsertTeam(_ context.Context, t domain.Team) (domain.Team, error) {
+	created := domain.Team{
+		Name:                  t.Name,
+		DefaultReviewCapacity: t.DefaultReviewCapacity,
+		Policy:                t.Policy,
+	}
+	err := r.src.update(func(st *state) error {
+		if _, ok := lookup(st, teamsOf, t.Name); !ok {
+			st.putTeam(&team{
+				name:            t.Name,
+				defaultCapacity: copyInt(t.DefaultReviewCapacity),
+				policy:          t.Policy,
+			})
+		}
+		for _, m := range t.Members {
+			m = copyUser(m)
+			m.TeamName = t.Name
+			if old, ok := lookup(st, usersOf, m.ID); ok {
+				m = keepUserSettings(m, old)
+			}
+			if m.Seniority == "" {
+				m.Seniority = domain.SeniorityMiddle
+			}
+			st.putUser(m)
+			created.Members = append(created.Members, copyUser(m))
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.Team{}, err
+	}
+	return created, nil
+}
+
+func (r *teamRepo) GetTeamByName(_ context.Context, teamName string) (domain.Team, error) {
+	var result domain.Team
+	err := r.src.view(func(st *state) error {
+		t, err := st.team(teamName)
+		if err != nil {
+			return err
+		}
+		result = st.teamView(t)
+		return nil
+	})
+	return result, err
+}
+
+func (r *teamRepo) SetDefaultReviewCapacity(_ context.Context, teamName string, capacity *int) (domain.Team, error) {
+	return r.modify(teamName, func(t *team) {
+		t.defaultCapacity = copyInt(capacity)
+	})
+}
+
+func (r *teamRepo) SetFallbackTeams(_ context.Context, teamName string, fallbacks []string) (domain.Team, error) {
+	return r.modify(teamName, func(t *team) {
+		t.fallbacks = slices.Clone(fallbacks)
+	})
+}
+
+func (r *teamRepo) ListFallbackTeams(_ context.Context, teamName string) ([]string, error) {
+	var fallbacks []string
+	err := r.src.view(func(st *state) error {
+		if t, ok := lookup(st, teamsOf, teamName); ok {
+			fallbacks = slices.Clone(t.fallbacks)
+		}
+		return nil
+	})
+	return fallbacks, err
+}
+
+func (r *teamRepo) GetTeamPolicy(_ context.Context, teamName string) (domain.TeamPolicy, error) {
+	var policy domain.TeamPolicy
+	err := r.src.view(func(st *state) error {
+		t, err := st.team(teamName)
+		if err != nil {
+			return err
+		}
+		policy = t.policy
+		return nil
+	})
+	return policy, err
+}
+
+func (r *teamRepo) SetTeamPolicy(_ context.Context, teamName string, policy domain.TeamPolicy) (domain.Team, error) {
+	return r.modify(teamName, func(t *team) {
+		t.policy = policy
+	})
+}
+
+func (r *teamRepo) modify(teamName string, fn func(*team)) (domain.Team, error) {
+	var result domain.Team
+	err := r.src.update(func(st *state) error {
+		stored, err := st.team(teamName)
+		if err != nil {
+			return err
+		}
+		t := stored.clone()
+		fn(t)
+		st.putTeam(t)
+		result = st.teamView(t)
+		return nil
+	})
+	return result, err
+}
+
+func (st *state) team(name string) (*team, error) {
+	t, ok := lookup(st, teamsOf, name)
+	if !ok {
+		return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "team not found")
+	}
+	return t, nil
+}
+
+func (st *state) teamView(t *team) domain.Team {
+	view := domain.Team{
+		Name:                  t.name,
+		DefaultReviewCapacity: copyInt(t.defaultCapacity),
+		Policy:                t.policy,
+		FallbackTeams:         slices.Clone(t.fallbacks),
+	}
+	each(st, usersOf, func(u domain.User) {
+		if u.TeamName == t.name {
+			view.Members = append(view.Members, copyUser(u))
+		}
+	})
+	slices.SortFunc(view.Members, func(a, b domain.User) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return view
+}
+
+// keepUserSettings fills the settings a re-posted member leaves unset from the
+// stored user, the way the SQL upserts coalesce them.
+func keepUserSettings(m, old domain.User) domain.User {
+	if m.Timezone == "" {
+		m.Timezone = old.Timezone
+	}
+	if m.WorkingHours == nil {
+		m.WorkingHours = copyUser(old).WorkingHours
+	}
+	if m.ReviewCapacity == nil {
+		m.ReviewCapacity = copyInt(old.ReviewCapacity)
+	}
+	if m.Seniority == "" {
+		m.Seniority = old.Seniority
+	}
+	return m
+}
@@ -0,0 +1,121 @@
+package repositorymemory
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/repository"
+)
+
+var ErrTxDone = errors.New("repositorymemory: transaction has already been committed or rolled back")
+
+type unitOfWork struct {
+	store *Store
+}
+
+func NewUnitOfWork(store *Store) repository.UnitOfWork {
+	return &unitOfWork{store: store}
+}
+
+// Begin waits until no other writer is active and starts a transaction as an
+// empty layer of writes over the committed state. Writing through the store outside of
+// the transaction before it ends blocks, so callers must not do that from the
+// goroutine holding it.
+func (u *unitOfWork) Begin(ctx context.Context) (repository.Tx, error) {
+	select {
+	case u.store.writer <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	// The committed state cannot change until the transaction ends, so the
+	// layer reads it without the lock.
+	t := &tx{store: u.store, state: u.store.state.layer()}
+	t.teamRepo = teamRepo{src: t}
+	t.userRepo = userRepo{src: t}
+	t.prRepo = prRepo{src: t}
+	t.codeOwnerRepo = codeOwnerRepo{src: t}
+	return t, nil
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(tx repository.Tx) error) error {
+	return repository.RunTx(ctx, u, fn)
+}
+
+type tx struct {
+	teamRepo
+	userRepo
+	prRepo
+	codeOwnerRepo
+
+	store *Store
+	mu    sync.Mutex
+	state *state
+	done  bool
+}
+
+func (t *tx) view(fn func(*state) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return ErrTxDone
+	}
+	return fn(t.state)
+}
+
+// update runs fn on a layer of its own so that a failed statement does not
+// leave partial writes in the transaction.
+func (t *tx) update(fn func(*state) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return ErrTxDone
+	}
+
+	next := t.state.layer()
+	if err := fn(next); err != nil {
+		return err
+	}
+	next.merge()
+	return nil
+}
+
+func (t *tx) Commit(context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return ErrTxDone
+	}
+
+	t.store.mu.Lock()
+	t.state.merge()
+	t.store.mu.Unlock()
+	t.finish()
+	return nil
+}
+
+// GetPullRequestForUpdate needs no extra lock: the transaction already
+// excludes every other writer.
+func (t *tx) GetPullRequestForUpdate(ctx context.Context, prID string) (domain.PullRequest, error) {
+	return t.GetPullRequestByID(ctx, prID)
+}
+
+// Rollback discards the transaction's writes. It is a no-op after Commit, so
+// it can be deferred.
+func (t *tx) Rollback(context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return nil
+	}
+	t.finish()
+	return nil
+}
+
+func (t *tx) finish() {
+	t.done = true
+	t.state = nil
+	<-t.store.writer
+}
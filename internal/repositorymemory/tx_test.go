@@ -0,0 +1,230 @@
+package repositorymemory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"pr-reviewer/internal/domain"
+)
+
+func seed(t *testing.T, store *Store) {
+	t.Helper()
+	_, err := NewTeamRepository(store).UpsertTeam(context.Background(), domain.Team{
+		Name:    "backend",
+		Members: []domain.User{{ID: "u1", IsActive: true}, {ID: "u2", IsActive: true}},
+	})
+	if err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+}
+
+func TestTx_IsolatesUncommittedWrites(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	seed(t, store)
+	users := NewUserRepository(store)
+
+	tx, err := NewUnitOfWork(store).Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	if _, err := tx.SetActive(ctx, "u1", false); err != nil {
+		t.Fatalf("set active: %v", err)
+	}
+
+	if u, _ := tx.GetUserByID(ctx, "u1"); u.IsActive {
+		t.Fatalf("transaction should see its own write")
+	}
+	if u, _ := users.GetUserByID(ctx, "u1"); !u.IsActive {
+		t.Fatalf("uncommitted write leaked outside the transaction")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if u, _ := users.GetUserByID(ctx, "u1"); u.IsActive {
+		t.Fatalf("committed write is not visible")
+	}
+	if _, err := tx.GetUserByID(ctx, "u1"); !errors.Is(err, ErrTxDone) {
+		t.Fatalf("expected ErrTxDone after commit, got %v", err)
+	}
+	if err := tx.Rollback(ctx); err != nil {
+		t.Fatalf("rollback after commit should be a no-op, got %v", err)
+	}
+}
+
+func TestTx_FailedStatementKeepsEarlierWrites(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	seed(t, store)
+
+	tx, err := NewUnitOfWork(store).Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.CreatePullRequest(ctx, domain.PullRequest{ID: "pr1", AuthorID: "u1", Status: domain.PullRequestStatusOpen, AssignedReviewers: []string{"u2"}}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := tx.CreatePullRequest(ctx, domain.PullRequest{ID: "pr2", AuthorID: "u1", AssignedReviewers: []string{"ghost"}}); err == nil {
+		t.Fatalf("expected unknown reviewer error")
+	}
+	if _, err := tx.GetPullRequestByID(ctx, "pr2"); err == nil {
+		t.Fatalf("failed statement left a partial write")
+	}
+	if _, err := tx.GetPullRequestByID(ctx, "pr1"); err != nil {
+		t.Fatalf("earlier write lost: %v", err)
+	}
+}
+
+func TestTx_SerializesWriters(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	seed(t, store)
+	uow := NewUnitOfWork(store)
+	users := NewUserRepository(store)
+
+	first, err := uow.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := uow.Begin(waitCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("second writer should wait for the first, got %v", err)
+	}
+
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		if _, err := users.SetActive(ctx, "u2", false); err != nil {
+			t.Errorf("set active: %v", err)
+		}
+	}()
+	select {
+	case <-written:
+		t.Fatalf("write outside the transaction should wait for it")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if err := first.Rollback(ctx); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	<-written
+	if u, _ := users.GetUserByID(ctx, "u2"); u.IsActive {
+		t.Fatalf("expected write after rollback to apply")
+	}
+}
+
+func TestTx_ConcurrentIncrementsAreNotLost(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	seed(t, store)
+	uow := NewUnitOfWork(store)
+
+	const workers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tx, err := uow.Begin(ctx)
+			if err != nil {
+				t.Errorf("begin: %v", err)
+				return
+			}
+			defer tx.Rollback(ctx)
+
+			team, err := tx.GetTeamByName(ctx, "backend")
+			if err != nil {
+				t.Errorf("get team: %v", err)
+				return
+			}
+			next := 1
+			if team.DefaultReviewCapacity != nil {
+				next = *team.DefaultReviewCapacity + 1
+			}
+			if _, err := tx.SetDefaultReviewCapacity(ctx, "backend", &next); err != nil {
+				t.Errorf("set capacity: %v", err)
+				return
+			}
+			if err := tx.Commit(ctx); err != nil {
+				t.Errorf("commit: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	team, err := NewTeamRepository(store).GetTeamByName(ctx, "backend")
+	if err != nil {
+		t.Fatalf("get team: %v", err)
+	}
+	if team.DefaultReviewCapacity == nil || *team.DefaultReviewCapacity != workers {
+		t.Fatalf("expected capacity %d, got %v", workers, team.DefaultReviewCapacity)
+	}
+}
+
+func TestTx_OpenReviewCountsFollowWrites(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	seed(t, store)
+	users := NewUserRepository(store)
+	openReviews := func(r interface {
+		GetReviewLoad(context.Context, []string) (map[string]domain.ReviewLoad, error)
+	}) int {
+		t.Helper()
+		load, err := r.GetReviewLoad(ctx, []string{"u2"})
+		if err != nil {
+			t.Fatalf("review load: %v", err)
+		}
+		return load["u2"].OpenReviews
+	}
+	uow := NewUnitOfWork(store)
+
+	tx, err := uow.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	if _, err := tx.CreatePullRequest(ctx, domain.PullRequest{ID: "pr1", AuthorID: "u1", Status: domain.PullRequestStatusOpen, AssignedReviewers: []string{"u2"}}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if n := openReviews(tx); n != 1 {
+		t.Fatalf("expected 1 open review inside the transaction, got %d", n)
+	}
+	if n := openReviews(users); n != 0 {
+		t.Fatalf("uncommitted review counted outside the transaction: %d", n)
+	}
+	if err := tx.Rollback(ctx); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	if n := openReviews(users); n != 0 {
+		t.Fatalf("rolled back review counted: %d", n)
+	}
+
+	prs := NewPullRequestRepository(store)
+	if _, err := prs.CreatePullRequest(ctx, domain.PullRequest{ID: "pr1", AuthorID: "u1", Status: domain.PullRequestStatusOpen, AssignedReviewers: []string{"u2"}}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if n := openReviews(users); n != 1 {
+		t.Fatalf("expected 1 open review, got %d", n)
+	}
+
+	tx, err = uow.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	if _, err := tx.MergePullRequest(ctx, "pr1", time.Now()); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if n := openReviews(users); n != 0 {
+		t.Fatalf("merged pull request still counted: %d", n)
+	}
+}
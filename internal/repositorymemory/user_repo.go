@@ -0,0 +1,214 @@
+package repositorymemory
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/repository"
+)
+
+type userRepo struct {
+	src source
+}
+
+func NewUserRepository(store *Store) repository.UserRepository {
+	return &userRepo{src: store}
+}
+
+func (r *userRepo) GetUserByID(_ context.Context, userID string) (domain.User, error) {
+	var result domain.User
+	err := r.src.view(func(st *state) error {
+		u, err := st.user(userID)
+		if err != nil {
+			return err
+		}
+		result = u
+		return nil
+	})
+	return result, err
+}
+
+func (r *userRepo) SetActive(_ context.Context, userID string, isActive bool) (domain.User, error) {
+	return r.modify(userID, func(u *domain.User) {
+		u.IsActive = isActive
+	})
+}
+
+func (r *userRepo) SetWorkingHours(_ context.Context, userID, timezone string, hours *domain.WorkingHours) (domain.User, error) {
+	return r.modify(userID, func(u *domain.User) {
+		u.Timezone = timezone
+		u.WorkingHours = nil
+		if hours != nil {
+			h := *hours
+			u.WorkingHours = &h
+		}
+	})
+}
+
+func (r *userRepo) SetReviewCapacity(_ context.Context, userID string, capacity *int) (domain.User, error) {
+	return r.modify(userID, func(u *domain.User) {
+		u.ReviewCapacity = copyInt(capacity)
+	})
+}
+
+func (r *userRepo) SetSeniority(_ context.Context, userID string, seniority domain.Seniority) (domain.User, error) {
+	return r.modify(userID, func(u *domain.User) {
+		u.Seniority = seniority
+	})
+}
+
+func (r *userRepo) ListActiveByTeam(_ context.Context, teamName string, at time.Time) ([]domain.User, error) {
+	var users []domain.User
+	err := r.src.view(func(st *state) error {
+		each(st, usersOf, func(u domain.User) {
+			if u.TeamName == teamName && u.IsActive && !st.unavailable(u.ID, at) {
+				users = append(users, copyUser(u))
+			}
+		})
+		return nil
+	})
+	slices.SortFunc(users, func(a, b domain.User) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+	return users, err
+}
+
+func (r *userRepo) GetReviewLoad(_ context.Context, userIDs []string) (map[string]domain.ReviewLoad, error) {
+	load := make(map[string]domain.ReviewLoad, len(userIDs))
+	err := r.src.view(func(st *state) error {
+		for _, id := range userIDs {
+			u, ok := lookup(st, usersOf, id)
+			if !ok {
+				continue
+			}
+			t, ok := lookup(st, teamsOf, u.TeamName)
+			if !ok {
+				continue
+			}
+
+			l := domain.ReviewLoad{
+				Capacity:    copyInt(u.ReviewCapacity),
+				OpenReviews: st.openReviewCount(id),
+			}
+			if l.Capacity == nil {
+				l.Capacity = copyInt(t.defaultCapacity)
+			}
+			load[id] = l
+		}
+		return nil
+	})
+	return load, err
+}
+
+func (r *userRepo) AddUnavailability(_ context.Context, period domain.Unavailability) (domain.Unavailability, error) {
+	err := r.src.update(func(st *state) error {
+		if _, err := st.user(period.UserID); err != nil {
+			return err
+		}
+		st.nextPeriodID++
+		period.ID = st.nextPeriodID
+		st.setPeriods(append(slices.Clone(st.allPeriods()), period))
+		return nil
+	})
+	if err != nil {
+		return domain.Unavailability{}, err
+	}
+	return period, nil
+}
+
+func (r *userRepo) ListUnavailability(_ context.Context, userID string) ([]domain.Unavailability, error) {
+	var periods []domain.Unavailability
+	err := r.src.view(func(st *state) error {
+		for _, p := range st.allPeriods() {
+			if p.UserID == userID {
+				periods = append(periods, p)
+			}
+		}
+		return nil
+	})
+	slices.SortFunc(periods, func(a, b domain.Unavailability) int {
+		if c := a.StartsAt.Compare(b.StartsAt); c != 0 {
+			return c
+		}
+		return int(a.ID - b.ID)
+	})
+	return periods, err
+}
+
+func (r *userRepo) DeleteUnavailability(_ context.Context, userID string, periodID int64) error {
+	return r.src.update(func(st *state) error {
+		periods := st.allPeriods()
+		i := slices.IndexFunc(periods, func(p domain.Unavailability) bool {
+			return p.ID == periodID && p.UserID == userID
+		})
+		if i < 0 {
+			return domain.NewDomainError(domain.ErrorCodeNotFound, "unavailability period not found")
+		}
+		st.setPeriods(slices.Delete(slices.Clone(periods), i, i+1))
+		return nil
+	})
+}
+
+func (r *userRepo) SetUserTags(_ context.Context, userID string, tags []string) error {
+	return r.src.update(func(st *state) error {
+		if _, err := st.user(userID); err != nil {
+			return err
+		}
+		st.userTags[userID] = sortedSet(tags)
+		return nil
+	})
+}
+
+func (r *userRepo) ListUserTags(_ context.Context, userIDs []string) (map[string][]string, error) {
+	tags := make(map[string][]string, len(userIDs))
+	err := r.src.view(func(st *state) error {
+		for _, id := range userIDs {
+			if t, _ := lookup(st, userTagsOf, id); len(t) > 0 {
+				tags[id] = slices.Clone(t)
+			}
+		}
+		return nil
+	})
+	return tags, err
+}
+
+func (r *userRepo) modify(userID string, fn func(*domain.User)) (domain.User, error) {
+	var result domain.User
+	err := r.src.update(func(st *state) error {
+		u, err := st.user(userID)
+		if err != nil {
+			return err
+		}
+		fn(&u)
+		st.putUser(u)
+		result = copyUser(u)
+		return nil
+	})
+	return result, err
+}
+
+func (st *state) user(id string) (domain.User, error) {
+	u, ok := lookup(st, usersOf, id)
+	if !ok {
+		return domain.User{}, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
+	}
+	return copyUser(u), nil
+}
+
+func (st *state) unavailable(userID string, at time.Time) bool {
+	for _, p := range st.allPeriods() {
+		if p.UserID == userID && !p.StartsAt.After(at) && p.EndsAt.After(at) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedSet(values []string) []string {
+	set := slices.Clone(values)
+	slices.Sort(set)
+	return slices.Compact(set)
+}
@@ -0,0 +1,59 @@
+package repositorypostgres
+
+import (
+	"context"
+	"strings"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/repository"
+)
+
+type codeOwnerRepo struct {
+	exec executor
+}
+
+func NewCodeOwnerRepository(db *DB) repository.CodeOwnerRepository {
+	return &codeOwnerRepo{exec: traced(db.SQL)}
+}
+
+func (r *codeOwnerRepo) ReplaceCodeOwnerRules(ctx context.Context, rules []domain.CodeOwnerRule) error {
+	if _, err := r.exec.ExecContext(ctx, `DELETE FROM code_owner_rules`); err != nil {
+		return err
+	}
+
+	for i, rule := range rules {
+		if _, err := r.exec.ExecContext(ctx, `
+			INSERT INTO code_owner_rules (position, pattern, owners)
+			VALUES ($1, $2, $3)
+		`, i, rule.Pattern, strings.Join(rule.Owners, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *codeOwnerRepo) ListCodeOwnerRules(ctx context.Context) ([]domain.CodeOwnerRule, error) {
+	rows, err := r.exec.QueryContext(ctx, `
+		SELECT pattern, owners
+		FROM code_owner_rules
+		ORDER BY position
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(rows)
+
+	var rules []domain.CodeOwnerRule
+	for rows.Next() {
+		var (
+			rule   domain.CodeOwnerRule
+			owners string
+		)
+		if err := rows.Scan(&rule.Pattern, &owners); err != nil {
+			return nil, err
+		}
+		rule.Owners = strings.Fields(owners)
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
@@ -0,0 +1,49 @@
+package repositorypostgres
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"pr-reviewer/internal/config"
+	"pr-reviewer/internal/repository/repositorytest"
+	"pr-reviewer/migrations"
+)
+
+// TestConformance needs a disposable database: every subtest truncates all
+// tables. Set TEST_DB_DSN to run it.
+func TestConformance(t *testing.T) {
+	dsn := os.Getenv("TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DB_DSN is not set")
+	}
+
+	ctx := context.Background()
+	db, err := NewDB(ctx, &config.Config{DB: config.DBConfig{DSN: dsn}})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	if err := ApplyMigrations(ctx, db, migrations.FS, nil); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Backend {
+		if _, err := db.SQL.ExecContext(ctx, `
+			TRUNCATE teams, users, pull_requests, pull_request_reviewers, user_unavailability,
+				team_fallbacks, code_owner_rules, user_tags, pull_request_tags
+			RESTART IDENTITY CASCADE
+		`); err != nil {
+			t.Fatalf("truncate: %v", err)
+		}
+		return repositorytest.Backend{
+			Teams:      NewTeamRepository(db),
+			Users:      NewUserRepository(db),
+			PRs:        NewPullRequestRepository(db),
+			CodeOwners: NewCodeOwnerRepository(db),
+			Stats:      NewStatsRepository(db),
+			UoW:        NewUnitOfWork(db),
+		}
+	})
+}
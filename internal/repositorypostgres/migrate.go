@@ -2,47 +2,398 @@ package repositorypostgres
 
 import (
 	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
 	"fmt"
-	"os"
-	"path/filepath"
+	"io/fs"
+	"log/slog"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"pr-reviewer/internal/logging"
 )
 
-func ApplyMigrations(ctx context.Context, db *DB, migrationsDir string, logger logging.Logger) error {
-	if logger == nil {
-		logger = logging.StdLogger{}
-	}
+// migrationLockID is the pg_advisory_lock key held while migrating, so that
+// replicas starting at the same time apply each migration once.
+const migrationLockID int64 = 0x70725f6d6967 // "pr_mig"
+
+const (
+	upSuffix   = ".up.sql"
+	downSuffix = ".down.sql"
+)
+
+// Migration is a NNNN_name.up.sql file and its optional NNNN_name.down.sql
+// pair. Checksum covers the up script only.
+type Migration struct {
+	Version  int64
+	Name     string
+	Checksum string
+	UpSQL    string
+	DownSQL  string
+}
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
 
-	entries, err := os.ReadDir(migrationsDir)
+// LoadMigrations reads migrations from the root of fsys, ordered by version.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(fsys, "*.sql")
 	if err != nil {
-		return fmt.Errorf("read migrations dir: %w", err)
+		return nil, fmt.Errorf("read migrations: %w", err)
 	}
+	sort.Strings(names)
 
-	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	byVersion := make(map[int64]*Migration, len(names))
+	downs := make(map[int64]string)
+	for _, name := range names {
+		var (
+			base string
+			down bool
+		)
+		switch {
+		case strings.HasSuffix(name, downSuffix):
+			base, down = strings.TrimSuffix(name, downSuffix), true
+		case strings.HasSuffix(name, upSuffix):
+			base = strings.TrimSuffix(name, upSuffix)
+		default:
+			return nil, fmt.Errorf("migration %s: name must end with %s or %s", name, upSuffix, downSuffix)
+		}
 
-	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
+		prefix, _, ok := strings.Cut(base, "_")
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: name must look like 0001_description%s", name, upSuffix)
 		}
-		if filepath.Ext(entry.Name()) != ".sql" {
+
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", name, err)
+		}
+
+		if down {
+			if other, ok := downs[version]; ok {
+				return nil, fmt.Errorf("migrations %s and %s have the same version %d", other, name, version)
+			}
+			downs[version] = name
 			continue
 		}
 
-		path := filepath.Join(migrationsDir, entry.Name())
-		query, err := os.ReadFile(path)
+		if other, ok := byVersion[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s have the same version %d", other.Name, name, version)
+		}
+		sum := sha256.Sum256(content)
+		byVersion[version] = &Migration{
+			Version:  version,
+			Name:     name,
+			Checksum: hex.EncodeToString(sum[:]),
+			UpSQL:    string(content),
+		}
+	}
+
+	for version, name := range downs {
+		m, ok := byVersion[version]
+		if !ok {
+			return nil, fmt.Errorf("migration %s has no up script", name)
+		}
+		content, err := fs.ReadFile(fsys, name)
 		if err != nil {
-			return fmt.Errorf("read migration %s: %w", entry.Name(), err)
+			return nil, fmt.Errorf("read migration %s: %w", name, err)
 		}
+		m.DownSQL = string(content)
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrator applies and rolls back migrations. Every operation holds the
+// advisory lock and first checks that applied migrations were not edited and
+// are known to this build.
+type Migrator struct {
+	db         *DB
+	migrations []Migration
+	logger     logging.Logger
+}
 
-		logger.Info("Applying migration %s", entry.Name())
-		if _, err := db.SQL.ExecContext(ctx, string(query)); err != nil {
-			logger.Error("Failed migration %s: %v", entry.Name(), err)
-			return fmt.Errorf("apply migration %s: %w", entry.Name(), err)
+func NewMigrator(db *DB, fsys fs.FS, logger logging.Logger) (*Migrator, error) {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations, logger: logger}, nil
+}
+
+// ApplyMigrations applies every pending migration from fsys.
+func ApplyMigrations(ctx context.Context, db *DB, fsys fs.FS, logger logging.Logger) error {
+	m, err := NewMigrator(db, fsys, logger)
+	if err != nil {
+		return err
+	}
+	return m.Up(ctx)
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	var latest int64
+	if len(m.migrations) > 0 {
+		latest = m.migrations[len(m.migrations)-1].Version
+	}
+	return m.To(ctx, latest)
+}
+
+// Down rolls back the latest applied migration.
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *sql.Conn, applied map[int64]appliedMigration) error {
+		var versions []int64
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; ok {
+				versions = append(versions, mig.Version)
+			}
+		}
+		if len(versions) == 0 {
+			m.logger.Info("No applied migrations to roll back")
+			return nil
+		}
+
+		var target int64
+		if len(versions) > 1 {
+			target = versions[len(versions)-2]
+		}
+		return m.migrate(ctx, conn, applied, target)
+	})
+}
+
+// To applies or rolls back migrations until exactly the migrations up to
+// version are applied. Version 0 rolls back everything.
+func (m *Migrator) To(ctx context.Context, version int64) error {
+	return m.withLock(ctx, func(conn *sql.Conn, applied map[int64]appliedMigration) error {
+		return m.migrate(ctx, conn, applied, version)
+	})
+}
+
+// Status lists every known migration with the time it was applied, if it
+// was.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withLock(ctx, func(_ *sql.Conn, applied map[int64]appliedMigration) error {
+		for _, mig := range m.migrations {
+			s := MigrationStatus{Version: mig.Version, Name: mig.Name}
+			if a, ok := applied[mig.Version]; ok {
+				appliedAt := a.appliedAt
+				s.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, s)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// Pending lists the migrations of this build that are not applied. Unlike
+// the other operations it takes no lock and does not verify checksums, so
+// that it is cheap enough for a readiness probe and an older build keeps
+// reporting ready after a newer one has migrated.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	conn, err := m.db.SQL.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	applied, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, mig := range m.migrations {
+		if _, ok := applied[mig.Version]; !ok {
+			pending = append(pending, mig)
 		}
-		logger.Info("Applied migration %s", entry.Name())
 	}
+	return pending, nil
+}
 
+func (m *Migrator) migrate(ctx context.Context, conn *sql.Conn, applied map[int64]appliedMigration, target int64) error {
+	up, down, err := planMigrations(m.migrations, applied, target)
+	if err != nil {
+		return err
+	}
+
+	for _, mig := range down {
+		m.logger.Info("Rolling back migration", "migration", mig.Name)
+		if err := runMigration(ctx, conn, mig.DownSQL, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version); err != nil {
+			m.logger.Error("Failed to roll back migration", "migration", mig.Name, "error", err)
+			return fmt.Errorf("roll back migration %s: %w", mig.Name, err)
+		}
+		m.logger.Info("Rolled back migration", "migration", mig.Name)
+	}
+	for _, mig := range up {
+		m.logger.Info("Applying migration", "migration", mig.Name)
+		if err := runMigration(ctx, conn, mig.UpSQL, `
+			INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)
+		`, mig.Version, mig.Name, mig.Checksum); err != nil {
+			m.logger.Error("Failed migration", "migration", mig.Name, "error", err)
+			return fmt.Errorf("apply migration %s: %w", mig.Name, err)
+		}
+		m.logger.Info("Applied migration", "migration", mig.Name)
+	}
+	return nil
+}
+
+// planMigrations returns the migrations to apply, in order, and the ones to
+// roll back, newest first, to reach target.
+func planMigrations(migrations []Migration, applied map[int64]appliedMigration, target int64) (up, down []Migration, err error) {
+	if target != 0 && !hasVersion(migrations, target) {
+		return nil, nil, fmt.Errorf("unknown migration version %d", target)
+	}
+
+	for _, mig := range migrations {
+		_, ok := applied[mig.Version]
+		switch {
+		case !ok && mig.Version <= target:
+			up = append(up, mig)
+		case ok && mig.Version > target:
+			if mig.DownSQL == "" {
+				return nil, nil, fmt.Errorf("migration %s has no down script", mig.Name)
+			}
+			down = append([]Migration{mig}, down...)
+		}
+	}
+	return up, down, nil
+}
+
+func hasVersion(migrations []Migration, version int64) bool {
+	for _, mig := range migrations {
+		if mig.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn, applied map[int64]appliedMigration) error) error {
+	conn, err := m.db.SQL.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	// The advisory lock belongs to the session, so everything below must run
+	// on this connection.
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, migrationLockID); err != nil {
+			m.logger.Error("Failed to release migration lock", "error", err)
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+		    version BIGINT PRIMARY KEY,
+		    name TEXT NOT NULL,
+		    checksum TEXT NOT NULL,
+		    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)
+	`); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	applied, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if err := verifyApplied(m.migrations, applied); err != nil {
+		return err
+	}
+	return fn(conn, applied)
+}
+
+type appliedMigration struct {
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+func appliedMigrations(ctx context.Context, conn *sql.Conn) (map[int64]appliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, name, checksum, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("read schema_migrations: %w", err)
+	}
+	defer closeRows(rows)
+
+	applied := make(map[int64]appliedMigration)
+	for rows.Next() {
+		var (
+			version int64
+			m       appliedMigration
+		)
+		if err := rows.Scan(&version, &m.name, &m.checksum, &m.appliedAt); err != nil {
+			return nil, fmt.Errorf("read schema_migrations: %w", err)
+		}
+		applied[version] = m
+	}
+	return applied, rows.Err()
+}
+
+func verifyApplied(migrations []Migration, applied map[int64]appliedMigration) error {
+	known := make(map[int64]Migration, len(migrations))
+	for _, m := range migrations {
+		known[m.Version] = m
+	}
+
+	versions := make([]int64, 0, len(applied))
+	for v := range applied {
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+
+	for _, v := range versions {
+		a := applied[v]
+		m, ok := known[v]
+		if !ok {
+			return fmt.Errorf("applied migration %s is unknown to this build", a.name)
+		}
+		if m.Checksum != a.checksum {
+			return fmt.Errorf("migration %s was edited after it was applied (checksum %s, applied %s)", m.Name, m.Checksum, a.checksum)
+		}
+	}
 	return nil
 }
+
+// runMigration runs script and the schema_migrations bookkeeping statement in
+// one transaction.
+func runMigration(ctx context.Context, conn *sql.Conn, script, record string, args ...any) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Migrations may legitimately run longer than db.statement_timeout.
+	if _, err := tx.ExecContext(ctx, "SET LOCAL statement_timeout = 0"); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
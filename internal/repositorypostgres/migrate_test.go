@@ -0,0 +1,248 @@
+package repositorypostgres
+
+import (
+	"context"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"pr-reviewer/internal/config"
+	"pr-reviewer/migrations"
+)
+
+func TestLoadMigrations_Embedded(t *testing.T) {
+	loaded, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(loaded) == 0 {
+		t.Fatalf("expected embedded migrations")
+	}
+	for i, m := range loaded {
+		if m.Version != int64(i+1) {
+			t.Fatalf("expected consecutive versions, got %d at position %d (%s)", m.Version, i, m.Name)
+		}
+		if strings.Contains(m.UpSQL, "CREATE VIEW IF NOT EXISTS") {
+			t.Fatalf("%s: Postgres does not support CREATE VIEW IF NOT EXISTS", m.Name)
+		}
+		if m.DownSQL == "" {
+			t.Fatalf("%s: missing down script", m.Name)
+		}
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	loaded, err := LoadMigrations(fstest.MapFS{
+		"0010_b.up.sql":   {Data: []byte("SELECT 2;")},
+		"0002_a.up.sql":   {Data: []byte("SELECT 1;")},
+		"0002_a.down.sql": {Data: []byte("SELECT -1;")},
+		"README.md":       {Data: []byte("ignored")},
+	})
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(loaded) != 2 || loaded[0].Name != "0002_a.up.sql" || loaded[1].Version != 10 {
+		t.Fatalf("unexpected migrations: %+v", loaded)
+	}
+	if loaded[0].Checksum == loaded[1].Checksum || len(loaded[0].Checksum) != 64 {
+		t.Fatalf("unexpected checksums: %q, %q", loaded[0].Checksum, loaded[1].Checksum)
+	}
+	if loaded[0].DownSQL != "SELECT -1;" || loaded[1].DownSQL != "" {
+		t.Fatalf("unexpected down scripts: %q, %q", loaded[0].DownSQL, loaded[1].DownSQL)
+	}
+
+	bad := []fstest.MapFS{
+		{"0001_init.sql": {}},
+		{"init.up.sql": {}},
+		{"0000_zero.up.sql": {}},
+		{"0001_a.up.sql": {}, "01_b.up.sql": {}},
+		{"0001_a.up.sql": {}, "0002_b.down.sql": {}},
+	}
+	for _, fsys := range bad {
+		if _, err := LoadMigrations(fsys); err == nil {
+			t.Fatalf("expected an error for %v", fsys)
+		}
+	}
+}
+
+func TestPlanMigrations(t *testing.T) {
+	known := []Migration{
+		{Version: 1, Name: "0001_a.up.sql", DownSQL: "-"},
+		{Version: 2, Name: "0002_b.up.sql", DownSQL: "-"},
+		{Version: 3, Name: "0003_c.up.sql"},
+		{Version: 4, Name: "0004_d.up.sql", DownSQL: "-"},
+	}
+	versions := func(ms []Migration) []int64 {
+		var vs []int64
+		for _, m := range ms {
+			vs = append(vs, m.Version)
+		}
+		return vs
+	}
+
+	tests := []struct {
+		name     string
+		applied  []int64
+		target   int64
+		wantUp   []int64
+		wantDown []int64
+		wantErr  string
+	}{
+		{name: "up from scratch", target: 4, wantUp: []int64{1, 2, 3, 4}},
+		{name: "up fills gaps", applied: []int64{1, 3}, target: 4, wantUp: []int64{2, 4}},
+		{name: "down newest first", applied: []int64{1, 2}, target: 0, wantDown: []int64{2, 1}},
+		{name: "to the middle", applied: []int64{1}, target: 2, wantUp: []int64{2}},
+		{name: "nothing to do", applied: []int64{1, 2}, target: 2},
+		{name: "no down script", applied: []int64{1, 2, 3, 4}, target: 2, wantErr: "no down script"},
+		{name: "unknown target", target: 7, wantErr: "unknown migration version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applied := make(map[int64]appliedMigration)
+			for _, v := range tt.applied {
+				applied[v] = appliedMigration{}
+			}
+			up, down, err := planMigrations(known, applied, tt.target)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("plan: %v", err)
+			}
+			if !slices.Equal(versions(up), tt.wantUp) || !slices.Equal(versions(down), tt.wantDown) {
+				t.Fatalf("expected up %v down %v, got up %v down %v", tt.wantUp, tt.wantDown, versions(up), versions(down))
+			}
+		})
+	}
+}
+
+func TestVerifyApplied(t *testing.T) {
+	known := []Migration{{Version: 1, Name: "0001_a.sql", Checksum: "aa"}, {Version: 2, Name: "0002_b.sql", Checksum: "bb"}}
+
+	if err := verifyApplied(known, map[int64]appliedMigration{1: {name: "0001_a.sql", checksum: "aa"}}); err != nil {
+		t.Fatalf("expected pending migrations to be fine, got %v", err)
+	}
+	err := verifyApplied(known, map[int64]appliedMigration{2: {name: "0002_b.sql", checksum: "cc"}})
+	if err == nil || !strings.Contains(err.Error(), "edited") {
+		t.Fatalf("expected edited migration error, got %v", err)
+	}
+	err = verifyApplied(known, map[int64]appliedMigration{3: {name: "0003_c.sql", checksum: "dd"}})
+	if err == nil || !strings.Contains(err.Error(), "unknown") {
+		t.Fatalf("expected unknown migration error, got %v", err)
+	}
+}
+
+// TestApplyMigrations drops the public schema. Set TEST_DB_DSN to a
+// disposable database to run it.
+func TestApplyMigrations(t *testing.T) {
+	dsn := os.Getenv("TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DB_DSN is not set")
+	}
+
+	ctx := context.Background()
+	db, err := NewDB(ctx, &config.Config{DB: config.DBConfig{DSN: dsn}})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if _, err := db.SQL.ExecContext(ctx, `DROP SCHEMA public CASCADE; CREATE SCHEMA public`); err != nil {
+		t.Fatalf("reset schema: %v", err)
+	}
+
+	// Replicas starting together apply every migration exactly once.
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = ApplyMigrations(ctx, db, migrations.FS, nil)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("concurrent apply: %v", err)
+		}
+	}
+
+	loaded, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	var count int
+	if err := db.SQL.QueryRowContext(ctx, `SELECT COUNT(*) FROM schema_migrations`).Scan(&count); err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if count != len(loaded) {
+		t.Fatalf("expected %d applied migrations, got %d", len(loaded), count)
+	}
+
+	edited := fstest.MapFS{}
+	for _, m := range loaded {
+		edited[m.Name] = &fstest.MapFile{Data: []byte(m.UpSQL)}
+	}
+	edited[loaded[0].Name] = &fstest.MapFile{Data: []byte(loaded[0].UpSQL + "\n-- edited\n")}
+	if err := ApplyMigrations(ctx, db, edited, nil); err == nil || !strings.Contains(err.Error(), "edited") {
+		t.Fatalf("expected edited migration to be rejected, got %v", err)
+	}
+
+	// A failing migration leaves no trace.
+	edited[loaded[0].Name] = &fstest.MapFile{Data: []byte(loaded[0].UpSQL)}
+	edited["9999_broken.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE broken_probe (id INT); SELECT no_such_column FROM teams;")}
+	if err := ApplyMigrations(ctx, db, edited, nil); err == nil {
+		t.Fatalf("expected broken migration to fail")
+	}
+	var exists bool
+	if err := db.SQL.QueryRowContext(ctx, `SELECT to_regclass('broken_probe') IS NOT NULL`).Scan(&exists); err != nil {
+		t.Fatalf("probe: %v", err)
+	}
+	if exists {
+		t.Fatalf("broken migration was partially applied")
+	}
+
+	// Every down script undoes its up script, all the way back and forth.
+	migrator, err := NewMigrator(db, migrations.FS, nil)
+	if err != nil {
+		t.Fatalf("migrator: %v", err)
+	}
+	if err := migrator.Down(ctx); err != nil {
+		t.Fatalf("down: %v", err)
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if last := statuses[len(statuses)-1]; last.AppliedAt != nil || statuses[len(statuses)-2].AppliedAt == nil {
+		t.Fatalf("expected only the latest migration to be rolled back, got %+v", statuses)
+	}
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		t.Fatalf("pending: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Version != loaded[len(loaded)-1].Version {
+		t.Fatalf("expected the latest migration to be pending, got %+v", pending)
+	}
+	if err := migrator.To(ctx, 0); err != nil {
+		t.Fatalf("down to 0: %v", err)
+	}
+	if err := db.SQL.QueryRowContext(ctx, `SELECT to_regclass('teams') IS NOT NULL`).Scan(&exists); err != nil {
+		t.Fatalf("probe: %v", err)
+	}
+	if exists {
+		t.Fatalf("expected every table to be dropped")
+	}
+	if err := migrator.Up(ctx); err != nil {
+		t.Fatalf("up again: %v", err)
+	}
+	if pending, err := migrator.Pending(ctx); err != nil || len(pending) != 0 {
+		t.Fatalf("expected no pending migrations, got %+v, %v", pending, err)
+	}
+}
@@ -15,41 +15,67 @@ type prRepo struct {
 }
 
 func NewPullRequestRepository(db *DB) repository.PullRequestRepository {
-	return &prRepo{exec: db.SQL}
+	return &prRepo{exec: traced(db.SQL)}
 }
 
 func (r *prRepo) CreatePullRequest(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error) {
 	row := r.exec.QueryRowContext(ctx, `
-		INSERT INTO pull_requests (id, name, author_id, status, created_at, merged_at)
-		VALUES ($1, $2, $3, $4, COALESCE($5, NOW()), $6)
-		RETURNING id, name, author_id, status, created_at, merged_at
-	`, pr.ID, pr.Name, pr.AuthorID, pr.Status, timeOrNil(pr.CreatedAt), pr.MergedAt)
+		INSERT INTO pull_requests (id, name, author_id, status, created_at, merged_at, understaffed)
+		VALUES ($1, $2, $3, $4, COALESCE($5, NOW()), $6, $7)
+		ON CONFLICT (id) DO NOTHING
+		RETURNING id, name, author_id, status, created_at, merged_at, understaffed, version
+	`, pr.ID, pr.Name, pr.AuthorID, pr.Status, timeOrNil(pr.CreatedAt), pr.MergedAt, pr.Understaffed)
 
+	// A concurrent create of the same ID waits for the other transaction and
+	// then inserts nothing.
 	created, err := scanPullRequest(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodePRExists, "pull request already exists")
+	}
 	if err != nil {
 		return domain.PullRequest{}, err
 	}
 
 	for _, reviewerID := range pr.AssignedReviewers {
+		if err := r.insertReviewer(ctx, created.ID, reviewerID, pr.CrossTeamReviewers[reviewerID]); err != nil {
+			return domain.PullRequest{}, err
+		}
+	}
+
+	if len(pr.Tags) > 0 {
 		if _, err := r.exec.ExecContext(ctx, `
-			INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id)
-			VALUES ($1, $2)
+			INSERT INTO pull_request_tags (pull_request_id, tag)
+			SELECT $1, tag FROM unnest($2::text[]) AS tag
 			ON CONFLICT DO NOTHING
-		`, created.ID, reviewerID); err != nil {
+		`, created.ID, pr.Tags); err != nil {
 			return domain.PullRequest{}, err
 		}
 	}
 
+	if err := r.loadRelations(ctx, &created); err != nil {
+		return domain.PullRequest{}, err
+	}
+	// Keep the selection order rather than the stored one.
 	created.AssignedReviewers = pr.AssignedReviewers
 	return created, nil
 }
 
 func (r *prRepo) GetPullRequestByID(ctx context.Context, prID string) (domain.PullRequest, error) {
+	return r.getPullRequest(ctx, prID, "")
+}
+
+// getPullRequestForUpdate locks the pull request row, so concurrent
+// reassignments of the same pull request run one after another.
+func (r *prRepo) getPullRequestForUpdate(ctx context.Context, prID string) (domain.PullRequest, error) {
+	return r.getPullRequest(ctx, prID, "FOR UPDATE")
+}
+
+func (r *prRepo) getPullRequest(ctx context.Context, prID, lock string) (domain.PullRequest, error) {
 	row := r.exec.QueryRowContext(ctx, `
-		SELECT id, name, author_id, status, created_at, merged_at
+		SELECT id, name, author_id, status, created_at, merged_at, understaffed, version
 		FROM pull_requests
 		WHERE id = $1
-	`, prID)
+	`+lock, prID)
 
 	pr, err := scanPullRequest(row)
 	if err != nil {
@@ -59,11 +85,9 @@ func (r *prRepo) GetPullRequestByID(ctx context.Context, prID string) (domain.Pu
 		return domain.PullRequest{}, err
 	}
 
-	reviewers, err := r.listReviewers(ctx, prID)
-	if err != nil {
+	if err := r.loadRelations(ctx, &pr); err != nil {
 		return domain.PullRequest{}, err
 	}
-	pr.AssignedReviewers = reviewers
 	return pr, nil
 }
 
@@ -71,9 +95,10 @@ func (r *prRepo) MergePullRequest(ctx context.Context, prID string, mergedAt tim
 	row := r.exec.QueryRowContext(ctx, `
 		UPDATE pull_requests
 		SET status = $2,
-		    merged_at = $3
+		    merged_at = $3,
+		    version = version + 1
 		WHERE id = $1
-		RETURNING id, name, author_id, status, created_at, merged_at
+		RETURNING id, name, author_id, status, created_at, merged_at, understaffed, version
 	`, prID, domain.PullRequestStatusMerged, mergedAt)
 
 	pr, err := scanPullRequest(row)
@@ -84,20 +109,19 @@ func (r *prRepo) MergePullRequest(ctx context.Context, prID string, mergedAt tim
 		return domain.PullRequest{}, err
 	}
 
-	reviewers, err := r.listReviewers(ctx, prID)
-	if err != nil {
+	if err := r.loadRelations(ctx, &pr); err != nil {
 		return domain.PullRequest{}, err
 	}
-	pr.AssignedReviewers = reviewers
 	return pr, nil
 }
 
 func (r *prRepo) UpdateStatus(ctx context.Context, prID string, status domain.PullRequestStatus) (domain.PullRequest, error) {
 	row := r.exec.QueryRowContext(ctx, `
 		UPDATE pull_requests
-		SET status = $2
+		SET status = $2,
+		    version = version + 1
 		WHERE id = $1
-		RETURNING id, name, author_id, status, created_at, merged_at
+		RETURNING id, name, author_id, status, created_at, merged_at, understaffed, version
 	`, prID, status)
 
 	pr, err := scanPullRequest(row)
@@ -107,15 +131,13 @@ func (r *prRepo) UpdateStatus(ctx context.Context, prID string, status domain.Pu
 		}
 		return domain.PullRequest{}, err
 	}
-	reviewers, err := r.listReviewers(ctx, prID)
-	if err != nil {
+	if err := r.loadRelations(ctx, &pr); err != nil {
 		return domain.PullRequest{}, err
 	}
-	pr.AssignedReviewers = reviewers
 	return pr, nil
 }
 
-func (r *prRepo) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) (domain.PullRequest, error) {
+func (r *prRepo) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID, sourceTeam string) (domain.PullRequest, error) {
 	if _, err := r.exec.ExecContext(ctx, `
 		DELETE FROM pull_request_reviewers
 		WHERE pull_request_id = $1 AND reviewer_id = $2
@@ -123,11 +145,13 @@ func (r *prRepo) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newR
 		return domain.PullRequest{}, err
 	}
 
+	if err := r.insertReviewer(ctx, prID, newReviewerID, sourceTeam); err != nil {
+		return domain.PullRequest{}, err
+	}
+
 	if _, err := r.exec.ExecContext(ctx, `
-		INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id)
-		VALUES ($1, $2)
-		ON CONFLICT DO NOTHING
-	`, prID, newReviewerID); err != nil {
+		UPDATE pull_requests SET version = version + 1 WHERE id = $1
+	`, prID); err != nil {
 		return domain.PullRequest{}, err
 	}
 
@@ -136,7 +160,7 @@ func (r *prRepo) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newR
 
 func (r *prRepo) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error) {
 	rows, err := r.exec.QueryContext(ctx, `
-		SELECT pr.id, pr.name, pr.author_id, pr.status
+		SELECT pr.id, pr.name, pr.author_id, pr.status, pr.version
 		FROM pull_requests pr
 		JOIN pull_request_reviewers r ON r.pull_request_id = pr.id
 		WHERE r.reviewer_id = $1
@@ -150,7 +174,7 @@ func (r *prRepo) ListByReviewer(ctx context.Context, reviewerID string) ([]domai
 	var result []domain.PullRequestShort
 	for rows.Next() {
 		var pr domain.PullRequestShort
-		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status); err != nil {
+		if err := rows.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.Version); err != nil {
 			return nil, err
 		}
 		result = append(result, pr)
@@ -158,32 +182,127 @@ func (r *prRepo) ListByReviewer(ctx context.Context, reviewerID string) ([]domai
 	return result, nil
 }
 
-func (r *prRepo) listReviewers(ctx context.Context, prID string) ([]string, error) {
+func (r *prRepo) CountRecentPairings(ctx context.Context, authorID string, reviewerIDs []string, since time.Time) (map[string]int, error) {
+	counts := make(map[string]int, len(reviewerIDs))
+	if len(reviewerIDs) == 0 {
+		return counts, nil
+	}
+
 	rows, err := r.exec.QueryContext(ctx, `
-		SELECT reviewer_id
-		FROM pull_request_reviewers
+		SELECT r.reviewer_id, COUNT(*)
+		FROM pull_request_reviewers r
+		JOIN pull_requests pr ON pr.id = r.pull_request_id
+		WHERE pr.author_id = $1 AND pr.created_at >= $2 AND r.reviewer_id = ANY($3)
+		GROUP BY r.reviewer_id
+	`, authorID, since, reviewerIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(rows)
+
+	for rows.Next() {
+		var (
+			reviewerID string
+			count      int
+		)
+		if err := rows.Scan(&reviewerID, &count); err != nil {
+			return nil, err
+		}
+		counts[reviewerID] = count
+	}
+	return counts, rows.Err()
+}
+
+// insertReviewer records sourceTeam, the fallback team the reviewer was
+// borrowed from, or NULL when it is empty.
+func (r *prRepo) insertReviewer(ctx context.Context, prID, reviewerID, sourceTeam string) error {
+	_, err := r.exec.ExecContext(ctx, `
+		INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id, source_team)
+		SELECT pr.id, rv.id, NULLIF($3, '')
+		FROM pull_requests pr
+		JOIN users rv ON rv.id = $2
+		WHERE pr.id = $1
+		ON CONFLICT DO NOTHING
+	`, prID, reviewerID, sourceTeam)
+	return err
+}
+
+func (r *prRepo) loadRelations(ctx context.Context, pr *domain.PullRequest) error {
+	reviewers, crossTeam, err := r.listReviewers(ctx, pr.ID)
+	if err != nil {
+		return err
+	}
+	tags, err := r.listTags(ctx, pr.ID)
+	if err != nil {
+		return err
+	}
+	pr.AssignedReviewers = reviewers
+	pr.CrossTeamReviewers = crossTeam
+	pr.Tags = tags
+	return nil
+}
+
+func (r *prRepo) listTags(ctx context.Context, prID string) ([]string, error) {
+	rows, err := r.exec.QueryContext(ctx, `
+		SELECT tag
+		FROM pull_request_tags
 		WHERE pull_request_id = $1
-		ORDER BY reviewer_id
+		ORDER BY tag
 	`, prID)
 	if err != nil {
 		return nil, err
 	}
 	defer closeRows(rows)
 
-	var reviewers []string
+	var tags []string
 	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
 			return nil, err
 		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+func (r *prRepo) listReviewers(ctx context.Context, prID string) ([]string, map[string]string, error) {
+	rows, err := r.exec.QueryContext(ctx, `
+		SELECT reviewer_id, source_team
+		FROM pull_request_reviewers
+		WHERE pull_request_id = $1
+		ORDER BY reviewer_id
+	`, prID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closeRows(rows)
+
+	var (
+		reviewers []string
+		crossTeam map[string]string
+	)
+	for rows.Next() {
+		var (
+			id         string
+			sourceTeam sql.NullString
+		)
+		if err := rows.Scan(&id, &sourceTeam); err != nil {
+			return nil, nil, err
+		}
 		reviewers = append(reviewers, id)
+		if sourceTeam.Valid {
+			if crossTeam == nil {
+				crossTeam = make(map[string]string)
+			}
+			crossTeam[id] = sourceTeam.String
+		}
 	}
-	return reviewers, nil
+	return reviewers, crossTeam, nil
 }
 
 func scanPullRequest(row *sql.Row) (domain.PullRequest, error) {
 	var pr domain.PullRequest
-	if err := row.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt); err != nil {
+	if err := row.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt, &pr.Understaffed, &pr.Version); err != nil {
 		return domain.PullRequest{}, err
 	}
 	return pr, nil
@@ -3,6 +3,8 @@ package repositorypostgres
 import (
 	"context"
 	"database/sql"
+
+	"pr-reviewer/internal/domain"
 )
 
 type executor interface {
@@ -11,8 +13,65 @@ type executor interface {
 	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
 
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// inTx runs fn in a transaction of its own, unless exec already belongs to
+// one, so that repository methods issuing several statements stay atomic
+// outside of a unit of work too.
+func inTx(ctx context.Context, exec executor, fn func(exec executor) error) error {
+	if t, ok := exec.(tracedExecutor); ok {
+		exec = t.exec
+	}
+	db, ok := exec.(*sql.DB)
+	if !ok {
+		return fn(traced(exec))
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(traced(tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
 func closeRows(rows *sql.Rows) {
 	if rows != nil {
 		_ = rows.Close()
 	}
 }
+
+func scanUser(row rowScanner) (domain.User, error) {
+	var (
+		u          domain.User
+		start, end sql.NullInt32
+	)
+	if err := row.Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive, &u.Timezone, &start, &end, &u.ReviewCapacity, &u.Seniority); err != nil {
+		return domain.User{}, err
+	}
+	if start.Valid && end.Valid {
+		u.WorkingHours = &domain.WorkingHours{StartMinute: int(start.Int32), EndMinute: int(end.Int32)}
+	}
+	return u, nil
+}
+
+func workingHoursArgs(h *domain.WorkingHours) (any, any) {
+	if h == nil {
+		return nil, nil
+	}
+	return h.StartMinute, h.EndMinute
+}
+
+// nullIfEmpty maps an unset string setting to NULL so upserts keep the stored value.
+func nullIfEmpty[S ~string](s S) any {
+	if s == "" {
+		return nil
+	}
+	return string(s)
+}
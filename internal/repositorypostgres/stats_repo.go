@@ -0,0 +1,80 @@
+package repositorypostgres
+
+import (
+	"context"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/repository"
+)
+
+type statsRepo struct {
+	exec executor
+}
+
+func NewStatsRepository(db *DB) repository.StatsRepository {
+	return &statsRepo{exec: traced(db.SQL)}
+}
+
+func (r *statsRepo) OpenPullRequestStats(ctx context.Context) (domain.OpenPullRequestStats, error) {
+	var (
+		stats domain.OpenPullRequestStats
+		err   error
+	)
+	stats.ByTeam, err = countBy[string](ctx, r.exec, `
+		SELECT u.team_name, COUNT(*)
+		FROM pull_requests pr
+		JOIN users u ON u.id = pr.author_id
+		WHERE pr.status = $1
+		GROUP BY u.team_name
+	`, domain.PullRequestStatusOpen)
+	if err != nil {
+		return domain.OpenPullRequestStats{}, err
+	}
+	stats.ByReviewerCount, err = countBy[int](ctx, r.exec, `
+		SELECT reviewers, COUNT(*)
+		FROM (
+		    SELECT COUNT(r.reviewer_id) AS reviewers
+		    FROM pull_requests pr
+		    LEFT JOIN pull_request_reviewers r ON r.pull_request_id = pr.id
+		    WHERE pr.status = $1
+		    GROUP BY pr.id
+		) per_pr
+		GROUP BY reviewers
+	`, domain.PullRequestStatusOpen)
+	if err != nil {
+		return domain.OpenPullRequestStats{}, err
+	}
+	stats.ReviewsByUser, err = countBy[string](ctx, r.exec, `
+		SELECT r.reviewer_id, COUNT(*)
+		FROM pull_request_reviewers r
+		JOIN pull_requests pr ON pr.id = r.pull_request_id
+		WHERE pr.status = $1
+		GROUP BY r.reviewer_id
+	`, domain.PullRequestStatusOpen)
+	if err != nil {
+		return domain.OpenPullRequestStats{}, err
+	}
+	return stats, nil
+}
+
+// countBy reads the key and count rows of a GROUP BY query into a map.
+func countBy[K comparable](ctx context.Context, exec executor, query string, args ...any) (map[K]int, error) {
+	rows, err := exec.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(rows)
+
+	counts := make(map[K]int)
+	for rows.Next() {
+		var (
+			key   K
+			count int
+		)
+		if err := rows.Scan(&key, &count); err != nil {
+			return nil, err
+		}
+		counts[key] = count
+	}
+	return counts, rows.Err()
+}
@@ -14,49 +14,57 @@ type teamRepo struct {
 }
 
 func NewTeamRepository(db *DB) repository.TeamRepository {
-	return &teamRepo{exec: db.SQL}
+	return &teamRepo{exec: traced(db.SQL)}
 }
 
 func (r *teamRepo) UpsertTeam(ctx context.Context, team domain.Team) (domain.Team, error) {
 	if _, err := r.exec.ExecContext(ctx, `
-		INSERT INTO teams (name) VALUES ($1)
+		INSERT INTO teams (name, default_review_capacity, require_senior_reviewer) VALUES ($1, $2, $3)
 		ON CONFLICT (name) DO NOTHING
-	`, team.Name); err != nil {
+	`, team.Name, team.DefaultReviewCapacity, team.Policy.RequireSeniorReviewer); err != nil {
 		return domain.Team{}, err
 	}
 
 	var members []domain.User
 	for _, m := range team.Members {
+		start, end := workingHoursArgs(m.WorkingHours)
 		row := r.exec.QueryRowContext(ctx, `
-			INSERT INTO users (id, username, team_name, is_active)
-			VALUES ($1, $2, $3, $4)
+			INSERT INTO users (id, username, team_name, is_active, timezone, work_start_minute, work_end_minute, review_capacity, seniority)
+			VALUES ($1, $2, $3, $4, COALESCE($5, ''), $6, $7, $8, COALESCE($9, 'middle'))
 			ON CONFLICT (id) DO UPDATE
 			SET username = EXCLUDED.username,
 			    team_name = EXCLUDED.team_name,
-			    is_active = EXCLUDED.is_active
-			RETURNING id, username, team_name, is_active
-		`, m.ID, m.Username, team.Name, m.IsActive)
+			    is_active = EXCLUDED.is_active,
+			    timezone = COALESCE($5, users.timezone),
+			    work_start_minute = COALESCE(EXCLUDED.work_start_minute, users.work_start_minute),
+			    work_end_minute = COALESCE(EXCLUDED.work_end_minute, users.work_end_minute),
+			    review_capacity = COALESCE(EXCLUDED.review_capacity, users.review_capacity),
+			    seniority = COALESCE($9, users.seniority)
+			RETURNING id, username, team_name, is_active, timezone, work_start_minute, work_end_minute, review_capacity, seniority
+		`, m.ID, m.Username, team.Name, m.IsActive, nullIfEmpty(m.Timezone), start, end, m.ReviewCapacity, nullIfEmpty(m.Seniority))
 
-		var member domain.User
-		if err := row.Scan(&member.ID, &member.Username, &member.TeamName, &member.IsActive); err != nil {
+		member, err := scanUser(row)
+		if err != nil {
 			return domain.Team{}, err
 		}
 		members = append(members, member)
 	}
 
 	return domain.Team{
-		Name:    team.Name,
-		Members: members,
+		Name:                  team.Name,
+		Members:               members,
+		DefaultReviewCapacity: team.DefaultReviewCapacity,
+		Policy:                team.Policy,
 	}, nil
 }
 
 func (r *teamRepo) GetTeamByName(ctx context.Context, teamName string) (domain.Team, error) {
 	row := r.exec.QueryRowContext(ctx, `
-		SELECT name FROM teams WHERE name = $1
+		SELECT name, default_review_capacity, require_senior_reviewer FROM teams WHERE name = $1
 	`, teamName)
 
 	var team domain.Team
-	if err := row.Scan(&team.Name); err != nil {
+	if err := row.Scan(&team.Name, &team.DefaultReviewCapacity, &team.Policy.RequireSeniorReviewer); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.Team{}, domain.NewDomainError(domain.ErrorCodeNotFound, "team not found")
 		}
@@ -64,7 +72,7 @@ func (r *teamRepo) GetTeamByName(ctx context.Context, teamName string) (domain.T
 	}
 
 	rows, err := r.exec.QueryContext(ctx, `
-		SELECT id, username, team_name, is_active
+		SELECT id, username, team_name, is_active, timezone, work_start_minute, work_end_minute, review_capacity, seniority
 		FROM users
 		WHERE team_name = $1
 		ORDER BY id
@@ -75,12 +83,112 @@ func (r *teamRepo) GetTeamByName(ctx context.Context, teamName string) (domain.T
 	defer closeRows(rows)
 
 	for rows.Next() {
-		var u domain.User
-		if err := rows.Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
+		u, err := scanUser(rows)
+		if err != nil {
 			return domain.Team{}, err
 		}
 		team.Members = append(team.Members, u)
 	}
 
+	fallbacks, err := r.ListFallbackTeams(ctx, teamName)
+	if err != nil {
+		return domain.Team{}, err
+	}
+	team.FallbackTeams = fallbacks
+
 	return team, nil
 }
+
+func (r *teamRepo) SetDefaultReviewCapacity(ctx context.Context, teamName string, capacity *int) (domain.Team, error) {
+	res, err := r.exec.ExecContext(ctx, `
+		UPDATE teams
+		SET default_review_capacity = $2
+		WHERE name = $1
+	`, teamName, capacity)
+	if err != nil {
+		return domain.Team{}, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return domain.Team{}, err
+	}
+	if affected == 0 {
+		return domain.Team{}, domain.NewDomainError(domain.ErrorCodeNotFound, "team not found")
+	}
+	return r.GetTeamByName(ctx, teamName)
+}
+
+func (r *teamRepo) GetTeamPolicy(ctx context.Context, teamName string) (domain.TeamPolicy, error) {
+	var policy domain.TeamPolicy
+	err := r.exec.QueryRowContext(ctx, `
+		SELECT require_senior_reviewer FROM teams WHERE name = $1
+	`, teamName).Scan(&policy.RequireSeniorReviewer)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.TeamPolicy{}, domain.NewDomainError(domain.ErrorCodeNotFound, "team not found")
+		}
+		return domain.TeamPolicy{}, err
+	}
+	return policy, nil
+}
+
+func (r *teamRepo) SetTeamPolicy(ctx context.Context, teamName string, policy domain.TeamPolicy) (domain.Team, error) {
+	res, err := r.exec.ExecContext(ctx, `
+		UPDATE teams
+		SET require_senior_reviewer = $2
+		WHERE name = $1
+	`, teamName, policy.RequireSeniorReviewer)
+	if err != nil {
+		return domain.Team{}, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return domain.Team{}, err
+	}
+	if affected == 0 {
+		return domain.Team{}, domain.NewDomainError(domain.ErrorCodeNotFound, "team not found")
+	}
+	return r.GetTeamByName(ctx, teamName)
+}
+
+func (r *teamRepo) SetFallbackTeams(ctx context.Context, teamName string, fallbacks []string) (domain.Team, error) {
+	if _, err := r.exec.ExecContext(ctx, `
+		DELETE FROM team_fallbacks WHERE team_name = $1
+	`, teamName); err != nil {
+		return domain.Team{}, err
+	}
+
+	for i, fallback := range fallbacks {
+		if _, err := r.exec.ExecContext(ctx, `
+			INSERT INTO team_fallbacks (team_name, fallback_team_name, priority)
+			VALUES ($1, $2, $3)
+		`, teamName, fallback, i); err != nil {
+			return domain.Team{}, err
+		}
+	}
+
+	return r.GetTeamByName(ctx, teamName)
+}
+
+func (r *teamRepo) ListFallbackTeams(ctx context.Context, teamName string) ([]string, error) {
+	rows, err := r.exec.QueryContext(ctx, `
+		SELECT fallback_team_name
+		FROM team_fallbacks
+		WHERE team_name = $1
+		ORDER BY priority, fallback_team_name
+	`, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(rows)
+
+	var fallbacks []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		fallbacks = append(fallbacks, name)
+	}
+	return fallbacks, rows.Err()
+}
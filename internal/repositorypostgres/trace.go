@@ -0,0 +1,64 @@
+package repositorypostgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"unicode"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"pr-reviewer/internal/tracing"
+)
+
+// tracedExecutor runs every statement in a client span named after its SQL
+// operation. Query spans end once the first rows are available, not when
+// they have all been read.
+type tracedExecutor struct {
+	exec executor
+}
+
+func traced(exec executor) executor {
+	return tracedExecutor{exec: exec}
+}
+
+func (e tracedExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := startStatement(ctx, query)
+	res, err := e.exec.ExecContext(ctx, query, args...)
+	tracing.End(span, err)
+	return res, err
+}
+
+func (e tracedExecutor) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := startStatement(ctx, query)
+	rows, err := e.exec.QueryContext(ctx, query, args...)
+	tracing.End(span, err)
+	return rows, err
+}
+
+func (e tracedExecutor) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := startStatement(ctx, query)
+	row := e.exec.QueryRowContext(ctx, query, args...)
+	tracing.End(span, row.Err())
+	return row
+}
+
+func startStatement(ctx context.Context, query string) (context.Context, trace.Span) {
+	query = strings.TrimSpace(query)
+	operation := sqlOperation(query)
+	return tracing.Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemNamePostgreSQL,
+		semconv.DBOperationName(operation),
+		semconv.DBQueryText(query),
+	))
+}
+
+// sqlOperation returns the statement's leading keyword, such as SELECT.
+func sqlOperation(query string) string {
+	end := strings.IndexFunc(query, unicode.IsSpace)
+	if end < 0 {
+		end = len(query)
+	}
+	return strings.ToUpper(query[:end])
+}
@@ -0,0 +1,71 @@
+package repositorypostgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"pr-reviewer/internal/tracing"
+	"pr-reviewer/internal/tracing/tracingtest"
+)
+
+type failingExecutor struct {
+	err error
+}
+
+func (e failingExecutor) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	return nil, e.err
+}
+
+func (e failingExecutor) QueryContext(context.Context, string, ...any) (*sql.Rows, error) {
+	return nil, e.err
+}
+
+func (e failingExecutor) QueryRowContext(context.Context, string, ...any) *sql.Row {
+	return nil
+}
+
+func TestTracedExecutor_StatementSpans(t *testing.T) {
+	spans := tracingtest.Record(t)
+	ctx, parent := tracing.Start(context.Background(), "PullRequestService.Reassign")
+	exec := traced(failingExecutor{err: errors.New("connection reset")})
+
+	query := "\n\t\tupdate pull_requests SET version = version + 1 WHERE id = $1\n\t"
+	if _, err := exec.ExecContext(ctx, query, "pr1"); err == nil {
+		t.Fatalf("expected the executor error to pass through")
+	}
+	if _, err := exec.QueryContext(ctx, "SELECT 1"); err == nil {
+		t.Fatalf("expected the executor error to pass through")
+	}
+	parent.End()
+
+	update := tracingtest.Find(t, spans, "UPDATE")
+	if update.SpanKind != trace.SpanKindClient || update.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Fatalf("expected a client span under the caller's span, got %+v", update)
+	}
+	if update.Status.Code != codes.Error {
+		t.Fatalf("expected a failed statement to mark the span, got %+v", update.Status)
+	}
+	want := map[string]string{
+		string(semconv.DBSystemNameKey):    "postgresql",
+		string(semconv.DBOperationNameKey): "UPDATE",
+		string(semconv.DBQueryTextKey):     "update pull_requests SET version = version + 1 WHERE id = $1",
+	}
+	for _, attr := range update.Attributes {
+		if v, ok := want[string(attr.Key)]; ok {
+			if attr.Value.AsString() != v {
+				t.Fatalf("%s: expected %q, got %q", attr.Key, v, attr.Value.AsString())
+			}
+			delete(want, string(attr.Key))
+		}
+	}
+	if len(want) > 0 {
+		t.Fatalf("missing attributes: %v", want)
+	}
+	tracingtest.Find(t, spans, "SELECT")
+}
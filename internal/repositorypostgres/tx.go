@@ -3,18 +3,32 @@ package repositorypostgres
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"math/rand/v2"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
+
 	"pr-reviewer/internal/domain"
 	"pr-reviewer/internal/repository"
 )
 
+// Transactions that lose a serialization or deadlock race are run again up
+// to txMaxAttempts times, sleeping a jittered, doubling backoff between
+// attempts.
+const (
+	txMaxAttempts = 4
+	txBaseBackoff = 10 * time.Millisecond
+	txMaxBackoff  = 200 * time.Millisecond
+)
+
 type unitOfWork struct {
-	db *DB
+	db    *DB
+	sleep func(ctx context.Context, d time.Duration) error
 }
 
 func NewUnitOfWork(db *DB) repository.UnitOfWork {
-	return &unitOfWork{db: db}
+	return &unitOfWork{db: db, sleep: sleepContext}
 }
 
 func (u *unitOfWork) Begin(ctx context.Context) (repository.Tx, error) {
@@ -25,19 +39,72 @@ func (u *unitOfWork) Begin(ctx context.Context) (repository.Tx, error) {
 	return newTx(tx), nil
 }
 
+func (u *unitOfWork) Do(ctx context.Context, fn func(tx repository.Tx) error) error {
+	return retryTx(ctx, u.sleep, func() error {
+		return repository.RunTx(ctx, u, fn)
+	})
+}
+
+func retryTx(ctx context.Context, sleep func(context.Context, time.Duration) error, run func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := run()
+		if err == nil || attempt == txMaxAttempts || !isRetryable(err) {
+			return err
+		}
+		if err := sleep(ctx, backoff(attempt)); err != nil {
+			return err
+		}
+	}
+}
+
+// isRetryable reports whether err is a serialization failure (40001) or a
+// deadlock (40P01), after which the whole transaction may succeed if run
+// again.
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == "40001" || pgErr.Code == "40P01"
+}
+
+// backoff returns a random delay up to txBaseBackoff*2^(attempt-1), capped
+// at txMaxBackoff.
+func backoff(attempt int) time.Duration {
+	limit := min(txBaseBackoff<<(attempt-1), txMaxBackoff)
+	return time.Duration(rand.Int64N(int64(limit))) + 1
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 type tx struct {
 	tx    *sql.Tx
 	teams *teamRepo
 	users *userRepo
 	prs   *prRepo
+	rules *codeOwnerRepo
 }
 
 func newTx(t *sql.Tx) *tx {
+	exec := traced(t)
 	return &tx{
 		tx:    t,
-		teams: &teamRepo{exec: t},
-		users: &userRepo{exec: t},
-		prs:   &prRepo{exec: t},
+		teams: &teamRepo{exec: exec},
+		users: &userRepo{exec: exec},
+		prs:   &prRepo{exec: exec},
+		rules: &codeOwnerRepo{exec: exec},
 	}
 }
 
@@ -53,6 +120,26 @@ func (t *tx) GetTeamByName(ctx context.Context, teamName string) (domain.Team, e
 	return t.teams.GetTeamByName(ctx, teamName)
 }
 
+func (t *tx) SetDefaultReviewCapacity(ctx context.Context, teamName string, capacity *int) (domain.Team, error) {
+	return t.teams.SetDefaultReviewCapacity(ctx, teamName, capacity)
+}
+
+func (t *tx) SetFallbackTeams(ctx context.Context, teamName string, fallbacks []string) (domain.Team, error) {
+	return t.teams.SetFallbackTeams(ctx, teamName, fallbacks)
+}
+
+func (t *tx) ListFallbackTeams(ctx context.Context, teamName string) ([]string, error) {
+	return t.teams.ListFallbackTeams(ctx, teamName)
+}
+
+func (t *tx) GetTeamPolicy(ctx context.Context, teamName string) (domain.TeamPolicy, error) {
+	return t.teams.GetTeamPolicy(ctx, teamName)
+}
+
+func (t *tx) SetTeamPolicy(ctx context.Context, teamName string, policy domain.TeamPolicy) (domain.Team, error) {
+	return t.teams.SetTeamPolicy(ctx, teamName, policy)
+}
+
 // UserRepository
 func (t *tx) GetUserByID(ctx context.Context, userID string) (domain.User, error) {
 	return t.users.GetUserByID(ctx, userID)
@@ -62,8 +149,44 @@ func (t *tx) SetActive(ctx context.Context, userID string, isActive bool) (domai
 	return t.users.SetActive(ctx, userID, isActive)
 }
 
-func (t *tx) ListActiveByTeam(ctx context.Context, teamName string) ([]domain.User, error) {
-	return t.users.ListActiveByTeam(ctx, teamName)
+func (t *tx) ListActiveByTeam(ctx context.Context, teamName string, at time.Time) ([]domain.User, error) {
+	return t.users.ListActiveByTeam(ctx, teamName, at)
+}
+
+func (t *tx) SetWorkingHours(ctx context.Context, userID, timezone string, hours *domain.WorkingHours) (domain.User, error) {
+	return t.users.SetWorkingHours(ctx, userID, timezone, hours)
+}
+
+func (t *tx) SetReviewCapacity(ctx context.Context, userID string, capacity *int) (domain.User, error) {
+	return t.users.SetReviewCapacity(ctx, userID, capacity)
+}
+
+func (t *tx) GetReviewLoad(ctx context.Context, userIDs []string) (map[string]domain.ReviewLoad, error) {
+	return t.users.GetReviewLoad(ctx, userIDs)
+}
+
+func (t *tx) AddUnavailability(ctx context.Context, period domain.Unavailability) (domain.Unavailability, error) {
+	return t.users.AddUnavailability(ctx, period)
+}
+
+func (t *tx) ListUnavailability(ctx context.Context, userID string) ([]domain.Unavailability, error) {
+	return t.users.ListUnavailability(ctx, userID)
+}
+
+func (t *tx) DeleteUnavailability(ctx context.Context, userID string, periodID int64) error {
+	return t.users.DeleteUnavailability(ctx, userID, periodID)
+}
+
+func (t *tx) SetSeniority(ctx context.Context, userID string, seniority domain.Seniority) (domain.User, error) {
+	return t.users.SetSeniority(ctx, userID, seniority)
+}
+
+func (t *tx) SetUserTags(ctx context.Context, userID string, tags []string) error {
+	return t.users.SetUserTags(ctx, userID, tags)
+}
+
+func (t *tx) ListUserTags(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	return t.users.ListUserTags(ctx, userIDs)
 }
 
 // PullRequestRepository
@@ -75,6 +198,10 @@ func (t *tx) GetPullRequestByID(ctx context.Context, prID string) (domain.PullRe
 	return t.prs.GetPullRequestByID(ctx, prID)
 }
 
+func (t *tx) GetPullRequestForUpdate(ctx context.Context, prID string) (domain.PullRequest, error) {
+	return t.prs.getPullRequestForUpdate(ctx, prID)
+}
+
 func (t *tx) MergePullRequest(ctx context.Context, prID string, mergedAt time.Time) (domain.PullRequest, error) {
 	return t.prs.MergePullRequest(ctx, prID, mergedAt)
 }
@@ -83,10 +210,23 @@ func (t *tx) UpdateStatus(ctx context.Context, prID string, status domain.PullRe
 	return t.prs.UpdateStatus(ctx, prID, status)
 }
 
-func (t *tx) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID string) (domain.PullRequest, error) {
-	return t.prs.ReassignReviewer(ctx, prID, oldReviewerID, newReviewerID)
+func (t *tx) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID, sourceTeam string) (domain.PullRequest, error) {
+	return t.prs.ReassignReviewer(ctx, prID, oldReviewerID, newReviewerID, sourceTeam)
 }
 
 func (t *tx) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error) {
 	return t.prs.ListByReviewer(ctx, reviewerID)
 }
+
+func (t *tx) CountRecentPairings(ctx context.Context, authorID string, reviewerIDs []string, since time.Time) (map[string]int, error) {
+	return t.prs.CountRecentPairings(ctx, authorID, reviewerIDs, since)
+}
+
+// CodeOwnerRepository
+func (t *tx) ReplaceCodeOwnerRules(ctx context.Context, rules []domain.CodeOwnerRule) error {
+	return t.rules.ReplaceCodeOwnerRules(ctx, rules)
+}
+
+func (t *tx) ListCodeOwnerRules(ctx context.Context) ([]domain.CodeOwnerRule, error) {
+	return t.rules.ListCodeOwnerRules(ctx)
+}
@@ -0,0 +1,87 @@
+package repositorypostgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestRetryTx_RetriesSerializationFailures(t *testing.T) {
+	var slept []time.Duration
+	sleep := func(_ context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+
+	calls := 0
+	err := retryTx(context.Background(), sleep, func() error {
+		calls++
+		switch calls {
+		case 1:
+			return fmt.Errorf("commit: %w", &pgconn.PgError{Code: "40001"})
+		case 2:
+			return &pgconn.PgError{Code: "40P01"}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 || len(slept) != 2 {
+		t.Fatalf("expected 3 attempts and 2 sleeps, got %d and %v", calls, slept)
+	}
+	for i, d := range slept {
+		if d <= 0 || d > txBaseBackoff<<i {
+			t.Fatalf("backoff %d out of range: %v", i, d)
+		}
+	}
+}
+
+func TestRetryTx_GivesUpAfterMaxAttempts(t *testing.T) {
+	calls := 0
+	err := retryTx(context.Background(), func(context.Context, time.Duration) error { return nil }, func() error {
+		calls++
+		return &pgconn.PgError{Code: "40001"}
+	})
+	if !isRetryable(err) || calls != txMaxAttempts {
+		t.Fatalf("expected %d attempts ending in the last error, got %d: %v", txMaxAttempts, calls, err)
+	}
+}
+
+func TestRetryTx_DoesNotRetryOtherErrors(t *testing.T) {
+	for _, want := range []error{errors.New("boom"), &pgconn.PgError{Code: "23505"}} {
+		calls := 0
+		err := retryTx(context.Background(), func(context.Context, time.Duration) error { return nil }, func() error {
+			calls++
+			return want
+		})
+		if !errors.Is(err, want) || calls != 1 {
+			t.Fatalf("expected a single attempt returning %v, got %d: %v", want, calls, err)
+		}
+	}
+}
+
+func TestRetryTx_StopsWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	err := retryTx(ctx, sleepContext, func() error {
+		calls++
+		return &pgconn.PgError{Code: "40001"}
+	})
+	if !errors.Is(err, context.Canceled) || calls != 1 {
+		t.Fatalf("expected cancellation after one attempt, got %d: %v", calls, err)
+	}
+}
+
+func TestBackoff_IsCapped(t *testing.T) {
+	for attempt := 1; attempt <= 10; attempt++ {
+		if d := backoff(attempt); d <= 0 || d > txMaxBackoff {
+			t.Fatalf("attempt %d: backoff %v out of range", attempt, d)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"pr-reviewer/internal/domain"
 	"pr-reviewer/internal/repository"
@@ -14,18 +15,18 @@ type userRepo struct {
 }
 
 func NewUserRepository(db *DB) repository.UserRepository {
-	return &userRepo{exec: db.SQL}
+	return &userRepo{exec: traced(db.SQL)}
 }
 
 func (r *userRepo) GetUserByID(ctx context.Context, userID string) (domain.User, error) {
 	row := r.exec.QueryRowContext(ctx, `
-		SELECT id, username, team_name, is_active
+		SELECT id, username, team_name, is_active, timezone, work_start_minute, work_end_minute, review_capacity, seniority
 		FROM users
 		WHERE id = $1
 	`, userID)
 
-	var u domain.User
-	if err := row.Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
+	u, err := scanUser(row)
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.User{}, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
 		}
@@ -39,11 +40,11 @@ func (r *userRepo) SetActive(ctx context.Context, userID string, isActive bool)
 		UPDATE users
 		SET is_active = $2
 		WHERE id = $1
-		RETURNING id, username, team_name, is_active
+		RETURNING id, username, team_name, is_active, timezone, work_start_minute, work_end_minute, review_capacity, seniority
 	`, userID, isActive)
 
-	var u domain.User
-	if err := row.Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
+	u, err := scanUser(row)
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.User{}, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
 		}
@@ -52,13 +53,18 @@ func (r *userRepo) SetActive(ctx context.Context, userID string, isActive bool)
 	return u, nil
 }
 
-func (r *userRepo) ListActiveByTeam(ctx context.Context, teamName string) ([]domain.User, error) {
+func (r *userRepo) ListActiveByTeam(ctx context.Context, teamName string, at time.Time) ([]domain.User, error) {
 	rows, err := r.exec.QueryContext(ctx, `
-		SELECT id, username, team_name, is_active
-		FROM users
-		WHERE team_name = $1 AND is_active = TRUE
-		ORDER BY id
-	`, teamName)
+		SELECT u.id, u.username, u.team_name, u.is_active, u.timezone, u.work_start_minute, u.work_end_minute, u.review_capacity, u.seniority
+		FROM users u
+		WHERE u.team_name = $1 AND u.is_active = TRUE
+		  AND NOT EXISTS (
+		      SELECT 1
+		      FROM user_unavailability ua
+		      WHERE ua.user_id = u.id AND ua.starts_at <= $2 AND ua.ends_at > $2
+		  )
+		ORDER BY u.id
+	`, teamName, at)
 	if err != nil {
 		return nil, err
 	}
@@ -66,8 +72,8 @@ func (r *userRepo) ListActiveByTeam(ctx context.Context, teamName string) ([]dom
 
 	var users []domain.User
 	for rows.Next() {
-		var u domain.User
-		if err := rows.Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
+		u, err := scanUser(rows)
+		if err != nil {
 			return nil, err
 		}
 		users = append(users, u)
@@ -75,3 +81,196 @@ func (r *userRepo) ListActiveByTeam(ctx context.Context, teamName string) ([]dom
 
 	return users, nil
 }
+
+func (r *userRepo) SetWorkingHours(ctx context.Context, userID, timezone string, hours *domain.WorkingHours) (domain.User, error) {
+	start, end := workingHoursArgs(hours)
+	row := r.exec.QueryRowContext(ctx, `
+		UPDATE users
+		SET timezone = $2,
+		    work_start_minute = $3,
+		    work_end_minute = $4
+		WHERE id = $1
+		RETURNING id, username, team_name, is_active, timezone, work_start_minute, work_end_minute, review_capacity, seniority
+	`, userID, timezone, start, end)
+
+	u, err := scanUser(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.User{}, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
+		}
+		return domain.User{}, err
+	}
+	return u, nil
+}
+
+func (r *userRepo) SetReviewCapacity(ctx context.Context, userID string, capacity *int) (domain.User, error) {
+	row := r.exec.QueryRowContext(ctx, `
+		UPDATE users
+		SET review_capacity = $2
+		WHERE id = $1
+		RETURNING id, username, team_name, is_active, timezone, work_start_minute, work_end_minute, review_capacity, seniority
+	`, userID, capacity)
+
+	u, err := scanUser(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.User{}, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
+		}
+		return domain.User{}, err
+	}
+	return u, nil
+}
+
+func (r *userRepo) SetSeniority(ctx context.Context, userID string, seniority domain.Seniority) (domain.User, error) {
+	row := r.exec.QueryRowContext(ctx, `
+		UPDATE users
+		SET seniority = $2
+		WHERE id = $1
+		RETURNING id, username, team_name, is_active, timezone, work_start_minute, work_end_minute, review_capacity, seniority
+	`, userID, seniority)
+
+	u, err := scanUser(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.User{}, domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
+		}
+		return domain.User{}, err
+	}
+	return u, nil
+}
+
+func (r *userRepo) GetReviewLoad(ctx context.Context, userIDs []string) (map[string]domain.ReviewLoad, error) {
+	load := make(map[string]domain.ReviewLoad, len(userIDs))
+	if len(userIDs) == 0 {
+		return load, nil
+	}
+
+	rows, err := r.exec.QueryContext(ctx, `
+		SELECT u.id,
+		       COALESCE(u.review_capacity, t.default_review_capacity),
+		       COUNT(pr.id)
+		FROM users u
+		JOIN teams t ON t.name = u.team_name
+		LEFT JOIN pull_request_reviewers r ON r.reviewer_id = u.id
+		LEFT JOIN pull_requests pr ON pr.id = r.pull_request_id AND pr.status = 'OPEN'
+		WHERE u.id = ANY($1)
+		GROUP BY u.id, u.review_capacity, t.default_review_capacity
+	`, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(rows)
+
+	for rows.Next() {
+		var (
+			id string
+			l  domain.ReviewLoad
+		)
+		if err := rows.Scan(&id, &l.Capacity, &l.OpenReviews); err != nil {
+			return nil, err
+		}
+		load[id] = l
+	}
+	return load, rows.Err()
+}
+
+func (r *userRepo) AddUnavailability(ctx context.Context, period domain.Unavailability) (domain.Unavailability, error) {
+	row := r.exec.QueryRowContext(ctx, `
+		INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, user_id, starts_at, ends_at, reason
+	`, period.UserID, period.StartsAt, period.EndsAt, period.Reason)
+
+	var created domain.Unavailability
+	if err := row.Scan(&created.ID, &created.UserID, &created.StartsAt, &created.EndsAt, &created.Reason); err != nil {
+		return domain.Unavailability{}, err
+	}
+	return created, nil
+}
+
+func (r *userRepo) ListUnavailability(ctx context.Context, userID string) ([]domain.Unavailability, error) {
+	rows, err := r.exec.QueryContext(ctx, `
+		SELECT id, user_id, starts_at, ends_at, reason
+		FROM user_unavailability
+		WHERE user_id = $1
+		ORDER BY starts_at, id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(rows)
+
+	var periods []domain.Unavailability
+	for rows.Next() {
+		var p domain.Unavailability
+		if err := rows.Scan(&p.ID, &p.UserID, &p.StartsAt, &p.EndsAt, &p.Reason); err != nil {
+			return nil, err
+		}
+		periods = append(periods, p)
+	}
+	return periods, nil
+}
+
+func (r *userRepo) DeleteUnavailability(ctx context.Context, userID string, periodID int64) error {
+	res, err := r.exec.ExecContext(ctx, `
+		DELETE FROM user_unavailability
+		WHERE id = $1 AND user_id = $2
+	`, periodID, userID)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.NewDomainError(domain.ErrorCodeNotFound, "unavailability period not found")
+	}
+	return nil
+}
+
+func (r *userRepo) SetUserTags(ctx context.Context, userID string, tags []string) error {
+	// Both statements of a data-modifying CTE see the same snapshot, so an
+	// INSERT there would conflict with the rows its DELETE removes. Keep the
+	// tags that stay and add the new ones in two statements instead.
+	return inTx(ctx, r.exec, func(exec executor) error {
+		if _, err := exec.ExecContext(ctx, `
+			DELETE FROM user_tags WHERE user_id = $1 AND tag <> ALL($2)
+		`, userID, tags); err != nil {
+			return err
+		}
+		_, err := exec.ExecContext(ctx, `
+			INSERT INTO user_tags (user_id, tag)
+			SELECT $1, tag FROM unnest($2::text[]) AS tag
+			ON CONFLICT DO NOTHING
+		`, userID, tags)
+		return err
+	})
+}
+
+func (r *userRepo) ListUserTags(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	tags := make(map[string][]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return tags, nil
+	}
+
+	rows, err := r.exec.QueryContext(ctx, `
+		SELECT user_id, tag
+		FROM user_tags
+		WHERE user_id = ANY($1)
+		ORDER BY user_id, tag
+	`, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRows(rows)
+
+	for rows.Next() {
+		var userID, tag string
+		if err := rows.Scan(&userID, &tag); err != nil {
+			return nil, err
+		}
+		tags[userID] = append(tags[userID], tag)
+	}
+	return tags, rows.Err()
+}
@@ -0,0 +1,70 @@
+package repositorysqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"pr-reviewer/internal/repository/repositorytest"
+)
+
+func TestConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Backend {
+		db := openTestDB(t)
+		return repositorytest.Backend{
+			Teams:      NewTeamRepository(db),
+			Users:      NewUserRepository(db),
+			PRs:        NewPullRequestRepository(db),
+			CodeOwners: NewCodeOwnerRepository(db),
+			Stats:      NewStatsRepository(db),
+			UoW:        NewUnitOfWork(db),
+		}
+	})
+}
+
+func TestApplyMigrations_IsIdempotent(t *testing.T) {
+	db := openTestDB(t)
+	if err := ApplyMigrations(context.Background(), db, nil); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+
+	var version int
+	if err := db.SQL.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		t.Fatalf("read version: %v", err)
+	}
+	if version != 2 {
+		t.Fatalf("expected schema version 2, got %d", version)
+	}
+	if pending, err := PendingMigrations(context.Background(), db); err != nil || pending != 0 {
+		t.Fatalf("expected no pending migrations, got %d, %v", pending, err)
+	}
+}
+
+func TestPendingMigrations(t *testing.T) {
+	db := openTestDB(t)
+	if _, err := db.SQL.Exec(`PRAGMA user_version = 1`); err != nil {
+		t.Fatalf("reset version: %v", err)
+	}
+	pending, err := PendingMigrations(context.Background(), db)
+	if err != nil {
+		t.Fatalf("pending: %v", err)
+	}
+	if pending != 1 {
+		t.Fatalf("expected 1 pending migration, got %d", pending)
+	}
+}
+
+func openTestDB(t *testing.T) *DB {
+	t.Helper()
+	ctx := context.Background()
+	db, err := NewDB(ctx, Scheme+"//"+filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	if err := ApplyMigrations(ctx, db, nil); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return db
+}
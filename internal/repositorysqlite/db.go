@@ -0,0 +1,65 @@
+package repositorysqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Scheme is the DB_DSN prefix that selects this backend, e.g.
+// sqlite://pr-reviewer.db or sqlite:///var/lib/pr-reviewer/data.db.
+const Scheme = "sqlite:"
+
+type DB struct {
+	SQL *sql.DB
+}
+
+func NewDB(ctx context.Context, dsn string) (*DB, error) {
+	path, query, _ := strings.Cut(strings.TrimPrefix(strings.TrimPrefix(dsn, Scheme), "//"), "?")
+	if path == "" {
+		return nil, fmt.Errorf("open db: empty sqlite path in %q", dsn)
+	}
+	if path == ":memory:" {
+		return nil, fmt.Errorf("open db: in-memory sqlite is not supported, use memory:// instead")
+	}
+
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("open db: %w", err)
+	}
+	setDefault(params, "_foreign_keys", "on")
+	setDefault(params, "_journal_mode", "WAL")
+	setDefault(params, "_busy_timeout", "5000")
+	// Take the write lock at BEGIN so that concurrent transactions wait for
+	// each other instead of failing on upgrade.
+	setDefault(params, "_txlock", "immediate")
+
+	db, err := sql.Open("sqlite3", "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("open db: %w", err)
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping db: %w", err)
+	}
+
+	return &DB{SQL: db}, nil
+}
+
+func (d *DB) Close() error {
+	if d == nil || d.SQL == nil {
+		return nil
+	}
+	return d.SQL.Close()
+}
+
+func setDefault(params url.Values, key, value string) {
+	if !params.Has(key) {
+		params.Set(key, value)
+	}
+}
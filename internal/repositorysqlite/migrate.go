@@ -0,0 +1,127 @@
+package repositorysqlite
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"pr-reviewer/internal/logging"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// ApplyMigrations applies the embedded migrations that are newer than the
+// schema version recorded in PRAGMA user_version, each in its own
+// transaction.
+func ApplyMigrations(ctx context.Context, db *DB, logger logging.Logger) error {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	files, err := migrationFiles()
+	if err != nil {
+		return err
+	}
+	current, err := schemaVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if f.version <= current {
+			continue
+		}
+
+		query, err := migrations.ReadFile(f.path)
+		if err != nil {
+			return fmt.Errorf("read migration %s: %w", f.name, err)
+		}
+
+		logger.Info("Applying migration", "migration", f.name)
+		if err := applyMigration(ctx, db, string(query), f.version); err != nil {
+			logger.Error("Failed migration", "migration", f.name, "error", err)
+			return fmt.Errorf("apply migration %s: %w", f.name, err)
+		}
+		logger.Info("Applied migration", "migration", f.name)
+	}
+
+	return nil
+}
+
+// PendingMigrations counts the embedded migrations newer than the schema
+// version of db.
+func PendingMigrations(ctx context.Context, db *DB) (int, error) {
+	files, err := migrationFiles()
+	if err != nil {
+		return 0, err
+	}
+	current, err := schemaVersion(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+	pending := 0
+	for _, f := range files {
+		if f.version > current {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+type migrationFile struct {
+	path    string
+	name    string
+	version int
+}
+
+// migrationFiles lists the embedded migrations ordered by version.
+func migrationFiles() ([]migrationFile, error) {
+	paths, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+	sort.Strings(paths)
+
+	files := make([]migrationFile, 0, len(paths))
+	for _, path := range paths {
+		name := strings.TrimPrefix(path, "migrations/")
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: invalid version prefix", name)
+		}
+		files = append(files, migrationFile{path: path, name: name, version: version})
+	}
+	return files, nil
+}
+
+func schemaVersion(ctx context.Context, db *DB) (int, error) {
+	var version int
+	if err := db.SQL.QueryRowContext(ctx, `PRAGMA user_version`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("read schema version: %w", err)
+	}
+	return version, nil
+}
+
+func applyMigration(ctx context.Context, db *DB, query string, version int) error {
+	tx, err := db.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		return err
+	}
+	// PRAGMA does not accept bound parameters.
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`PRAGMA user_version = %d`, version)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
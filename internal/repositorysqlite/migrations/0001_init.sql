@@ -0,0 +1,87 @@
+CREATE TABLE teams (
+    name TEXT PRIMARY KEY,
+    default_review_capacity INTEGER CHECK (default_review_capacity >= 0),
+    require_senior_reviewer BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE users (
+    id TEXT PRIMARY KEY,
+    username TEXT NOT NULL,
+    team_name TEXT NOT NULL REFERENCES teams(name) ON UPDATE CASCADE ON DELETE RESTRICT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    timezone TEXT NOT NULL DEFAULT '',
+    work_start_minute INTEGER CHECK (work_start_minute BETWEEN 0 AND 1439),
+    work_end_minute INTEGER CHECK (work_end_minute BETWEEN 0 AND 1439),
+    review_capacity INTEGER CHECK (review_capacity >= 0),
+    seniority TEXT NOT NULL DEFAULT 'middle' CHECK (seniority IN ('junior', 'middle', 'senior'))
+);
+
+CREATE INDEX idx_users_team_active ON users (team_name, is_active, id);
+
+-- Timestamps are stored as fixed-width UTC text, so they compare correctly
+-- as strings.
+CREATE TABLE pull_requests (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    author_id TEXT NOT NULL REFERENCES users(id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    status TEXT NOT NULL CHECK (status IN ('OPEN', 'MERGED')),
+    created_at TEXT NOT NULL,
+    merged_at TEXT,
+    understaffed BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX idx_pr_status ON pull_requests (status);
+CREATE INDEX idx_pull_requests_author_created ON pull_requests (author_id, created_at);
+
+CREATE TABLE pull_request_reviewers (
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    reviewer_id TEXT NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    source_team TEXT,
+    PRIMARY KEY (pull_request_id, reviewer_id)
+);
+
+CREATE INDEX idx_pr_reviewers_reviewer ON pull_request_reviewers (reviewer_id, pull_request_id);
+
+CREATE VIEW pr_status_counts AS
+SELECT status, COUNT(*) AS total
+FROM pull_requests
+GROUP BY status;
+
+CREATE TABLE user_unavailability (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    starts_at TEXT NOT NULL,
+    ends_at TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_user_unavailability_user ON user_unavailability (user_id, starts_at, ends_at);
+
+CREATE TABLE team_fallbacks (
+    team_name TEXT NOT NULL REFERENCES teams(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    fallback_team_name TEXT NOT NULL REFERENCES teams(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    priority INTEGER NOT NULL,
+    PRIMARY KEY (team_name, fallback_team_name),
+    CHECK (team_name <> fallback_team_name)
+);
+
+CREATE TABLE code_owner_rules (
+    position INTEGER PRIMARY KEY,
+    pattern TEXT NOT NULL,
+    owners TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE user_tags (
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (user_id, tag)
+);
+
+CREATE INDEX idx_user_tags_tag ON user_tags (tag);
+
+CREATE TABLE pull_request_tags (
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (pull_request_id, tag)
+);
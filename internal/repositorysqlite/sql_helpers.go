@@ -0,0 +1,121 @@
+package repositorysqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"pr-reviewer/internal/domain"
+)
+
+// timeLayout is fixed-width so that stored timestamps sort and compare
+// correctly as text.
+const timeLayout = "2006-01-02T15:04:05.000000000Z"
+
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func closeRows(rows *sql.Rows) {
+	if rows != nil {
+		_ = rows.Close()
+	}
+}
+
+func scanUser(row rowScanner) (domain.User, error) {
+	var (
+		u          domain.User
+		start, end sql.NullInt32
+	)
+	if err := row.Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive, &u.Timezone, &start, &end, &u.ReviewCapacity, &u.Seniority); err != nil {
+		return domain.User{}, err
+	}
+	if start.Valid && end.Valid {
+		u.WorkingHours = &domain.WorkingHours{StartMinute: int(start.Int32), EndMinute: int(end.Int32)}
+	}
+	return u, nil
+}
+
+func workingHoursArgs(h *domain.WorkingHours) (any, any) {
+	if h == nil {
+		return nil, nil
+	}
+	return h.StartMinute, h.EndMinute
+}
+
+// nullIfEmpty maps an unset string setting to NULL so upserts keep the stored value.
+func nullIfEmpty[S ~string](s S) any {
+	if s == "" {
+		return nil
+	}
+	return string(s)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
+
+func formatTimePtr(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return formatTime(*t)
+}
+
+// timestamp scans a column written by formatTime.
+type timestamp struct {
+	t *time.Time
+}
+
+func (ts timestamp) Scan(src any) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("scan timestamp: unsupported type %T", src)
+	}
+	t, err := time.Parse(timeLayout, s)
+	if err != nil {
+		return fmt.Errorf("scan timestamp: %w", err)
+	}
+	*ts.t = t
+	return nil
+}
+
+// nullTimestamp is timestamp for nullable columns.
+type nullTimestamp struct {
+	t **time.Time
+}
+
+func (ts nullTimestamp) Scan(src any) error {
+	if src == nil {
+		*ts.t = nil
+		return nil
+	}
+	var t time.Time
+	if err := (timestamp{t: &t}).Scan(src); err != nil {
+		return err
+	}
+	*ts.t = &t
+	return nil
+}
+
+// inList returns "?, ?, ..." and the matching arguments for an IN clause.
+func inList(values []string) (string, []any) {
+	args := make([]any, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", "), args
+}
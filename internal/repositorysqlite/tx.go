@@ -0,0 +1,173 @@
+package repositorysqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/repository"
+)
+
+type unitOfWork struct {
+	db *DB
+}
+
+func NewUnitOfWork(db *DB) repository.UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+func (u *unitOfWork) Begin(ctx context.Context) (repository.Tx, error) {
+	tx, err := u.db.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return newTx(tx), nil
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(tx repository.Tx) error) error {
+	return repository.RunTx(ctx, u, fn)
+}
+
+type tx struct {
+	tx    *sql.Tx
+	teams *teamRepo
+	users *userRepo
+	prs   *prRepo
+	rules *codeOwnerRepo
+}
+
+func newTx(t *sql.Tx) *tx {
+	return &tx{
+		tx:    t,
+		teams: &teamRepo{exec: t},
+		users: &userRepo{exec: t},
+		prs:   &prRepo{exec: t},
+		rules: &codeOwnerRepo{exec: t},
+	}
+}
+
+func (t *tx) Commit(ctx context.Context) error   { return t.tx.Commit() }
+func (t *tx) Rollback(ctx context.Context) error { return t.tx.Rollback() }
+
+// TeamRepository
+func (t *tx) UpsertTeam(ctx context.Context, team domain.Team) (domain.Team, error) {
+	return t.teams.UpsertTeam(ctx, team)
+}
+
+func (t *tx) GetTeamByName(ctx context.Context, teamName string) (domain.Team, error) {
+	return t.teams.GetTeamByName(ctx, teamName)
+}
+
+func (t *tx) SetDefaultReviewCapacity(ctx context.Context, teamName string, capacity *int) (domain.Team, error) {
+	return t.teams.SetDefaultReviewCapacity(ctx, teamName, capacity)
+}
+
+func (t *tx) SetFallbackTeams(ctx context.Context, teamName string, fallbacks []string) (domain.Team, error) {
+	return t.teams.SetFallbackTeams(ctx, teamName, fallbacks)
+}
+
+func (t *tx) ListFallbackTeams(ctx context.Context, teamName string) ([]string, error) {
+	return t.teams.ListFallbackTeams(ctx, teamName)
+}
+
+func (t *tx) GetTeamPolicy(ctx context.Context, teamName string) (domain.TeamPolicy, error) {
+	return t.teams.GetTeamPolicy(ctx, teamName)
+}
+
+func (t *tx) SetTeamPolicy(ctx context.Context, teamName string, policy domain.TeamPolicy) (domain.Team, error) {
+	return t.teams.SetTeamPolicy(ctx, teamName, policy)
+}
+
+// UserRepository
+func (t *tx) GetUserByID(ctx context.Context, userID string) (domain.User, error) {
+	return t.users.GetUserByID(ctx, userID)
+}
+
+func (t *tx) SetActive(ctx context.Context, userID string, isActive bool) (domain.User, error) {
+	return t.users.SetActive(ctx, userID, isActive)
+}
+
+func (t *tx) ListActiveByTeam(ctx context.Context, teamName string, at time.Time) ([]domain.User, error) {
+	return t.users.ListActiveByTeam(ctx, teamName, at)
+}
+
+func (t *tx) SetWorkingHours(ctx context.Context, userID, timezone string, hours *domain.WorkingHours) (domain.User, error) {
+	return t.users.SetWorkingHours(ctx, userID, timezone, hours)
+}
+
+func (t *tx) SetReviewCapacity(ctx context.Context, userID string, capacity *int) (domain.User, error) {
+	return t.users.SetReviewCapacity(ctx, userID, capacity)
+}
+
+func (t *tx) GetReviewLoad(ctx context.Context, userIDs []string) (map[string]domain.ReviewLoad, error) {
+	return t.users.GetReviewLoad(ctx, userIDs)
+}
+
+func (t *tx) AddUnavailability(ctx context.Context, period domain.Unavailability) (domain.Unavailability, error) {
+	return t.users.AddUnavailability(ctx, period)
+}
+
+func (t *tx) ListUnavailability(ctx context.Context, userID string) ([]domain.Unavailability, error) {
+	return t.users.ListUnavailability(ctx, userID)
+}
+
+func (t *tx) DeleteUnavailability(ctx context.Context, userID string, periodID int64) error {
+	return t.users.DeleteUnavailability(ctx, userID, periodID)
+}
+
+func (t *tx) SetSeniority(ctx context.Context, userID string, seniority domain.Seniority) (domain.User, error) {
+	return t.users.SetSeniority(ctx, userID, seniority)
+}
+
+func (t *tx) SetUserTags(ctx context.Context, userID string, tags []string) error {
+	return t.users.SetUserTags(ctx, userID, tags)
+}
+
+func (t *tx) ListUserTags(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	return t.users.ListUserTags(ctx, userIDs)
+}
+
+// PullRequestRepository
+func (t *tx) CreatePullRequest(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error) {
+	return t.prs.CreatePullRequest(ctx, pr)
+}
+
+func (t *tx) GetPullRequestByID(ctx context.Context, prID string) (domain.PullRequest, error) {
+	return t.prs.GetPullRequestByID(ctx, prID)
+}
+
+// GetPullRequestForUpdate needs no row lock: transactions begin immediate,
+// so a write transaction already excludes every other one.
+func (t *tx) GetPullRequestForUpdate(ctx context.Context, prID string) (domain.PullRequest, error) {
+	return t.prs.GetPullRequestByID(ctx, prID)
+}
+
+func (t *tx) MergePullRequest(ctx context.Context, prID string, mergedAt time.Time) (domain.PullRequest, error) {
+	return t.prs.MergePullRequest(ctx, prID, mergedAt)
+}
+
+func (t *tx) UpdateStatus(ctx context.Context, prID string, status domain.PullRequestStatus) (domain.PullRequest, error) {
+	return t.prs.UpdateStatus(ctx, prID, status)
+}
+
+func (t *tx) ReassignReviewer(ctx context.Context, prID, oldReviewerID, newReviewerID, sourceTeam string) (domain.PullRequest, error) {
+	return t.prs.ReassignReviewer(ctx, prID, oldReviewerID, newReviewerID, sourceTeam)
+}
+
+func (t *tx) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error) {
+	return t.prs.ListByReviewer(ctx, reviewerID)
+}
+
+func (t *tx) CountRecentPairings(ctx context.Context, authorID string, reviewerIDs []string, since time.Time) (map[string]int, error) {
+	return t.prs.CountRecentPairings(ctx, authorID, reviewerIDs, since)
+}
+
+// CodeOwnerRepository
+func (t *tx) ReplaceCodeOwnerRules(ctx context.Context, rules []domain.CodeOwnerRule) error {
+	return t.rules.ReplaceCodeOwnerRules(ctx, rules)
+}
+
+func (t *tx) ListCodeOwnerRules(ctx context.Context) ([]domain.CodeOwnerRule, error) {
+	return t.rules.ListCodeOwnerRules(ctx)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+
+	"pr-reviewer/internal/codeowners"
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/repository"
+)
+
+type CodeOwnerService interface {
+	ReplaceRules(ctx context.Context, rules []domain.CodeOwnerRule) ([]domain.CodeOwnerRule, error)
+	ListRules(ctx context.Context) ([]domain.CodeOwnerRule, error)
+}
+
+type codeOwnerService struct {
+	rules repository.CodeOwnerRepository
+	users repository.UserRepository
+	teams repository.TeamRepository
+	uow   repository.UnitOfWork
+}
+
+func NewCodeOwnerService(rules repository.CodeOwnerRepository, users repository.UserRepository, teams repository.TeamRepository, uow repository.UnitOfWork) CodeOwnerService {
+	return &codeOwnerService{
+		rules: rules,
+		users: users,
+		teams: teams,
+		uow:   uow,
+	}
+}
+
+func (s *codeOwnerService) ReplaceRules(ctx context.Context, rules []domain.CodeOwnerRule) ([]domain.CodeOwnerRule, error) {
+	for _, rule := range rules {
+		for _, token := range rule.Owners {
+			if err := s.ensureOwner(ctx, token); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err := s.uow.Do(ctx, func(tx repository.Tx) error {
+		return tx.ReplaceCodeOwnerRules(ctx, rules)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (s *codeOwnerService) ListRules(ctx context.Context) ([]domain.CodeOwnerRule, error) {
+	return s.rules.ListCodeOwnerRules(ctx)
+}
+
+func (s *codeOwnerService) ensureOwner(ctx context.Context, token string) error {
+	owner, err := codeowners.ParseOwner(token)
+	if err != nil {
+		return err
+	}
+
+	if owner.TeamName != "" {
+		_, err = s.teams.GetTeamByName(ctx, owner.TeamName)
+	} else {
+		_, err = s.users.GetUserByID(ctx, owner.UserID)
+	}
+	if derr, ok := domain.AsDomainError(err); ok && derr.Code == domain.ErrorCodeNotFound {
+		return domain.NewDomainError(domain.ErrorCodeNotFound, "owner "+token+" not found")
+	}
+	return err
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+
+	"pr-reviewer/internal/domain"
+	repoMocks "pr-reviewer/mocks/repository"
+)
+
+func TestCodeOwnerService_ReplaceRules_UnknownOwner(t *testing.T) {
+	users := repoMocks.NewMockUserRepository(t)
+	users.On("GetUserByID", mock.Anything, "ghost").Return(domain.User{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
+	teams := repoMocks.NewMockTeamRepository(t)
+	teams.On("GetTeamByName", mock.Anything, "backend").Return(domain.Team{Name: "backend"}, nil)
+
+	svc := NewCodeOwnerService(repoMocks.NewMockCodeOwnerRepository(t), users, teams, newUnitOfWork(t))
+	_, err := svc.ReplaceRules(context.Background(), []domain.CodeOwnerRule{
+		{Pattern: "*", Owners: []string{"@acme/backend"}},
+		{Pattern: "*.sql", Owners: []string{"@ghost"}},
+	})
+	derr, ok := domain.AsDomainError(err)
+	if !ok || derr.Code != domain.ErrorCodeNotFound || derr.Message != "owner @ghost not found" {
+		t.Fatalf("expected unknown owner error, got %v", err)
+	}
+}
+
+func TestCodeOwnerService_ReplaceRules_Success(t *testing.T) {
+	rules := []domain.CodeOwnerRule{
+		{Pattern: "*.sql", Owners: []string{"@dba"}},
+		{Pattern: "/docs/"},
+	}
+	users := repoMocks.NewMockUserRepository(t)
+	users.On("GetUserByID", mock.Anything, "dba").Return(domain.User{ID: "dba"}, nil)
+
+	tx := repoMocks.NewMockTx(t)
+	tx.On("ReplaceCodeOwnerRules", mock.Anything, rules).Return(nil)
+	tx.On("Commit", mock.Anything).Return(nil)
+	tx.On("Rollback", mock.Anything).Return(nil)
+	uow := newUnitOfWork(t)
+	uow.On("Begin", mock.Anything).Return(tx, nil)
+
+	svc := NewCodeOwnerService(repoMocks.NewMockCodeOwnerRepository(t), users, repoMocks.NewMockTeamRepository(t), uow)
+	saved, err := svc.ReplaceRules(context.Background(), rules)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(saved) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(saved))
+	}
+}
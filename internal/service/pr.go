@@ -2,86 +2,258 @@ package service
 
 import (
 	"context"
+	"slices"
 	"time"
 
+	"pr-reviewer/internal/codeowners"
 	"pr-reviewer/internal/domain"
 	"pr-reviewer/internal/metrics"
 	"pr-reviewer/internal/repository"
+	"pr-reviewer/internal/tracing"
 )
 
+const defaultReviewers = 2
+
 type PullRequestService interface {
 	Create(ctx context.Context, pr domain.PullRequest) (*domain.PullRequest, error)
-	Merge(ctx context.Context, prID string) (*domain.PullRequest, error)
-	Reassign(ctx context.Context, prID, oldReviewerID string) (*domain.PullRequest, string, error)
+	GetPullRequest(ctx context.Context, prID string) (*domain.PullRequest, error)
+	// Merge and Reassign fail with VERSION_MISMATCH when ifVersions is not
+	// empty and does not contain the pull request's current version.
+	Merge(ctx context.Context, prID string, ifVersions []int64) (*domain.PullRequest, error)
+	Reassign(ctx context.Context, prID, oldReviewerID string, ifVersions []int64) (*domain.PullRequest, string, error)
+	PreviewAssignment(ctx context.Context, pr domain.PullRequest) (*domain.AssignmentPreview, error)
+}
+
+// SelectionPolicy tunes how reviewers are ranked before they are picked.
+type SelectionPolicy struct {
+	// PreferWorkingHours moves reviewers that are inside their working hours
+	// ahead of the rest of the team.
+	PreferWorkingHours bool
+	// PairingWindow, when positive, moves reviewers that reviewed the author
+	// less often within the window ahead, spreading knowledge across the team.
+	PairingWindow time.Duration
+	// Reviewers is the number of reviewers to assign; zero means two.
+	Reviewers int
+}
+
+// ReviewerCount is the number of reviewers assigned to a new pull request.
+func (p SelectionPolicy) ReviewerCount() int {
+	if p.Reviewers <= 0 {
+		return defaultReviewers
+	}
+	return p.Reviewers
+}
+
+type PullRequestServiceOption func(*pullRequestService)
+
+func WithClock(now func() time.Time) PullRequestServiceOption {
+	return func(s *pullRequestService) {
+		s.now = now
+	}
+}
+
+// WithCodeOwners enables code-owner based assignment for pull requests that
+// list their changed paths.
+func WithCodeOwners(rules repository.CodeOwnerRepository) PullRequestServiceOption {
+	return func(s *pullRequestService) {
+		s.codeOwners = rules
+	}
+}
+
+func WithSelectionPolicy(policy SelectionPolicy) PullRequestServiceOption {
+	return WithSelectionPolicySource(func() SelectionPolicy { return policy })
+}
+
+// WithSelectionPolicySource reads the policy once per request, so a policy
+// swapped at runtime applies to new requests only.
+func WithSelectionPolicySource(policy func() SelectionPolicy) PullRequestServiceOption {
+	return func(s *pullRequestService) {
+		s.policy = policy
+	}
 }
 
 type pullRequestService struct {
-	prs     repository.PullRequestRepository
-	users   repository.UserRepository
-	uow     repository.UnitOfWork
-	metrics metrics.BusinessMetrics
+	prs        repository.PullRequestRepository
+	users      repository.UserRepository
+	teams      repository.TeamRepository
+	codeOwners repository.CodeOwnerRepository
+	uow        repository.UnitOfWork
+	metrics    metrics.BusinessMetrics
+	policy     func() SelectionPolicy
+	now        func() time.Time
+}
+
+// repos is where a request reads from: the service's repositories, or the
+// transaction it writes in, so that what it read cannot change under it.
+type repos struct {
+	prs   repository.PullRequestRepository
+	users repository.UserRepository
+	teams repository.TeamRepository
+	// codeOwners is nil when code-owner assignment is off.
+	codeOwners repository.CodeOwnerRepository
+}
+
+func (s *pullRequestService) repos() repos {
+	return repos{prs: s.prs, users: s.users, teams: s.teams, codeOwners: s.codeOwners}
 }
 
-func NewPullRequestService(prs repository.PullRequestRepository, users repository.UserRepository, uow repository.UnitOfWork, metrics metrics.BusinessMetrics) PullRequestService {
-	return &pullRequestService{
+func (s *pullRequestService) txRepos(tx repository.Tx) repos {
+	r := repos{prs: tx, users: tx, teams: tx}
+	if s.codeOwners != nil {
+		r.codeOwners = tx
+	}
+	return r
+}
+
+func NewPullRequestService(prs repository.PullRequestRepository, users repository.UserRepository, teams repository.TeamRepository, uow repository.UnitOfWork, metrics metrics.BusinessMetrics, opts ...PullRequestServiceOption) PullRequestService {
+	s := &pullRequestService{
 		prs:     prs,
 		users:   users,
+		teams:   teams,
 		uow:     uow,
 		metrics: metrics,
+		policy:  func() SelectionPolicy { return SelectionPolicy{} },
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 func (s *pullRequestService) Create(ctx context.Context, pr domain.PullRequest) (*domain.PullRequest, error) {
-	if _, err := s.prs.GetPullRequestByID(ctx, pr.ID); err == nil {
-		return nil, domain.NewDomainError(domain.ErrorCodePRExists, "pull request already exists")
-	} else if derr, ok := domain.AsDomainError(err); ok && derr.Code == domain.ErrorCodeNotFound {
-		// ok
-	} else if err != nil {
+	var created domain.PullRequest
+	err := s.uow.Do(ctx, func(tx repository.Tx) error {
+		r := s.txRepos(tx)
+		if _, err := tx.GetPullRequestByID(ctx, pr.ID); err == nil {
+			return domain.NewDomainError(domain.ErrorCodePRExists, "pull request already exists")
+		} else if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeNotFound {
+			return err
+		}
+
+		author, err := s.getAuthor(ctx, r, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+
+		now := s.now().UTC()
+		a, err := s.assign(ctx, r, pr, author, now)
+		if err != nil {
+			return err
+		}
+
+		pr.Status = domain.PullRequestStatusOpen
+		pr.AssignedReviewers = userIDs(a.reviewers)
+		pr.CrossTeamReviewers = a.borrowed
+		pr.Understaffed = a.understaffed
+		pr.CreatedAt = now
+
+		created, err = tx.CreatePullRequest(ctx, pr)
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	author, err := s.users.GetUserByID(ctx, pr.AuthorID)
+	if s.metrics != nil {
+		s.metrics.IncPRCreated()
+	}
+
+	return &created, nil
+}
+
+func (s *pullRequestService) getAuthor(ctx context.Context, r repos, authorID string) (domain.User, error) {
+	author, err := r.users.GetUserByID(ctx, authorID)
 	if err != nil {
 		if derr, ok := domain.AsDomainError(err); ok && derr.Code == domain.ErrorCodeNotFound {
-			return nil, domain.NewDomainError(domain.ErrorCodeNotFound, "author not found")
+			return domain.User{}, domain.NewDomainError(domain.ErrorCodeNotFound, "author not found")
 		}
-		return nil, err
+		return domain.User{}, err
 	}
+	return author, nil
+}
 
-	active, err := s.users.ListActiveByTeam(ctx, author.TeamName)
-	if err != nil {
-		return nil, err
-	}
+// assignment is the outcome of reviewer selection for a new pull request.
+type assignment struct {
+	reviewers []domain.User
+	// borrowed maps the reviewers picked from a fallback team to that team.
+	// Reviewers from other teams picked as code owners are not borrowed.
+	borrowed     map[string]string
+	understaffed bool
+}
 
-	reviewers := pickReviewers(active, pr.AuthorID, 2)
+func (a *assignment) borrow(users []domain.User) {
+	for _, u := range users {
+		if a.borrowed == nil {
+			a.borrowed = make(map[string]string)
+		}
+		a.borrowed[u.ID] = u.TeamName
+	}
+}
 
-	pr.Status = domain.PullRequestStatusOpen
-	pr.AssignedReviewers = reviewers
-	pr.CreatedAt = time.Now().UTC()
+// assign picks reviewers for pr without writing anything and reports whether
+// the pull request ends up understaffed.
+func (s *pullRequestService) assign(ctx context.Context, r repos, pr domain.PullRequest, author domain.User, at time.Time) (_ assignment, err error) {
+	ctx, span := tracing.Start(ctx, "select reviewers")
+	defer func() { tracing.End(span, err) }()
 
-	tx, err := s.uow.Begin(ctx)
+	var a assignment
+	policy, err := r.teams.GetTeamPolicy(ctx, author.TeamName)
 	if err != nil {
-		return nil, err
+		return a, err
 	}
-	defer tx.Rollback(ctx)
+	rule := &seniorityRule{required: policy.RequireSeniorReviewer}
 
-	created, err := tx.CreatePullRequest(ctx, pr)
+	sel := selection{authorID: pr.AuthorID, at: at, tags: pr.Tags, policy: s.policy()}
+	required := sel.policy.ReviewerCount()
+	reviewers, uncovered, err := s.pickCodeOwners(ctx, r, pr.AuthorID, pr.ChangedPaths, sel)
 	if err != nil {
-		return nil, err
+		return a, err
+	}
+	for _, u := range reviewers {
+		rule.observe(u)
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return nil, err
+	candidates, err := s.availableCandidates(ctx, r, author.TeamName, sel)
+	if err != nil {
+		return a, err
 	}
 
-	if s.metrics != nil {
-		s.metrics.IncPRCreated()
+	exclude := func() []string {
+		return append([]string{pr.AuthorID}, userIDs(reviewers)...)
+	}
+	if rule.pending() {
+		onlySenior := &seniorityRule{required: true, strict: true}
+		senior := pickReviewers(candidates, exclude(), 1, onlySenior)
+		if len(senior) == 0 {
+			if senior, err = s.pickFromFallbackTeams(ctx, r, author.TeamName, exclude(), 1, sel, onlySenior); err != nil {
+				return a, err
+			}
+			a.borrow(senior)
+		}
+		for _, u := range senior {
+			rule.observe(u)
+		}
+		reviewers = append(reviewers, senior...)
+	}
+	if len(reviewers) < required {
+		reviewers = append(reviewers, pickReviewers(candidates, exclude(), required-len(reviewers), rule)...)
+	}
+	if len(reviewers) < required {
+		borrowed, err := s.pickFromFallbackTeams(ctx, r, author.TeamName, exclude(), required-len(reviewers), sel, rule)
+		if err != nil {
+			return a, err
+		}
+		a.borrow(borrowed)
+		reviewers = append(reviewers, borrowed...)
 	}
 
-	return &created, nil
+	a.reviewers = reviewers
+	a.understaffed = len(reviewers) < required || uncovered > 0 || rule.pending()
+	return a, nil
 }
 
-func (s *pullRequestService) Merge(ctx context.Context, prID string) (*domain.PullRequest, error) {
+func (s *pullRequestService) GetPullRequest(ctx context.Context, prID string) (*domain.PullRequest, error) {
 	pr, err := s.prs.GetPullRequestByID(ctx, prID)
 	if err != nil {
 		if derr, ok := domain.AsDomainError(err); ok && derr.Code == domain.ErrorCodeNotFound {
@@ -89,76 +261,149 @@ func (s *pullRequestService) Merge(ctx context.Context, prID string) (*domain.Pu
 		}
 		return nil, err
 	}
+	return &pr, nil
+}
 
-	if pr.Status == domain.PullRequestStatusMerged {
-		return &pr, nil
-	}
-
-	merged, err := s.prs.MergePullRequest(ctx, prID, time.Now().UTC())
+// Merge is idempotent: merging a merged pull request returns it unchanged.
+func (s *pullRequestService) Merge(ctx context.Context, prID string, ifVersions []int64) (*domain.PullRequest, error) {
+	var (
+		merged    domain.PullRequest
+		wasMerged bool
+	)
+	err := s.uow.Do(ctx, func(tx repository.Tx) error {
+		pr, err := s.lockPullRequest(ctx, tx, prID, ifVersions)
+		if err != nil {
+			return err
+		}
+		if pr.Status == domain.PullRequestStatusMerged {
+			merged, wasMerged = pr, true
+			return nil
+		}
+		merged, err = tx.MergePullRequest(ctx, prID, s.now().UTC())
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if s.metrics != nil {
+	if s.metrics != nil && !wasMerged {
 		s.metrics.IncPRMerged()
 	}
 	return &merged, nil
 }
 
-func (s *pullRequestService) Reassign(ctx context.Context, prID, oldReviewerID string) (*domain.PullRequest, string, error) {
-	pr, err := s.prs.GetPullRequestByID(ctx, prID)
+// lockPullRequest reads the pull request for update and checks it against
+// the versions the caller expects.
+func (s *pullRequestService) lockPullRequest(ctx context.Context, tx repository.Tx, prID string, ifVersions []int64) (domain.PullRequest, error) {
+	pr, err := tx.GetPullRequestForUpdate(ctx, prID)
 	if err != nil {
 		if derr, ok := domain.AsDomainError(err); ok && derr.Code == domain.ErrorCodeNotFound {
-			return nil, "", s.reassignMetricErr("not_found", domain.NewDomainError(domain.ErrorCodeNotFound, "pull request not found"))
+			return domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "pull request not found")
 		}
-		return nil, "", s.reassignMetricErr("internal_error", err)
+		return domain.PullRequest{}, err
+	}
+	if len(ifVersions) > 0 && !slices.Contains(ifVersions, pr.Version) {
+		return domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeVersionMismatch, "pull request has been modified")
+	}
+	return pr, nil
+}
+
+// Reassign locks the pull request first, so concurrent reassignments of the
+// same pull request see each other's result instead of replacing one
+// reviewer twice.
+func (s *pullRequestService) Reassign(ctx context.Context, prID, oldReviewerID string, ifVersions []int64) (*domain.PullRequest, string, error) {
+	var (
+		updated   domain.PullRequest
+		candidate string
+	)
+	err := s.uow.Do(ctx, func(tx repository.Tx) error {
+		var err error
+		updated, candidate, err = s.reassign(ctx, tx, prID, oldReviewerID, ifVersions)
+		return err
+	})
+	if err != nil {
+		return nil, "", s.reassignMetricErr(reassignResult(err), err)
+	}
+
+	if s.metrics != nil {
+		s.metrics.IncPRReassign("success")
+	}
+
+	return &updated, candidate, nil
+}
+
+func (s *pullRequestService) reassign(ctx context.Context, tx repository.Tx, prID, oldReviewerID string, ifVersions []int64) (domain.PullRequest, string, error) {
+	r := s.txRepos(tx)
+	pr, err := s.lockPullRequest(ctx, tx, prID, ifVersions)
+	if err != nil {
+		return domain.PullRequest{}, "", err
 	}
 
 	if pr.Status == domain.PullRequestStatusMerged {
-		return nil, "", s.reassignMetricErr("pr_merged", domain.NewDomainError(domain.ErrorCodePRMerged, "cannot reassign on merged pull request"))
+		return domain.PullRequest{}, "", domain.NewDomainError(domain.ErrorCodePRMerged, "cannot reassign on merged pull request")
 	}
 
 	if !contains(pr.AssignedReviewers, oldReviewerID) {
-		return nil, "", s.reassignMetricErr("not_assigned", domain.NewDomainError(domain.ErrorCodeNotAssigned, "reviewer is not assigned to this pull request"))
+		return domain.PullRequest{}, "", domain.NewDomainError(domain.ErrorCodeNotAssigned, "reviewer is not assigned to this pull request")
 	}
 
-	oldReviewer, err := s.users.GetUserByID(ctx, oldReviewerID)
+	oldReviewer, err := r.users.GetUserByID(ctx, oldReviewerID)
 	if err != nil {
 		if derr, ok := domain.AsDomainError(err); ok && derr.Code == domain.ErrorCodeNotFound {
-			return nil, "", s.reassignMetricErr("not_found", domain.NewDomainError(domain.ErrorCodeNotFound, "reviewer not found"))
+			return domain.PullRequest{}, "", domain.NewDomainError(domain.ErrorCodeNotFound, "reviewer not found")
 		}
-		return nil, "", s.reassignMetricErr("internal_error", err)
+		return domain.PullRequest{}, "", err
 	}
 
-	candidate, err := s.pickReplacementCandidate(ctx, oldReviewer.TeamName, pr.AuthorID, pr.AssignedReviewers)
+	author, err := s.getAuthor(ctx, r, pr.AuthorID)
 	if err != nil {
-		code := "internal_error"
-		if derr, ok := domain.AsDomainError(err); ok && derr.Code == domain.ErrorCodeNoCandidate {
-			code = "no_candidate"
-		}
-		return nil, "", s.reassignMetricErr(code, err)
+		return domain.PullRequest{}, "", err
 	}
 
-	tx, err := s.uow.Begin(ctx)
+	rule, err := s.replacementRule(ctx, r, author.TeamName, oldReviewer, pr.AssignedReviewers)
 	if err != nil {
-		return nil, "", s.reassignMetricErr("internal_error", err)
+		return domain.PullRequest{}, "", err
 	}
-	defer tx.Rollback(ctx)
 
-	updated, err := tx.ReassignReviewer(ctx, prID, oldReviewerID, candidate)
+	sel := selection{authorID: pr.AuthorID, at: s.now().UTC(), tags: pr.Tags, policy: s.policy()}
+	candidate, fromFallback, err := s.pickReplacementCandidate(ctx, r, oldReviewer.TeamName, pr.AuthorID, pr.AssignedReviewers, sel, rule)
 	if err != nil {
-		return nil, "", s.reassignMetricErr("internal_error", err)
+		return domain.PullRequest{}, "", err
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return nil, "", s.reassignMetricErr("internal_error", err)
+	// The replacement is borrowed when it comes from a fallback team or takes
+	// over a borrowed seat, unless it belongs to the author's team.
+	sourceTeam := ""
+	if _, borrowedSeat := pr.CrossTeamReviewers[oldReviewerID]; (fromFallback || borrowedSeat) && candidate.TeamName != author.TeamName {
+		sourceTeam = candidate.TeamName
 	}
 
-	if s.metrics != nil {
-		s.metrics.IncPRReassign("success")
+	updated, err := tx.ReassignReviewer(ctx, prID, oldReviewerID, candidate.ID, sourceTeam)
+	if err != nil {
+		return domain.PullRequest{}, "", err
 	}
+	return updated, candidate.ID, nil
+}
 
-	return &updated, candidate, nil
+// reassignResult labels a failed reassignment for the reassign metric.
+func reassignResult(err error) string {
+	derr, ok := domain.AsDomainError(err)
+	if !ok {
+		return "internal_error"
+	}
+	switch derr.Code {
+	case domain.ErrorCodeNotFound:
+		return "not_found"
+	case domain.ErrorCodePRMerged:
+		return "pr_merged"
+	case domain.ErrorCodeNotAssigned:
+		return "not_assigned"
+	case domain.ErrorCodeNoCandidate:
+		return "no_candidate"
+	case domain.ErrorCodeVersionMismatch:
+		return "version_mismatch"
+	}
+	return "internal_error"
 }
 
 func (s *pullRequestService) reassignMetricErr(result string, err error) error {
@@ -168,42 +413,383 @@ func (s *pullRequestService) reassignMetricErr(result string, err error) error {
 	return err
 }
 
-func pickReviewers(users []domain.User, authorID string, limit int) []string {
-	var reviewers []string
+// seniorityRule tracks a team's senior reviewer policy while reviewers are
+// picked from several candidate lists for one pull request. A nil rule means
+// the policy is off.
+type seniorityRule struct {
+	required bool
+	// covered is set once a senior is among the reviewers.
+	covered bool
+	// strict accepts nothing but a senior while the rule is pending.
+	strict bool
+}
+
+func (r *seniorityRule) pending() bool {
+	return r != nil && r.required && !r.covered
+}
+
+func (r *seniorityRule) observe(u domain.User) {
+	if r != nil && u.Seniority == domain.SenioritySenior {
+		r.covered = true
+	}
+}
+
+// pickReviewers picks up to limit users that are not excluded. While the
+// seniority rule is pending the first pick is a senior, and juniors only join
+// as learning reviewers once a senior is in place.
+func pickReviewers(users []domain.User, exclude []string, limit int, rule *seniorityRule) []domain.User {
+	var picked []domain.User
+	eligible := func(u domain.User) bool {
+		return !contains(exclude, u.ID) && !containsUser(picked, u.ID)
+	}
+
+	if rule.pending() && limit > 0 {
+		for _, u := range users {
+			if eligible(u) && u.Seniority == domain.SenioritySenior {
+				picked = append(picked, u)
+				rule.observe(u)
+				break
+			}
+		}
+	}
+
 	for _, u := range users {
-		if u.ID == authorID {
+		if len(picked) >= limit {
+			break
+		}
+		if !eligible(u) {
+			continue
+		}
+		if rule.pending() && (rule.strict || u.Seniority == domain.SeniorityJunior) {
 			continue
 		}
-		reviewers = append(reviewers, u.ID)
-		if len(reviewers) == limit {
+		picked = append(picked, u)
+	}
+	return picked
+}
+
+// selection carries the per-request inputs that shape candidate ranking.
+type selection struct {
+	authorID string
+	at       time.Time
+	tags     []string
+	policy   SelectionPolicy
+}
+
+// availableCandidates lists active team members that are not out of office
+// and still have review capacity, ranked by the selection policy.
+func (s *pullRequestService) availableCandidates(ctx context.Context, r repos, teamName string, sel selection) ([]domain.User, error) {
+	users, err := r.users.ListActiveByTeam(ctx, teamName, sel.at)
+	if err != nil {
+		return nil, err
+	}
+	users, err = s.withSpareCapacity(ctx, r, s.rankCandidates(users, sel))
+	if err != nil {
+		return nil, err
+	}
+	users, err = s.rankByPairingHistory(ctx, r, users, sel)
+	if err != nil {
+		return nil, err
+	}
+	return s.rankByTags(ctx, r, users, sel.tags)
+}
+
+// pickFromFallbackTeams borrows up to limit reviewers from the team's fallback
+// teams, walking them in priority order.
+func (s *pullRequestService) pickFromFallbackTeams(ctx context.Context, r repos, teamName string, exclude []string, limit int, sel selection, rule *seniorityRule) ([]domain.User, error) {
+	fallbacks, err := r.teams.ListFallbackTeams(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	var picked []domain.User
+	for _, fallback := range fallbacks {
+		candidates, err := s.availableCandidates(ctx, r, fallback, sel)
+		if err != nil {
+			return nil, err
+		}
+		more := pickReviewers(candidates, append(exclude, userIDs(picked)...), limit-len(picked), rule)
+		picked = append(picked, more...)
+		if len(picked) == limit {
 			break
 		}
 	}
-	return reviewers
+	return picked, nil
 }
 
-func (s *pullRequestService) pickReplacementCandidate(ctx context.Context, teamName, authorID string, currentReviewers []string) (string, error) {
-	users, err := s.users.ListActiveByTeam(ctx, teamName)
+// pickCodeOwners picks one available owner for every area the changed paths
+// touch, reusing an owner that already covers an earlier area. It also returns
+// how many areas had no available owner.
+func (s *pullRequestService) pickCodeOwners(ctx context.Context, r repos, authorID string, paths []string, sel selection) ([]domain.User, int, error) {
+	if r.codeOwners == nil || len(paths) == 0 {
+		return nil, 0, nil
+	}
+
+	rules, err := r.codeOwners.ListCodeOwnerRules(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	matcher, err := codeowners.NewMatcher(rules)
 	if err != nil {
-		return "", err
+		return nil, 0, err
+	}
+
+	byTeam := make(map[string][]domain.User)
+	var (
+		picked    []domain.User
+		uncovered int
+	)
+	for _, area := range matcher.Areas(paths) {
+		owners, err := s.ownerCandidates(ctx, r, area.Owners, byTeam, sel)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		covered := false
+		for _, u := range owners {
+			if containsUser(picked, u.ID) {
+				covered = true
+				break
+			}
+		}
+		if covered {
+			continue
+		}
+
+		if next := pickReviewers(owners, append([]string{authorID}, userIDs(picked)...), 1, nil); len(next) == 1 {
+			picked = append(picked, next[0])
+		} else {
+			uncovered++
+		}
 	}
+	return picked, uncovered, nil
+}
 
-	current := make(map[string]struct{}, len(currentReviewers))
-	for _, id := range currentReviewers {
-		current[id] = struct{}{}
+// ownerCandidates expands owner tokens into available users. Candidates are
+// cached per team for the duration of one assignment.
+func (s *pullRequestService) ownerCandidates(ctx context.Context, r repos, tokens []string, byTeam map[string][]domain.User, sel selection) ([]domain.User, error) {
+	teamCandidates := func(teamName string) ([]domain.User, error) {
+		if cached, ok := byTeam[teamName]; ok {
+			return cached, nil
+		}
+		candidates, err := s.availableCandidates(ctx, r, teamName, sel)
+		if err != nil {
+			return nil, err
+		}
+		byTeam[teamName] = candidates
+		return candidates, nil
 	}
 
+	var owners []domain.User
+	for _, token := range tokens {
+		owner, err := codeowners.ParseOwner(token)
+		if err != nil {
+			continue
+		}
+
+		if owner.TeamName != "" {
+			candidates, err := teamCandidates(owner.TeamName)
+			if err != nil {
+				return nil, err
+			}
+			owners = append(owners, candidates...)
+			continue
+		}
+
+		user, err := r.users.GetUserByID(ctx, owner.UserID)
+		if err != nil {
+			if derr, ok := domain.AsDomainError(err); ok && derr.Code == domain.ErrorCodeNotFound {
+				continue
+			}
+			return nil, err
+		}
+		candidates, err := teamCandidates(user.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range candidates {
+			if u.ID == owner.UserID {
+				owners = append(owners, u)
+			}
+		}
+	}
+	return owners, nil
+}
+
+// rankCandidates orders candidates according to the selection policy. The
+// ordering is stable, so users that rank equally keep the repository order.
+func (s *pullRequestService) rankCandidates(users []domain.User, sel selection) []domain.User {
+	if !sel.policy.PreferWorkingHours {
+		return users
+	}
+
+	ranked := make([]domain.User, 0, len(users))
+	var offHours []domain.User
 	for _, u := range users {
-		if u.ID == authorID {
+		if u.InWorkingHours(sel.at) {
+			ranked = append(ranked, u)
+		} else {
+			offHours = append(offHours, u)
+		}
+	}
+	return append(ranked, offHours...)
+}
+
+// rankByPairingHistory moves reviewers that reviewed the author less often
+// within the pairing window ahead. Tag ranking runs afterwards, so expertise
+// still wins over spreading pairs.
+func (s *pullRequestService) rankByPairingHistory(ctx context.Context, r repos, users []domain.User, sel selection) ([]domain.User, error) {
+	if sel.policy.PairingWindow <= 0 || sel.authorID == "" || len(users) < 2 {
+		return users, nil
+	}
+
+	pairings, err := r.prs.CountRecentPairings(ctx, sel.authorID, userIDs(users), sel.at.Add(-sel.policy.PairingWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := slices.Clone(users)
+	slices.SortStableFunc(ranked, func(a, b domain.User) int {
+		return pairings[a.ID] - pairings[b.ID]
+	})
+	return ranked, nil
+}
+
+// rankByTags moves candidates sharing more tags with the pull request ahead.
+// Candidates without any overlap stay in the list as general reviewers.
+func (s *pullRequestService) rankByTags(ctx context.Context, r repos, users []domain.User, tags []string) ([]domain.User, error) {
+	if len(tags) == 0 || len(users) < 2 {
+		return users, nil
+	}
+
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	userTags, err := r.users.ListUserTags(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	overlap := make(map[string]int, len(users))
+	for _, u := range users {
+		for _, tag := range userTags[u.ID] {
+			if contains(tags, tag) {
+				overlap[u.ID]++
+			}
+		}
+	}
+
+	ranked := slices.Clone(users)
+	slices.SortStableFunc(ranked, func(a, b domain.User) int {
+		return overlap[b.ID] - overlap[a.ID]
+	})
+	return ranked, nil
+}
+
+// withSpareCapacity drops users whose open reviews already reach their
+// capacity.
+func (s *pullRequestService) withSpareCapacity(ctx context.Context, r repos, users []domain.User) ([]domain.User, error) {
+	if len(users) == 0 {
+		return users, nil
+	}
+
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	load, err := r.users.GetReviewLoad(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	available := make([]domain.User, 0, len(users))
+	for _, u := range users {
+		if l, ok := load[u.ID]; ok && !l.HasSpareCapacity() {
 			continue
 		}
-		if _, exists := current[u.ID]; exists {
+		available = append(available, u)
+	}
+	return available, nil
+}
+
+// replacementRule builds the seniority rule for replacing oldReviewer under
+// the policy of authorTeam, the same policy assign applied, so a reviewer
+// borrowed from a fallback team is held to the author's team rules. Only a
+// senior may take over from the last senior reviewer.
+func (s *pullRequestService) replacementRule(ctx context.Context, r repos, authorTeam string, oldReviewer domain.User, reviewers []string) (*seniorityRule, error) {
+	policy, err := r.teams.GetTeamPolicy(ctx, authorTeam)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.RequireSeniorReviewer {
+		return nil, nil
+	}
+
+	rule := &seniorityRule{required: true}
+	for _, id := range reviewers {
+		if id == oldReviewer.ID {
 			continue
 		}
-		return u.ID, nil
+		u, err := r.users.GetUserByID(ctx, id)
+		if err != nil {
+			if derr, ok := domain.AsDomainError(err); ok && derr.Code == domain.ErrorCodeNotFound {
+				continue
+			}
+			return nil, err
+		}
+		rule.observe(u)
+	}
+	rule.strict = oldReviewer.Seniority == domain.SenioritySenior && !rule.covered
+	return rule, nil
+}
+
+// pickReplacementCandidate picks a replacement from teamName or, failing
+// that, from its fallback teams, and reports whether it came from a fallback
+// team.
+func (s *pullRequestService) pickReplacementCandidate(ctx context.Context, r repos, teamName, authorID string, currentReviewers []string, sel selection, rule *seniorityRule) (_ domain.User, fromFallback bool, err error) {
+	ctx, span := tracing.Start(ctx, "select replacement")
+	defer func() { tracing.End(span, err) }()
+
+	candidates, err := s.availableCandidates(ctx, r, teamName, sel)
+	if err != nil {
+		return domain.User{}, false, err
+	}
+
+	exclude := append([]string{authorID}, currentReviewers...)
+	if picked := pickReviewers(candidates, exclude, 1, rule); len(picked) == 1 {
+		return picked[0], false, nil
+	}
+
+	borrowed, err := s.pickFromFallbackTeams(ctx, r, teamName, exclude, 1, sel, rule)
+	if err != nil {
+		return domain.User{}, false, err
+	}
+	if len(borrowed) == 1 {
+		return borrowed[0], true, nil
+	}
+
+	if rule.pending() && rule.strict {
+		return domain.User{}, false, domain.NewDomainError(domain.ErrorCodeNoCandidate, "no senior replacement candidate: cannot remove the last senior reviewer")
+	}
+	return domain.User{}, false, domain.NewDomainError(domain.ErrorCodeNoCandidate, "no active replacement candidate in team")
+}
+
+func containsUser(users []domain.User, id string) bool {
+	for _, u := range users {
+		if u.ID == id {
+			return true
+		}
 	}
+	return false
+}
 
-	return "", domain.NewDomainError(domain.ErrorCodeNoCandidate, "no active replacement candidate in team")
+func userIDs(users []domain.User) []string {
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	return ids
 }
 
 func contains(list []string, value string) bool {
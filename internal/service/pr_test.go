@@ -3,11 +3,15 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
 	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/repository"
 	repoMocks "pr-reviewer/mocks/repository"
 )
 
@@ -26,14 +30,63 @@ func (m *metricsStub) IncPRReassign(result string) {
 	m.reassigns[result]++
 }
 
-func TestPullRequestService_Create_PRExists(t *testing.T) {
-	prRepo := repoMocks.NewMockPullRequestRepository(t)
-	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1"}, nil)
-	userRepo := repoMocks.NewMockUserRepository(t)
+func defaultTeams(t *testing.T) *repoMocks.MockTeamRepository {
+	teams := repoMocks.NewMockTeamRepository(t)
+	expectDefaultTeamPolicy(&teams.Mock)
+	return teams
+}
+
+// expectDefaultTeamPolicy lets every team go without a policy and without
+// fallback teams.
+func expectDefaultTeamPolicy(m *mock.Mock) {
+	m.On("GetTeamPolicy", mock.Anything, mock.Anything).Return(domain.TeamPolicy{}, nil).Maybe()
+	m.On("ListFallbackTeams", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+}
+
+// newUnitOfWork returns a unit of work whose Do runs through the mocked
+// Begin, Commit and Rollback.
+func newUnitOfWork(t *testing.T) *repoMocks.MockUnitOfWork {
 	uow := repoMocks.NewMockUnitOfWork(t)
+	uow.On("Do", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(repository.Tx) error) error {
+		return repository.RunTx(ctx, uow, fn)
+	}).Maybe()
+	return uow
+}
+
+// newTx returns a transaction mock and a unit of work handing it out. Create
+// and Reassign read and write through it.
+func newTx(t *testing.T) (*repoMocks.MockTx, *repoMocks.MockUnitOfWork) {
+	tx := repoMocks.NewMockTx(t)
+	tx.On("Rollback", mock.Anything).Return(nil).Maybe()
+	uow := newUnitOfWork(t)
+	uow.On("Begin", mock.Anything).Return(tx, nil).Maybe()
+	return tx, uow
+}
+
+// newTxService builds a service whose own repositories expect no calls, so
+// everything Create and Reassign read must come from the transaction.
+func newTxService(t *testing.T, uow repository.UnitOfWork, metrics *metricsStub, opts ...PullRequestServiceOption) PullRequestService {
+	return NewPullRequestService(repoMocks.NewMockPullRequestRepository(t), repoMocks.NewMockUserRepository(t), repoMocks.NewMockTeamRepository(t), uow, metrics, opts...)
+}
+
+func TestPullRequestService_GetPullRequest_NotFound(t *testing.T) {
+	prRepo := repoMocks.NewMockPullRequestRepository(t)
+	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
+	svc := NewPullRequestService(prRepo, repoMocks.NewMockUserRepository(t), repoMocks.NewMockTeamRepository(t), newUnitOfWork(t), &metricsStub{})
+
+	_, err := svc.GetPullRequest(context.Background(), "pr1")
+	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeNotFound {
+		t.Fatalf("expected NOT_FOUND, got %v", err)
+	}
+}
+
+func TestPullRequestService_Create_PRExists(t *testing.T) {
+	tx, uow := newTx(t)
+	expectDefaultTeamPolicy(&tx.Mock)
+	tx.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1"}, nil)
 	metrics := &metricsStub{}
 
-	svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+	svc := newTxService(t, uow, metrics)
 	_, err := svc.Create(context.Background(), domain.PullRequest{ID: "pr1"})
 	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodePRExists {
 		t.Fatalf("expected PR_EXISTS error, got %v", err)
@@ -41,13 +94,12 @@ func TestPullRequestService_Create_PRExists(t *testing.T) {
 }
 
 func TestPullRequestService_Create_AuthorNotFound(t *testing.T) {
-	prRepo := repoMocks.NewMockPullRequestRepository(t)
-	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "missing"))
-	userRepo := repoMocks.NewMockUserRepository(t)
-	userRepo.On("GetUserByID", mock.Anything, "u1").Return(domain.User{}, domain.NewDomainError(domain.ErrorCodeNotFound, "no author"))
-	uow := repoMocks.NewMockUnitOfWork(t)
+	tx, uow := newTx(t)
+	expectDefaultTeamPolicy(&tx.Mock)
+	tx.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "missing"))
+	tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{}, domain.NewDomainError(domain.ErrorCodeNotFound, "no author"))
 	metrics := &metricsStub{}
-	svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+	svc := newTxService(t, uow, metrics)
 
 	_, err := svc.Create(context.Background(), domain.PullRequest{ID: "pr1", AuthorID: "u1"})
 	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeNotFound || derr.Message != "author not found" {
@@ -68,25 +120,21 @@ func TestPullRequestService_Create_ReviewerSelection(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			prRepo := repoMocks.NewMockPullRequestRepository(t)
-			prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
+			tx, uow := newTx(t)
+			expectDefaultTeamPolicy(&tx.Mock)
+			tx.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
 
-			userRepo := repoMocks.NewMockUserRepository(t)
-			userRepo.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
-			userRepo.On("ListActiveByTeam", mock.Anything, "t").Return(tt.activeUsers, nil)
+			tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+			tx.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return(tt.activeUsers, nil)
+			tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{}, nil).Maybe()
 
-			tx := repoMocks.NewMockTx(t)
 			tx.On("CreatePullRequest", mock.Anything, mock.AnythingOfType("domain.PullRequest")).Return(func(_ context.Context, pr domain.PullRequest) domain.PullRequest {
 				return pr
 			}, nil)
 			tx.On("Commit", mock.Anything).Return(nil)
-			tx.On("Rollback", mock.Anything).Return(nil)
-
-			uow := repoMocks.NewMockUnitOfWork(t)
-			uow.On("Begin", mock.Anything).Return(tx, nil)
 
 			metrics := &metricsStub{}
-			svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+			svc := newTxService(t, uow, metrics)
 
 			pr, err := svc.Create(context.Background(), domain.PullRequest{ID: "pr1", AuthorID: "u1"})
 			if err != nil {
@@ -107,17 +155,37 @@ func TestPullRequestService_Create_ReviewerSelection(t *testing.T) {
 	}
 }
 
-func TestPullRequestService_Create_BeginError(t *testing.T) {
-	prRepo := repoMocks.NewMockPullRequestRepository(t)
-	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
-	userRepo := repoMocks.NewMockUserRepository(t)
-	userRepo.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
-	userRepo.On("ListActiveByTeam", mock.Anything, "t").Return([]domain.User{}, nil)
+func TestPullRequestService_Create_ListsCandidatesAtAssignmentTime(t *testing.T) {
+	tx, uow := newTx(t)
+	expectDefaultTeamPolicy(&tx.Mock)
+	tx.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
 
-	uow := repoMocks.NewMockUnitOfWork(t)
+	var listedAt time.Time
+	tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+	tx.On("ListActiveByTeam", mock.Anything, "t", mock.AnythingOfType("time.Time")).Run(func(args mock.Arguments) {
+		listedAt = args.Get(2).(time.Time)
+	}).Return([]domain.User{{ID: "u2", TeamName: "t"}}, nil)
+	tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{}, nil)
+
+	tx.On("CreatePullRequest", mock.Anything, mock.AnythingOfType("domain.PullRequest")).Return(func(_ context.Context, pr domain.PullRequest) domain.PullRequest {
+		return pr
+	}, nil)
+	tx.On("Commit", mock.Anything).Return(nil)
+
+	svc := newTxService(t, uow, &metricsStub{})
+	pr, err := svc.Create(context.Background(), domain.PullRequest{ID: "pr1", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if listedAt.IsZero() || !listedAt.Equal(pr.CreatedAt) {
+		t.Fatalf("expected candidates listed at creation time %v, got %v", pr.CreatedAt, listedAt)
+	}
+}
+
+func TestPullRequestService_Create_BeginError(t *testing.T) {
+	uow := newUnitOfWork(t)
 	uow.On("Begin", mock.Anything).Return(nil, errors.New("begin fail"))
-	metrics := &metricsStub{}
-	svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+	svc := newTxService(t, uow, &metricsStub{})
 
 	_, err := svc.Create(context.Background(), domain.PullRequest{ID: "pr1", AuthorID: "u1"})
 	if err == nil || err.Error() != "begin fail" {
@@ -126,20 +194,16 @@ func TestPullRequestService_Create_BeginError(t *testing.T) {
 }
 
 func TestPullRequestService_Create_CreateError(t *testing.T) {
-	prRepo := repoMocks.NewMockPullRequestRepository(t)
-	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
-	userRepo := repoMocks.NewMockUserRepository(t)
-	userRepo.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
-	userRepo.On("ListActiveByTeam", mock.Anything, "t").Return([]domain.User{}, nil)
+	tx, uow := newTx(t)
+	expectDefaultTeamPolicy(&tx.Mock)
+	tx.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
+	tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+	tx.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return([]domain.User{}, nil)
 
-	tx := repoMocks.NewMockTx(t)
 	tx.On("CreatePullRequest", mock.Anything, mock.AnythingOfType("domain.PullRequest")).Return(domain.PullRequest{}, errors.New("create fail"))
-	tx.On("Rollback", mock.Anything).Return(nil)
-	uow := repoMocks.NewMockUnitOfWork(t)
-	uow.On("Begin", mock.Anything).Return(tx, nil)
 
 	metrics := &metricsStub{}
-	svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+	svc := newTxService(t, uow, metrics)
 
 	_, err := svc.Create(context.Background(), domain.PullRequest{ID: "pr1", AuthorID: "u1"})
 	if err == nil || err.Error() != "create fail" {
@@ -148,23 +212,19 @@ func TestPullRequestService_Create_CreateError(t *testing.T) {
 }
 
 func TestPullRequestService_Create_CommitError(t *testing.T) {
-	prRepo := repoMocks.NewMockPullRequestRepository(t)
-	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
-	userRepo := repoMocks.NewMockUserRepository(t)
-	userRepo.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
-	userRepo.On("ListActiveByTeam", mock.Anything, "t").Return([]domain.User{}, nil)
+	tx, uow := newTx(t)
+	expectDefaultTeamPolicy(&tx.Mock)
+	tx.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
+	tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+	tx.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return([]domain.User{}, nil)
 
-	tx := repoMocks.NewMockTx(t)
 	tx.On("CreatePullRequest", mock.Anything, mock.AnythingOfType("domain.PullRequest")).Return(func(_ context.Context, pr domain.PullRequest) domain.PullRequest {
 		return pr
 	}, nil)
 	tx.On("Commit", mock.Anything).Return(errors.New("commit fail"))
-	tx.On("Rollback", mock.Anything).Return(nil)
-	uow := repoMocks.NewMockUnitOfWork(t)
-	uow.On("Begin", mock.Anything).Return(tx, nil)
 
 	metrics := &metricsStub{}
-	svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+	svc := newTxService(t, uow, metrics)
 
 	_, err := svc.Create(context.Background(), domain.PullRequest{ID: "pr1", AuthorID: "u1"})
 	if err == nil || err.Error() != "commit fail" {
@@ -173,166 +233,186 @@ func TestPullRequestService_Create_CommitError(t *testing.T) {
 }
 
 func TestPullRequestService_Merge_NotFound(t *testing.T) {
-	prRepo := repoMocks.NewMockPullRequestRepository(t)
-	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "missing"))
-	userRepo := repoMocks.NewMockUserRepository(t)
-	uow := repoMocks.NewMockUnitOfWork(t)
+	tx, uow := newTx(t)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "missing"))
 	metrics := &metricsStub{}
-	svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+	svc := newTxService(t, uow, metrics)
 
-	_, err := svc.Merge(context.Background(), "pr1")
+	_, err := svc.Merge(context.Background(), "pr1", nil)
 	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeNotFound {
 		t.Fatalf("expected not found error, got %v", err)
 	}
 }
 
 func TestPullRequestService_Merge_AlreadyMerged(t *testing.T) {
-	prRepo := repoMocks.NewMockPullRequestRepository(t)
-	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusMerged}, nil)
-	userRepo := repoMocks.NewMockUserRepository(t)
-	uow := repoMocks.NewMockUnitOfWork(t)
+	tx, uow := newTx(t)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusMerged, Version: 2}, nil)
+	tx.On("Commit", mock.Anything).Return(nil)
 	metrics := &metricsStub{}
-	svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+	svc := newTxService(t, uow, metrics)
 
-	pr, err := svc.Merge(context.Background(), "pr1")
+	pr, err := svc.Merge(context.Background(), "pr1", []int64{2})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if pr.Status != domain.PullRequestStatusMerged {
 		t.Fatalf("expected merged status")
 	}
-	prRepo.AssertNotCalled(t, "MergePullRequest", mock.Anything, mock.Anything, mock.Anything)
+	tx.AssertNotCalled(t, "MergePullRequest", mock.Anything, mock.Anything, mock.Anything)
+	if metrics.merged != 0 {
+		t.Fatalf("expected no merged metric increment, got %d", metrics.merged)
+	}
 }
 
 func TestPullRequestService_Merge_Success(t *testing.T) {
-	prRepo := repoMocks.NewMockPullRequestRepository(t)
-	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen}, nil)
-	prRepo.On("MergePullRequest", mock.Anything, "pr1", mock.Anything).Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusMerged}, nil)
-	userRepo := repoMocks.NewMockUserRepository(t)
-	uow := repoMocks.NewMockUnitOfWork(t)
+	tx, uow := newTx(t)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, Version: 1}, nil)
+	tx.On("MergePullRequest", mock.Anything, "pr1", mock.Anything).Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusMerged, Version: 2}, nil)
+	tx.On("Commit", mock.Anything).Return(nil)
 	metrics := &metricsStub{}
-	svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+	svc := newTxService(t, uow, metrics)
 
-	pr, err := svc.Merge(context.Background(), "pr1")
+	// Any of the expected versions may match.
+	pr, err := svc.Merge(context.Background(), "pr1", []int64{4, 1})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if pr.Status != domain.PullRequestStatusMerged {
-		t.Fatalf("expected merged status")
+	if pr.Status != domain.PullRequestStatusMerged || pr.Version != 2 {
+		t.Fatalf("expected merged pull request at version 2, got %+v", pr)
 	}
-	prRepo.AssertCalled(t, "MergePullRequest", mock.Anything, "pr1", mock.Anything)
 	if metrics.merged != 1 {
 		t.Fatalf("expected merged metric increment")
 	}
 }
 
+func TestPullRequestService_Merge_VersionMismatch(t *testing.T) {
+	tx, uow := newTx(t)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusMerged, Version: 3}, nil)
+	metrics := &metricsStub{}
+	svc := newTxService(t, uow, metrics)
+
+	_, err := svc.Merge(context.Background(), "pr1", []int64{1, 2})
+	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeVersionMismatch {
+		t.Fatalf("expected VERSION_MISMATCH, got %v", err)
+	}
+	tx.AssertNotCalled(t, "Commit", mock.Anything)
+}
+
 func TestPullRequestService_Merge_Error(t *testing.T) {
-	prRepo := repoMocks.NewMockPullRequestRepository(t)
-	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen}, nil)
-	prRepo.On("MergePullRequest", mock.Anything, "pr1", mock.Anything).Return(domain.PullRequest{}, errors.New("merge fail"))
-	userRepo := repoMocks.NewMockUserRepository(t)
-	uow := repoMocks.NewMockUnitOfWork(t)
+	tx, uow := newTx(t)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen}, nil)
+	tx.On("MergePullRequest", mock.Anything, "pr1", mock.Anything).Return(domain.PullRequest{}, errors.New("merge fail"))
 	metrics := &metricsStub{}
-	svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+	svc := newTxService(t, uow, metrics)
 
-	_, err := svc.Merge(context.Background(), "pr1")
+	_, err := svc.Merge(context.Background(), "pr1", nil)
 	if err == nil || err.Error() != "merge fail" {
 		t.Fatalf("expected merge fail, got %v", err)
 	}
 }
 
 func TestPullRequestService_Reassign_NotFound(t *testing.T) {
-	prRepo := repoMocks.NewMockPullRequestRepository(t)
-	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "missing"))
-	userRepo := repoMocks.NewMockUserRepository(t)
-	uow := repoMocks.NewMockUnitOfWork(t)
+	tx, uow := newTx(t)
+	expectDefaultTeamPolicy(&tx.Mock)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "missing"))
 	metrics := &metricsStub{}
-	svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+	svc := newTxService(t, uow, metrics)
 
-	_, _, err := svc.Reassign(context.Background(), "pr1", "u2")
+	_, _, err := svc.Reassign(context.Background(), "pr1", "u2", nil)
 	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeNotFound {
 		t.Fatalf("expected not found, got %v", err)
 	}
 }
 
 func TestPullRequestService_Reassign_Merged(t *testing.T) {
-	prRepo := repoMocks.NewMockPullRequestRepository(t)
-	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusMerged}, nil)
-	userRepo := repoMocks.NewMockUserRepository(t)
-	uow := repoMocks.NewMockUnitOfWork(t)
+	tx, uow := newTx(t)
+	expectDefaultTeamPolicy(&tx.Mock)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusMerged}, nil)
 	metrics := &metricsStub{}
-	svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+	svc := newTxService(t, uow, metrics)
 
-	_, _, err := svc.Reassign(context.Background(), "pr1", "u2")
+	_, _, err := svc.Reassign(context.Background(), "pr1", "u2", nil)
 	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodePRMerged {
 		t.Fatalf("expected pr merged, got %v", err)
 	}
 }
 
+func TestPullRequestService_Reassign_VersionMismatch(t *testing.T) {
+	tx, uow := newTx(t)
+	expectDefaultTeamPolicy(&tx.Mock)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AssignedReviewers: []string{"u2"}, Version: 4}, nil)
+	metrics := &metricsStub{}
+	svc := newTxService(t, uow, metrics)
+
+	_, _, err := svc.Reassign(context.Background(), "pr1", "u2", []int64{3})
+	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeVersionMismatch {
+		t.Fatalf("expected VERSION_MISMATCH, got %v", err)
+	}
+	if metrics.reassigns["version_mismatch"] != 1 {
+		t.Fatalf("expected version_mismatch metric, got %v", metrics.reassigns)
+	}
+}
+
 func TestPullRequestService_Reassign_NotAssigned(t *testing.T) {
-	prRepo := repoMocks.NewMockPullRequestRepository(t)
-	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AssignedReviewers: []string{"u3"}}, nil)
-	userRepo := repoMocks.NewMockUserRepository(t)
-	uow := repoMocks.NewMockUnitOfWork(t)
+	tx, uow := newTx(t)
+	expectDefaultTeamPolicy(&tx.Mock)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AssignedReviewers: []string{"u3"}}, nil)
 	metrics := &metricsStub{}
-	svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+	svc := newTxService(t, uow, metrics)
 
-	_, _, err := svc.Reassign(context.Background(), "pr1", "u2")
+	_, _, err := svc.Reassign(context.Background(), "pr1", "u2", nil)
 	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeNotAssigned {
 		t.Fatalf("expected not assigned, got %v", err)
 	}
 }
 
 func TestPullRequestService_Reassign_ReviewerNotFound(t *testing.T) {
-	prRepo := repoMocks.NewMockPullRequestRepository(t)
-	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AssignedReviewers: []string{"u2"}}, nil)
-	userRepo := repoMocks.NewMockUserRepository(t)
-	userRepo.On("GetUserByID", mock.Anything, "u2").Return(domain.User{}, domain.NewDomainError(domain.ErrorCodeNotFound, "no reviewer"))
-	uow := repoMocks.NewMockUnitOfWork(t)
+	tx, uow := newTx(t)
+	expectDefaultTeamPolicy(&tx.Mock)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AssignedReviewers: []string{"u2"}}, nil)
+	tx.On("GetUserByID", mock.Anything, "u2").Return(domain.User{}, domain.NewDomainError(domain.ErrorCodeNotFound, "no reviewer"))
 	metrics := &metricsStub{}
-	svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+	svc := newTxService(t, uow, metrics)
 
-	_, _, err := svc.Reassign(context.Background(), "pr1", "u2")
+	_, _, err := svc.Reassign(context.Background(), "pr1", "u2", nil)
 	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeNotFound || derr.Message != "reviewer not found" {
 		t.Fatalf("expected reviewer not found, got %v", err)
 	}
 }
 
 func TestPullRequestService_Reassign_NoCandidate(t *testing.T) {
-	prRepo := repoMocks.NewMockPullRequestRepository(t)
-	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil)
-	userRepo := repoMocks.NewMockUserRepository(t)
-	userRepo.On("GetUserByID", mock.Anything, "u2").Return(domain.User{ID: "u2", TeamName: "t"}, nil)
-	userRepo.On("ListActiveByTeam", mock.Anything, "t").Return([]domain.User{{ID: "u1", TeamName: "t"}, {ID: "u2", TeamName: "t"}}, nil)
-	uow := repoMocks.NewMockUnitOfWork(t)
+	tx, uow := newTx(t)
+	expectDefaultTeamPolicy(&tx.Mock)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil)
+	tx.On("GetUserByID", mock.Anything, "u2").Return(domain.User{ID: "u2", TeamName: "t"}, nil)
+	tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+	tx.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return([]domain.User{{ID: "u1", TeamName: "t"}, {ID: "u2", TeamName: "t"}}, nil)
+	tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{}, nil)
 	metrics := &metricsStub{}
-	svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+	svc := newTxService(t, uow, metrics)
 
-	_, _, err := svc.Reassign(context.Background(), "pr1", "u2")
+	_, _, err := svc.Reassign(context.Background(), "pr1", "u2", nil)
 	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeNoCandidate {
 		t.Fatalf("expected no candidate, got %v", err)
 	}
 }
 
 func TestPullRequestService_Reassign_Success(t *testing.T) {
-	prRepo := repoMocks.NewMockPullRequestRepository(t)
-	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil)
-	userRepo := repoMocks.NewMockUserRepository(t)
-	userRepo.On("GetUserByID", mock.Anything, "u2").Return(domain.User{ID: "u2", TeamName: "t"}, nil)
-	userRepo.On("ListActiveByTeam", mock.Anything, "t").Return([]domain.User{{ID: "u1", TeamName: "t"}, {ID: "u2", TeamName: "t"}, {ID: "u3", TeamName: "t"}}, nil)
-
-	tx := repoMocks.NewMockTx(t)
-	tx.On("ReassignReviewer", mock.Anything, "pr1", "u2", mock.Anything).Return(domain.PullRequest{ID: "pr1", AssignedReviewers: []string{"u3"}}, nil)
+	tx, uow := newTx(t)
+	expectDefaultTeamPolicy(&tx.Mock)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil)
+	tx.On("GetUserByID", mock.Anything, "u2").Return(domain.User{ID: "u2", TeamName: "t"}, nil)
+	tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+	tx.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return([]domain.User{{ID: "u1", TeamName: "t"}, {ID: "u2", TeamName: "t"}, {ID: "u3", TeamName: "t"}}, nil)
+	tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{}, nil)
+
+	tx.On("ReassignReviewer", mock.Anything, "pr1", "u2", mock.Anything, "").Return(domain.PullRequest{ID: "pr1", AssignedReviewers: []string{"u3"}}, nil)
 	tx.On("Commit", mock.Anything).Return(nil)
-	tx.On("Rollback", mock.Anything).Return(nil)
-
-	uow := repoMocks.NewMockUnitOfWork(t)
-	uow.On("Begin", mock.Anything).Return(tx, nil)
 
 	metrics := &metricsStub{}
-	svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+	svc := newTxService(t, uow, metrics)
 
-	pr, newReviewer, err := svc.Reassign(context.Background(), "pr1", "u2")
+	pr, newReviewer, err := svc.Reassign(context.Background(), "pr1", "u2", nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -345,66 +425,56 @@ func TestPullRequestService_Reassign_Success(t *testing.T) {
 }
 
 func TestPullRequestService_Reassign_BeginError(t *testing.T) {
-	prRepo := repoMocks.NewMockPullRequestRepository(t)
-	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AssignedReviewers: []string{"u2"}}, nil)
-	userRepo := repoMocks.NewMockUserRepository(t)
-	userRepo.On("GetUserByID", mock.Anything, "u2").Return(domain.User{ID: "u2", TeamName: "t"}, nil)
-	userRepo.On("ListActiveByTeam", mock.Anything, "t").Return([]domain.User{{ID: "u3", TeamName: "t"}}, nil)
-
-	uow := repoMocks.NewMockUnitOfWork(t)
+	uow := newUnitOfWork(t)
 	uow.On("Begin", mock.Anything).Return(nil, errors.New("begin fail"))
-
 	metrics := &metricsStub{}
-	svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+	svc := newTxService(t, uow, metrics)
 
-	_, _, err := svc.Reassign(context.Background(), "pr1", "u2")
+	_, _, err := svc.Reassign(context.Background(), "pr1", "u2", nil)
 	if err == nil || err.Error() != "begin fail" {
 		t.Fatalf("expected begin fail, got %v", err)
 	}
+	if metrics.reassigns["internal_error"] != 1 {
+		t.Fatalf("expected internal_error metric increment")
+	}
 }
 
 func TestPullRequestService_Reassign_ReassignError(t *testing.T) {
-	prRepo := repoMocks.NewMockPullRequestRepository(t)
-	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil)
-	userRepo := repoMocks.NewMockUserRepository(t)
-	userRepo.On("GetUserByID", mock.Anything, "u2").Return(domain.User{ID: "u2", TeamName: "t"}, nil)
-	userRepo.On("ListActiveByTeam", mock.Anything, "t").Return([]domain.User{{ID: "u3", TeamName: "t"}}, nil)
-
-	tx := repoMocks.NewMockTx(t)
-	tx.On("ReassignReviewer", mock.Anything, "pr1", "u2", mock.Anything).Return(domain.PullRequest{}, errors.New("reassign fail"))
-	tx.On("Rollback", mock.Anything).Return(nil)
+	tx, uow := newTx(t)
+	expectDefaultTeamPolicy(&tx.Mock)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil)
+	tx.On("GetUserByID", mock.Anything, "u2").Return(domain.User{ID: "u2", TeamName: "t"}, nil)
+	tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+	tx.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return([]domain.User{{ID: "u3", TeamName: "t"}}, nil)
+	tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{}, nil)
 
-	uow := repoMocks.NewMockUnitOfWork(t)
-	uow.On("Begin", mock.Anything).Return(tx, nil)
+	tx.On("ReassignReviewer", mock.Anything, "pr1", "u2", mock.Anything, "").Return(domain.PullRequest{}, errors.New("reassign fail"))
 
 	metrics := &metricsStub{}
-	svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+	svc := newTxService(t, uow, metrics)
 
-	_, _, err := svc.Reassign(context.Background(), "pr1", "u2")
+	_, _, err := svc.Reassign(context.Background(), "pr1", "u2", nil)
 	if err == nil || err.Error() != "reassign fail" {
 		t.Fatalf("expected reassign fail, got %v", err)
 	}
 }
 
 func TestPullRequestService_Reassign_CommitError(t *testing.T) {
-	prRepo := repoMocks.NewMockPullRequestRepository(t)
-	prRepo.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil)
-	userRepo := repoMocks.NewMockUserRepository(t)
-	userRepo.On("GetUserByID", mock.Anything, "u2").Return(domain.User{ID: "u2", TeamName: "t"}, nil)
-	userRepo.On("ListActiveByTeam", mock.Anything, "t").Return([]domain.User{{ID: "u3", TeamName: "t"}}, nil)
-
-	tx := repoMocks.NewMockTx(t)
-	tx.On("ReassignReviewer", mock.Anything, "pr1", "u2", mock.Anything).Return(domain.PullRequest{ID: "pr1"}, nil)
+	tx, uow := newTx(t)
+	expectDefaultTeamPolicy(&tx.Mock)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil)
+	tx.On("GetUserByID", mock.Anything, "u2").Return(domain.User{ID: "u2", TeamName: "t"}, nil)
+	tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+	tx.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return([]domain.User{{ID: "u3", TeamName: "t"}}, nil)
+	tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{}, nil)
+
+	tx.On("ReassignReviewer", mock.Anything, "pr1", "u2", mock.Anything, "").Return(domain.PullRequest{ID: "pr1"}, nil)
 	tx.On("Commit", mock.Anything).Return(errors.New("commit fail"))
-	tx.On("Rollback", mock.Anything).Return(nil)
-
-	uow := repoMocks.NewMockUnitOfWork(t)
-	uow.On("Begin", mock.Anything).Return(tx, nil)
 
 	metrics := &metricsStub{}
-	svc := NewPullRequestService(prRepo, userRepo, uow, metrics)
+	svc := newTxService(t, uow, metrics)
 
-	_, _, err := svc.Reassign(context.Background(), "pr1", "u2")
+	_, _, err := svc.Reassign(context.Background(), "pr1", "u2", nil)
 	if err == nil || err.Error() != "commit fail" {
 		t.Fatalf("expected commit fail, got %v", err)
 	}
@@ -418,13 +488,852 @@ func TestPickReviewers(t *testing.T) {
 		{ID: "u3"},
 	}
 
-	revs := pickReviewers(users, "author", 2)
+	revs := pickReviewers(users, []string{"author"}, 2, nil)
 	if len(revs) != 2 {
 		t.Fatalf("expected 2 reviewers, got %d", len(revs))
 	}
 	for _, r := range revs {
-		if r == "author" {
+		if r.ID == "author" {
 			t.Fatalf("author should not be reviewer")
 		}
 	}
 }
+
+func TestPullRequestService_Create_PrefersWorkingHours(t *testing.T) {
+	// 18:00 in Berlin (UTC+1 in winter), 12:00 in New York.
+	now := time.Date(2025, 1, 15, 17, 0, 0, 0, time.UTC)
+	office := &domain.WorkingHours{StartMinute: 9 * 60, EndMinute: 17 * 60}
+	night := &domain.WorkingHours{StartMinute: 22 * 60, EndMinute: 6 * 60}
+
+	tests := []struct {
+		name     string
+		users    []domain.User
+		expected []string
+	}{
+		{
+			name: "inside hours first",
+			users: []domain.User{
+				{ID: "berlin", TeamName: "t", Timezone: "Europe/Berlin", WorkingHours: office},
+				{ID: "nyc", TeamName: "t", Timezone: "America/New_York", WorkingHours: office},
+				{ID: "unset", TeamName: "t"},
+			},
+			expected: []string{"nyc", "unset"},
+		},
+		{
+			name: "window crossing midnight",
+			users: []domain.User{
+				{ID: "berlin", TeamName: "t", Timezone: "Europe/Berlin", WorkingHours: office},
+				{ID: "tokyo", TeamName: "t", Timezone: "Asia/Tokyo", WorkingHours: night},
+			},
+			expected: []string{"tokyo", "berlin"},
+		},
+		{
+			name: "fallback when nobody is working",
+			users: []domain.User{
+				{ID: "berlin", TeamName: "t", Timezone: "Europe/Berlin", WorkingHours: office},
+				{ID: "paris", TeamName: "t", Timezone: "Europe/Paris", WorkingHours: office},
+			},
+			expected: []string{"berlin", "paris"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, uow := newTx(t)
+			expectDefaultTeamPolicy(&tx.Mock)
+			tx.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
+			tx.On("GetUserByID", mock.Anything, "author").Return(domain.User{ID: "author", TeamName: "t"}, nil)
+			tx.On("ListActiveByTeam", mock.Anything, "t", now).Return(tt.users, nil)
+			tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{}, nil)
+
+			tx.On("CreatePullRequest", mock.Anything, mock.AnythingOfType("domain.PullRequest")).Return(func(_ context.Context, pr domain.PullRequest) domain.PullRequest {
+				return pr
+			}, nil)
+			tx.On("Commit", mock.Anything).Return(nil)
+
+			svc := newTxService(t, uow, &metricsStub{},
+				WithClock(func() time.Time { return now }),
+				WithSelectionPolicy(SelectionPolicy{PreferWorkingHours: true}),
+			)
+
+			pr, err := svc.Create(context.Background(), domain.PullRequest{ID: "pr1", AuthorID: "author"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !pr.CreatedAt.Equal(now) {
+				t.Fatalf("expected injected clock to be used, got %v", pr.CreatedAt)
+			}
+			if len(pr.AssignedReviewers) != len(tt.expected) {
+				t.Fatalf("expected reviewers %v, got %v", tt.expected, pr.AssignedReviewers)
+			}
+			for i, id := range tt.expected {
+				if pr.AssignedReviewers[i] != id {
+					t.Fatalf("expected reviewers %v, got %v", tt.expected, pr.AssignedReviewers)
+				}
+			}
+		})
+	}
+}
+
+func TestPullRequestService_Reassign_PrefersWorkingHours(t *testing.T) {
+	now := time.Date(2025, 1, 15, 17, 0, 0, 0, time.UTC)
+	office := &domain.WorkingHours{StartMinute: 9 * 60, EndMinute: 17 * 60}
+
+	tx, uow := newTx(t)
+	expectDefaultTeamPolicy(&tx.Mock)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil)
+	tx.On("GetUserByID", mock.Anything, "u2").Return(domain.User{ID: "u2", TeamName: "t"}, nil)
+	tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+	tx.On("ListActiveByTeam", mock.Anything, "t", now).Return([]domain.User{
+		{ID: "u1", TeamName: "t"},
+		{ID: "u2", TeamName: "t"},
+		{ID: "u3", TeamName: "t", Timezone: "Europe/Berlin", WorkingHours: office},
+		{ID: "u4", TeamName: "t", Timezone: "America/New_York", WorkingHours: office},
+	}, nil)
+	tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{}, nil)
+
+	tx.On("ReassignReviewer", mock.Anything, "pr1", "u2", "u4", "").Return(domain.PullRequest{ID: "pr1", AssignedReviewers: []string{"u4"}}, nil)
+	tx.On("Commit", mock.Anything).Return(nil)
+
+	svc := newTxService(t, uow, &metricsStub{},
+		WithClock(func() time.Time { return now }),
+		WithSelectionPolicy(SelectionPolicy{PreferWorkingHours: true}),
+	)
+
+	_, replacedBy, err := svc.Reassign(context.Background(), "pr1", "u2", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if replacedBy != "u4" {
+		t.Fatalf("expected reviewer inside working hours, got %s", replacedBy)
+	}
+}
+
+func TestPullRequestService_Create_SkipsReviewersAtCapacity(t *testing.T) {
+	one, two := 1, 2
+
+	tests := []struct {
+		name         string
+		load         map[string]domain.ReviewLoad
+		expected     []string
+		understaffed bool
+	}{
+		{
+			name:     "spare capacity everywhere",
+			load:     map[string]domain.ReviewLoad{"u2": {OpenReviews: 1, Capacity: &two}, "u3": {OpenReviews: 5}},
+			expected: []string{"u2", "u3"},
+		},
+		{
+			name:         "one reviewer at capacity",
+			load:         map[string]domain.ReviewLoad{"u2": {OpenReviews: 2, Capacity: &two}, "u3": {OpenReviews: 0, Capacity: &one}},
+			expected:     []string{"u3"},
+			understaffed: true,
+		},
+		{
+			name:         "nobody has spare capacity",
+			load:         map[string]domain.ReviewLoad{"u2": {OpenReviews: 1, Capacity: &one}, "u3": {OpenReviews: 3, Capacity: &two}},
+			expected:     nil,
+			understaffed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, uow := newTx(t)
+			expectDefaultTeamPolicy(&tx.Mock)
+			tx.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
+			tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+			tx.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return([]domain.User{{ID: "u1"}, {ID: "u2"}, {ID: "u3"}}, nil)
+			tx.On("GetReviewLoad", mock.Anything, []string{"u1", "u2", "u3"}).Return(tt.load, nil)
+
+			tx.On("CreatePullRequest", mock.Anything, mock.AnythingOfType("domain.PullRequest")).Return(func(_ context.Context, pr domain.PullRequest) domain.PullRequest {
+				return pr
+			}, nil)
+			tx.On("Commit", mock.Anything).Return(nil)
+
+			svc := newTxService(t, uow, &metricsStub{})
+			pr, err := svc.Create(context.Background(), domain.PullRequest{ID: "pr1", AuthorID: "u1"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(pr.AssignedReviewers) != len(tt.expected) {
+				t.Fatalf("expected reviewers %v, got %v", tt.expected, pr.AssignedReviewers)
+			}
+			for i, id := range tt.expected {
+				if pr.AssignedReviewers[i] != id {
+					t.Fatalf("expected reviewers %v, got %v", tt.expected, pr.AssignedReviewers)
+				}
+			}
+			if pr.Understaffed != tt.understaffed {
+				t.Fatalf("expected understaffed=%v, got %v", tt.understaffed, pr.Understaffed)
+			}
+		})
+	}
+}
+
+func TestPullRequestService_Reassign_SkipsReviewersAtCapacity(t *testing.T) {
+	one := 1
+	tx, uow := newTx(t)
+	expectDefaultTeamPolicy(&tx.Mock)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}}, nil)
+	tx.On("GetUserByID", mock.Anything, "u2").Return(domain.User{ID: "u2", TeamName: "t"}, nil)
+	tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+	tx.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return([]domain.User{{ID: "u1"}, {ID: "u2"}, {ID: "u3"}}, nil)
+	tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{"u3": {OpenReviews: 1, Capacity: &one}}, nil)
+	metrics := &metricsStub{}
+	svc := newTxService(t, uow, metrics)
+
+	_, _, err := svc.Reassign(context.Background(), "pr1", "u2", nil)
+	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeNoCandidate {
+		t.Fatalf("expected no candidate, got %v", err)
+	}
+	if metrics.reassigns["no_candidate"] != 1 {
+		t.Fatalf("expected no_candidate metric increment")
+	}
+}
+
+func TestPullRequestService_Create_BorrowsFromFallbackTeams(t *testing.T) {
+	tx, uow := newTx(t)
+	tx.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
+	tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+	tx.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return([]domain.User{{ID: "u1"}, {ID: "u2"}}, nil)
+	tx.On("ListActiveByTeam", mock.Anything, "empty", mock.Anything).Return([]domain.User{}, nil)
+	tx.On("ListActiveByTeam", mock.Anything, "backend", mock.Anything).Return([]domain.User{{ID: "b1", TeamName: "backend"}, {ID: "b2", TeamName: "backend"}}, nil)
+	tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{}, nil)
+	tx.On("GetTeamPolicy", mock.Anything, "t").Return(domain.TeamPolicy{}, nil)
+	tx.On("ListFallbackTeams", mock.Anything, "t").Return([]string{"empty", "backend"}, nil)
+
+	tx.On("CreatePullRequest", mock.Anything, mock.AnythingOfType("domain.PullRequest")).Return(func(_ context.Context, pr domain.PullRequest) domain.PullRequest {
+		return pr
+	}, nil)
+	tx.On("Commit", mock.Anything).Return(nil)
+
+	svc := newTxService(t, uow, &metricsStub{})
+	pr, err := svc.Create(context.Background(), domain.PullRequest{ID: "pr1", AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pr.AssignedReviewers) != 2 || pr.AssignedReviewers[0] != "u2" || pr.AssignedReviewers[1] != "b1" {
+		t.Fatalf("expected [u2 b1], got %v", pr.AssignedReviewers)
+	}
+	if len(pr.CrossTeamReviewers) != 1 || pr.CrossTeamReviewers["b1"] != "backend" {
+		t.Fatalf("expected b1 borrowed from backend, got %v", pr.CrossTeamReviewers)
+	}
+	if pr.Understaffed {
+		t.Fatalf("expected fully staffed pull request")
+	}
+}
+
+func TestPullRequestService_Reassign_BorrowsFromFallbackTeams(t *testing.T) {
+	tx, uow := newTx(t)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2", "b1"}}, nil)
+	tx.On("GetUserByID", mock.Anything, "u2").Return(domain.User{ID: "u2", TeamName: "t"}, nil)
+	tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+	tx.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return([]domain.User{{ID: "u1"}, {ID: "u2"}}, nil)
+	tx.On("ListActiveByTeam", mock.Anything, "backend", mock.Anything).Return([]domain.User{{ID: "b1", TeamName: "backend"}, {ID: "b2", TeamName: "backend"}}, nil)
+	tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{}, nil)
+	tx.On("GetTeamPolicy", mock.Anything, "t").Return(domain.TeamPolicy{}, nil)
+	tx.On("ListFallbackTeams", mock.Anything, "t").Return([]string{"backend"}, nil)
+
+	tx.On("ReassignReviewer", mock.Anything, "pr1", "u2", "b2", "backend").Return(domain.PullRequest{ID: "pr1", AssignedReviewers: []string{"b2", "b1"}, CrossTeamReviewers: map[string]string{"b1": "backend", "b2": "backend"}}, nil)
+	tx.On("Commit", mock.Anything).Return(nil)
+
+	svc := newTxService(t, uow, &metricsStub{})
+	_, replacedBy, err := svc.Reassign(context.Background(), "pr1", "u2", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if replacedBy != "b2" {
+		t.Fatalf("expected b2, got %s", replacedBy)
+	}
+}
+
+func TestPullRequestService_Create_AssignsCodeOwners(t *testing.T) {
+	rules := []domain.CodeOwnerRule{
+		{Pattern: "*", Owners: []string{"@acme/t"}},
+		{Pattern: "*.sql", Owners: []string{"@dba"}},
+		{Pattern: "/docs/", Owners: []string{"@writer", "@u2"}},
+		{Pattern: "/frontend/", Owners: []string{"@acme/web"}},
+	}
+
+	tests := []struct {
+		name         string
+		paths        []string
+		expected     []string
+		understaffed bool
+	}{
+		{
+			name:     "no paths fill from team",
+			expected: []string{"u2", "u3"},
+		},
+		{
+			name:     "owner from another team first",
+			paths:    []string{"migrations/0001_init.sql"},
+			expected: []string{"dba", "u2"},
+		},
+		{
+			name:     "one owner covers several areas",
+			paths:    []string{"docs/intro.md", "main.go"},
+			expected: []string{"u2", "u3"},
+		},
+		{
+			name:     "every area gets an owner",
+			paths:    []string{"main.go", "schema.sql", "docs/intro.md"},
+			expected: []string{"u2", "dba"},
+		},
+		{
+			name:         "area without available owner",
+			paths:        []string{"frontend/app.ts"},
+			expected:     []string{"u2", "u3"},
+			understaffed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, uow := newTx(t)
+			expectDefaultTeamPolicy(&tx.Mock)
+			tx.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
+			tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+			tx.On("GetUserByID", mock.Anything, "dba").Return(domain.User{ID: "dba", TeamName: "platform"}, nil).Maybe()
+			tx.On("GetUserByID", mock.Anything, "writer").Return(domain.User{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found")).Maybe()
+			tx.On("GetUserByID", mock.Anything, "u2").Return(domain.User{ID: "u2", TeamName: "t"}, nil).Maybe()
+			tx.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return([]domain.User{{ID: "u1"}, {ID: "u2"}, {ID: "u3"}}, nil)
+			tx.On("ListActiveByTeam", mock.Anything, "platform", mock.Anything).Return([]domain.User{{ID: "ops", TeamName: "platform"}, {ID: "dba", TeamName: "platform"}}, nil).Maybe()
+			tx.On("ListActiveByTeam", mock.Anything, "web", mock.Anything).Return([]domain.User{}, nil).Maybe()
+			tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{}, nil)
+			codeOwners := repoMocks.NewMockCodeOwnerRepository(t)
+			tx.On("ListCodeOwnerRules", mock.Anything).Return(rules, nil).Maybe()
+
+			tx.On("CreatePullRequest", mock.Anything, mock.AnythingOfType("domain.PullRequest")).Return(func(_ context.Context, pr domain.PullRequest) domain.PullRequest {
+				return pr
+			}, nil)
+			tx.On("Commit", mock.Anything).Return(nil)
+
+			svc := newTxService(t, uow, &metricsStub{}, WithCodeOwners(codeOwners))
+			pr, err := svc.Create(context.Background(), domain.PullRequest{ID: "pr1", AuthorID: "u1", ChangedPaths: tt.paths})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(pr.AssignedReviewers) != len(tt.expected) {
+				t.Fatalf("expected reviewers %v, got %v", tt.expected, pr.AssignedReviewers)
+			}
+			for i, id := range tt.expected {
+				if pr.AssignedReviewers[i] != id {
+					t.Fatalf("expected reviewers %v, got %v", tt.expected, pr.AssignedReviewers)
+				}
+			}
+			if pr.Understaffed != tt.understaffed {
+				t.Fatalf("expected understaffed=%v, got %v", tt.understaffed, pr.Understaffed)
+			}
+			// Owners from other teams are picked for their area, not
+			// borrowed from a fallback team.
+			if len(pr.CrossTeamReviewers) != 0 {
+				t.Fatalf("expected no borrowed reviewers, got %v", pr.CrossTeamReviewers)
+			}
+		})
+	}
+}
+
+func TestPullRequestService_Create_RanksByTagOverlap(t *testing.T) {
+	userTags := map[string][]string{
+		"u2": {"frontend"},
+		"u3": {"go"},
+		"u4": {"go", "sql"},
+	}
+
+	tests := []struct {
+		name     string
+		tags     []string
+		expected []string
+	}{
+		{name: "no tags keep team order", expected: []string{"u2", "u3"}},
+		{name: "best overlap first", tags: []string{"go", "sql"}, expected: []string{"u4", "u3"}},
+		{name: "general candidates fill the rest", tags: []string{"sql"}, expected: []string{"u4", "u2"}},
+		{name: "no overlap falls back to general candidates", tags: []string{"rust"}, expected: []string{"u2", "u3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, uow := newTx(t)
+			expectDefaultTeamPolicy(&tx.Mock)
+			tx.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
+			tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+			tx.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return([]domain.User{{ID: "u1"}, {ID: "u2"}, {ID: "u3"}, {ID: "u4"}}, nil)
+			tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{}, nil)
+			tx.On("ListUserTags", mock.Anything, []string{"u1", "u2", "u3", "u4"}).Return(userTags, nil).Maybe()
+
+			tx.On("CreatePullRequest", mock.Anything, mock.AnythingOfType("domain.PullRequest")).Return(func(_ context.Context, pr domain.PullRequest) domain.PullRequest {
+				return pr
+			}, nil)
+			tx.On("Commit", mock.Anything).Return(nil)
+
+			svc := newTxService(t, uow, &metricsStub{})
+			pr, err := svc.Create(context.Background(), domain.PullRequest{ID: "pr1", AuthorID: "u1", Tags: tt.tags})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(pr.AssignedReviewers) != len(tt.expected) || pr.AssignedReviewers[0] != tt.expected[0] || pr.AssignedReviewers[1] != tt.expected[1] {
+				t.Fatalf("expected reviewers %v, got %v", tt.expected, pr.AssignedReviewers)
+			}
+		})
+	}
+}
+
+func TestPullRequestService_Reassign_PrefersTaggedReviewer(t *testing.T) {
+	tx, uow := newTx(t)
+	expectDefaultTeamPolicy(&tx.Mock)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2"}, Tags: []string{"sql"}}, nil)
+	tx.On("GetUserByID", mock.Anything, "u2").Return(domain.User{ID: "u2", TeamName: "t"}, nil)
+	tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+	tx.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return([]domain.User{{ID: "u1"}, {ID: "u2"}, {ID: "u3"}, {ID: "u4"}}, nil)
+	tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{}, nil)
+	tx.On("ListUserTags", mock.Anything, mock.Anything).Return(map[string][]string{"u4": {"sql"}}, nil)
+
+	tx.On("ReassignReviewer", mock.Anything, "pr1", "u2", "u4", "").Return(domain.PullRequest{ID: "pr1", AssignedReviewers: []string{"u4"}}, nil)
+	tx.On("Commit", mock.Anything).Return(nil)
+
+	svc := newTxService(t, uow, &metricsStub{})
+	_, replacedBy, err := svc.Reassign(context.Background(), "pr1", "u2", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if replacedBy != "u4" {
+		t.Fatalf("expected u4, got %s", replacedBy)
+	}
+}
+
+func TestPickReviewers_SeniorityRule(t *testing.T) {
+	junior := domain.User{ID: "j1", Seniority: domain.SeniorityJunior}
+	middle := domain.User{ID: "m1", Seniority: domain.SeniorityMiddle}
+	senior := domain.User{ID: "s1", Seniority: domain.SenioritySenior}
+
+	tests := []struct {
+		name     string
+		users    []domain.User
+		rule     *seniorityRule
+		expected []string
+	}{
+		{
+			name:     "policy off keeps ranking",
+			users:    []domain.User{junior, middle, senior},
+			expected: []string{"j1", "m1"},
+		},
+		{
+			name:     "senior first, junior learns",
+			users:    []domain.User{junior, middle, senior},
+			rule:     &seniorityRule{required: true},
+			expected: []string{"s1", "j1"},
+		},
+		{
+			name:     "no senior holds juniors back",
+			users:    []domain.User{junior, middle, {ID: "m2", Seniority: domain.SeniorityMiddle}},
+			rule:     &seniorityRule{required: true},
+			expected: []string{"m1", "m2"},
+		},
+		{
+			name:     "senior already reviewing",
+			users:    []domain.User{junior, middle, senior},
+			rule:     &seniorityRule{required: true, covered: true},
+			expected: []string{"j1", "m1"},
+		},
+		{
+			name:     "strict accepts only seniors",
+			users:    []domain.User{junior, middle},
+			rule:     &seniorityRule{required: true, strict: true},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := userIDs(pickReviewers(tt.users, nil, 2, tt.rule))
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Fatalf("expected %v, got %v", tt.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestPullRequestService_Create_RequiresSeniorReviewer(t *testing.T) {
+	tests := []struct {
+		name         string
+		fallback     []domain.User
+		expected     []string
+		understaffed bool
+	}{
+		{
+			name:     "senior borrowed from fallback team",
+			fallback: []domain.User{{ID: "b1", Seniority: domain.SeniorityMiddle}, {ID: "b2", Seniority: domain.SenioritySenior}},
+			expected: []string{"b2", "u2"},
+		},
+		{
+			name:         "no senior anywhere",
+			fallback:     []domain.User{{ID: "b1", Seniority: domain.SeniorityJunior}},
+			expected:     []string{"u3"},
+			understaffed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, uow := newTx(t)
+			tx.On("GetPullRequestByID", mock.Anything, "pr1").Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
+			tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+			tx.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return([]domain.User{
+				{ID: "u1", Seniority: domain.SenioritySenior},
+				{ID: "u2", Seniority: domain.SeniorityJunior},
+				{ID: "u3", Seniority: domain.SeniorityMiddle},
+			}, nil)
+			tx.On("ListActiveByTeam", mock.Anything, "backend", mock.Anything).Return(tt.fallback, nil)
+			tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{}, nil)
+			tx.On("GetTeamPolicy", mock.Anything, "t").Return(domain.TeamPolicy{RequireSeniorReviewer: true}, nil)
+			tx.On("ListFallbackTeams", mock.Anything, "t").Return([]string{"backend"}, nil)
+
+			tx.On("CreatePullRequest", mock.Anything, mock.AnythingOfType("domain.PullRequest")).Return(func(_ context.Context, pr domain.PullRequest) domain.PullRequest {
+				return pr
+			}, nil)
+			tx.On("Commit", mock.Anything).Return(nil)
+
+			svc := newTxService(t, uow, &metricsStub{})
+			pr, err := svc.Create(context.Background(), domain.PullRequest{ID: "pr1", AuthorID: "u1"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(pr.AssignedReviewers) != len(tt.expected) {
+				t.Fatalf("expected reviewers %v, got %v", tt.expected, pr.AssignedReviewers)
+			}
+			for i, id := range tt.expected {
+				if pr.AssignedReviewers[i] != id {
+					t.Fatalf("expected reviewers %v, got %v", tt.expected, pr.AssignedReviewers)
+				}
+			}
+			if pr.Understaffed != tt.understaffed {
+				t.Fatalf("expected understaffed=%v, got %v", tt.understaffed, pr.Understaffed)
+			}
+		})
+	}
+}
+
+func TestPullRequestService_Reassign_KeepsLastSenior(t *testing.T) {
+	tests := []struct {
+		name       string
+		team       []domain.User
+		replacedBy string
+		noCand     bool
+	}{
+		{
+			name: "senior replaces senior",
+			team: []domain.User{
+				{ID: "u1"},
+				{ID: "u2", Seniority: domain.SenioritySenior},
+				{ID: "u3", Seniority: domain.SeniorityJunior},
+				{ID: "u4", Seniority: domain.SeniorityMiddle},
+				{ID: "u5", Seniority: domain.SenioritySenior},
+			},
+			replacedBy: "u5",
+		},
+		{
+			name: "no senior left to take over",
+			team: []domain.User{
+				{ID: "u1"},
+				{ID: "u2", Seniority: domain.SenioritySenior},
+				{ID: "u3", Seniority: domain.SeniorityJunior},
+				{ID: "u4", Seniority: domain.SeniorityMiddle},
+			},
+			noCand: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, uow := newTx(t)
+			tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2", "u3"}}, nil)
+			tx.On("GetUserByID", mock.Anything, "u2").Return(domain.User{ID: "u2", TeamName: "t", Seniority: domain.SenioritySenior}, nil)
+			tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+			tx.On("GetUserByID", mock.Anything, "u3").Return(domain.User{ID: "u3", TeamName: "t", Seniority: domain.SeniorityJunior}, nil)
+			tx.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return(tt.team, nil)
+			tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{}, nil)
+			tx.On("GetTeamPolicy", mock.Anything, "t").Return(domain.TeamPolicy{RequireSeniorReviewer: true}, nil)
+			tx.On("ListFallbackTeams", mock.Anything, "t").Return(nil, nil).Maybe()
+
+			if !tt.noCand {
+				tx.On("ReassignReviewer", mock.Anything, "pr1", "u2", tt.replacedBy, "").Return(domain.PullRequest{ID: "pr1"}, nil)
+				tx.On("Commit", mock.Anything).Return(nil)
+			}
+
+			svc := newTxService(t, uow, &metricsStub{})
+			_, replacedBy, err := svc.Reassign(context.Background(), "pr1", "u2", nil)
+			if tt.noCand {
+				if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeNoCandidate {
+					t.Fatalf("expected no candidate, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if replacedBy != tt.replacedBy {
+				t.Fatalf("expected %s, got %s", tt.replacedBy, replacedBy)
+			}
+		})
+	}
+}
+
+func TestPullRequestService_Reassign_BorrowedSeniorFollowsAuthorTeamPolicy(t *testing.T) {
+	tx, uow := newTx(t)
+	tx.On("GetPullRequestForUpdate", mock.Anything, "pr1").Return(domain.PullRequest{ID: "pr1", Status: domain.PullRequestStatusOpen, AuthorID: "u1", AssignedReviewers: []string{"u2", "b1"}, CrossTeamReviewers: map[string]string{"b1": "backend"}}, nil)
+	tx.On("GetUserByID", mock.Anything, "b1").Return(domain.User{ID: "b1", TeamName: "backend", Seniority: domain.SenioritySenior}, nil)
+	tx.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+	tx.On("GetUserByID", mock.Anything, "u2").Return(domain.User{ID: "u2", TeamName: "t", Seniority: domain.SeniorityJunior}, nil)
+	tx.On("ListActiveByTeam", mock.Anything, "backend", mock.Anything).Return([]domain.User{
+		{ID: "b1", TeamName: "backend", Seniority: domain.SenioritySenior},
+		{ID: "b2", TeamName: "backend", Seniority: domain.SeniorityJunior},
+		{ID: "b3", TeamName: "backend", Seniority: domain.SenioritySenior},
+	}, nil)
+	tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{}, nil)
+	tx.On("GetTeamPolicy", mock.Anything, "t").Return(domain.TeamPolicy{RequireSeniorReviewer: true}, nil)
+	tx.On("GetTeamPolicy", mock.Anything, "backend").Return(domain.TeamPolicy{}, nil).Maybe()
+	tx.On("ListFallbackTeams", mock.Anything, "backend").Return(nil, nil).Maybe()
+	tx.On("ReassignReviewer", mock.Anything, "pr1", "b1", "b3", "backend").Return(domain.PullRequest{ID: "pr1"}, nil)
+	tx.On("Commit", mock.Anything).Return(nil)
+
+	svc := newTxService(t, uow, &metricsStub{})
+	_, replacedBy, err := svc.Reassign(context.Background(), "pr1", "b1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if replacedBy != "b3" {
+		t.Fatalf("expected the senior b3 to replace the borrowed senior, got %s", replacedBy)
+	}
+}
+
+func TestPullRequestService_Create_SpreadsAuthorReviewerPairs(t *testing.T) {
+	team := []domain.User{{ID: "a1"}, {ID: "a2"}, {ID: "r1"}, {ID: "r2"}, {ID: "r3"}, {ID: "r4"}, {ID: "r5"}}
+	authors := []string{"a1", "a2"}
+
+	type pairing struct {
+		author, reviewer string
+		at               time.Time
+	}
+
+	simulate := func(t *testing.T, window time.Duration, prsPerAuthor int) map[string]map[string]int {
+		start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+		now := start
+		var history []pairing
+
+		tx, uow := newTx(t)
+		expectDefaultTeamPolicy(&tx.Mock)
+		tx.On("GetPullRequestByID", mock.Anything, mock.Anything).Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
+		tx.On("CountRecentPairings", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+			func(_ context.Context, authorID string, reviewerIDs []string, since time.Time) map[string]int {
+				counts := make(map[string]int)
+				for _, p := range history {
+					if p.author == authorID && !p.at.Before(since) && contains(reviewerIDs, p.reviewer) {
+						counts[p.reviewer]++
+					}
+				}
+				return counts
+			}, nil).Maybe()
+
+		for _, id := range authors {
+			tx.On("GetUserByID", mock.Anything, id).Return(domain.User{ID: id, TeamName: "t"}, nil)
+		}
+		tx.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return(team, nil)
+		tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{}, nil)
+
+		tx.On("CreatePullRequest", mock.Anything, mock.AnythingOfType("domain.PullRequest")).Return(func(_ context.Context, pr domain.PullRequest) domain.PullRequest {
+			for _, reviewer := range pr.AssignedReviewers {
+				history = append(history, pairing{author: pr.AuthorID, reviewer: reviewer, at: now})
+			}
+			return pr
+		}, nil)
+		tx.On("Commit", mock.Anything).Return(nil)
+
+		svc := newTxService(t, uow, &metricsStub{},
+			WithClock(func() time.Time { return now }),
+			WithSelectionPolicy(SelectionPolicy{PairingWindow: window}),
+		)
+
+		for i := 0; i < prsPerAuthor; i++ {
+			for _, author := range authors {
+				id := fmt.Sprintf("pr-%s-%d", author, i)
+				if _, err := svc.Create(context.Background(), domain.PullRequest{ID: id, AuthorID: author}); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			now = now.Add(time.Hour)
+		}
+
+		pairs := make(map[string]map[string]int)
+		for _, p := range history {
+			if pairs[p.author] == nil {
+				pairs[p.author] = make(map[string]int)
+			}
+			pairs[p.author][p.reviewer]++
+		}
+		return pairs
+	}
+
+	spread := func(author string, counts map[string]int) (int, int) {
+		lo, hi := -1, 0
+		for _, u := range team {
+			if u.ID == author {
+				continue
+			}
+			n := counts[u.ID]
+			if lo < 0 || n < lo {
+				lo = n
+			}
+			hi = max(hi, n)
+		}
+		return lo, hi
+	}
+
+	t.Run("without penalty the same pairs repeat", func(t *testing.T) {
+		pairs := simulate(t, 0, 20)
+		for _, author := range authors {
+			if lo, hi := spread(author, pairs[author]); lo != 0 || hi != 20 {
+				t.Fatalf("%s: expected skewed pairs, got %v", author, pairs[author])
+			}
+		}
+	})
+
+	t.Run("penalty spreads pairs evenly", func(t *testing.T) {
+		pairs := simulate(t, 30*24*time.Hour, 20)
+		for _, author := range authors {
+			// 20 PRs with two reviewers each over six teammates.
+			if lo, hi := spread(author, pairs[author]); lo != 6 || hi != 7 {
+				t.Fatalf("%s: expected 6-7 reviews per pair, got %v", author, pairs[author])
+			}
+			if pairs[author][author] != 0 {
+				t.Fatalf("%s reviewed own pull request", author)
+			}
+		}
+	})
+
+	t.Run("pairs outside the window are forgotten", func(t *testing.T) {
+		// With a one hour window only the previous round counts, so the two
+		// pairs alternate and the rest of the team is never reached.
+		pairs := simulate(t, time.Hour, 6)
+		for _, author := range authors {
+			for _, id := range []string{"r4", "r5"} {
+				if pairs[author][id] != 0 {
+					t.Fatalf("%s: expected %s to stay unused, got %v", author, id, pairs[author])
+				}
+			}
+			if lo, hi := spread(author, pairs[author]); lo != 0 || hi != 3 {
+				t.Fatalf("%s: expected two alternating pairs, got %v", author, pairs[author])
+			}
+		}
+	})
+}
+
+func TestPullRequestService_Create_ReadsPolicyPerRequest(t *testing.T) {
+	team := []domain.User{{ID: "a"}, {ID: "r1"}, {ID: "r2"}, {ID: "r3"}, {ID: "r4"}}
+
+	tx, uow := newTx(t)
+	expectDefaultTeamPolicy(&tx.Mock)
+	tx.On("GetPullRequestByID", mock.Anything, mock.Anything).Return(domain.PullRequest{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
+	tx.On("GetUserByID", mock.Anything, "a").Return(domain.User{ID: "a", TeamName: "t"}, nil)
+	tx.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return(team, nil)
+	tx.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{}, nil)
+
+	tx.On("CreatePullRequest", mock.Anything, mock.AnythingOfType("domain.PullRequest")).Return(func(_ context.Context, pr domain.PullRequest) domain.PullRequest { return pr }, nil)
+	tx.On("Commit", mock.Anything).Return(nil)
+
+	policy := SelectionPolicy{}
+	svc := newTxService(t, uow, &metricsStub{},
+		WithSelectionPolicySource(func() SelectionPolicy { return policy }),
+	)
+
+	for i, tc := range []struct {
+		reviewers int
+		want      []string
+	}{
+		{reviewers: 0, want: []string{"r1", "r2"}},
+		{reviewers: 3, want: []string{"r1", "r2", "r3"}},
+		{reviewers: 1, want: []string{"r1"}},
+	} {
+		policy = SelectionPolicy{Reviewers: tc.reviewers}
+		pr, err := svc.Create(context.Background(), domain.PullRequest{ID: fmt.Sprintf("pr-%d", i), AuthorID: "a"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !slices.Equal(pr.AssignedReviewers, tc.want) || pr.Understaffed {
+			t.Fatalf("reviewers=%d: expected %v, got %v (understaffed %v)", tc.reviewers, tc.want, pr.AssignedReviewers, pr.Understaffed)
+		}
+	}
+}
+
+func TestPullRequestService_PreviewAssignment(t *testing.T) {
+	capacity := 1
+	prRepo := repoMocks.NewMockPullRequestRepository(t)
+	userRepo := repoMocks.NewMockUserRepository(t)
+	userRepo.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1", TeamName: "t"}, nil)
+	// u4 is active but out of office, so it is not listed.
+	userRepo.On("ListActiveByTeam", mock.Anything, "t", mock.Anything).Return([]domain.User{{ID: "u1"}, {ID: "u2"}, {ID: "u5"}, {ID: "u6"}, {ID: "u7"}}, nil)
+	userRepo.On("GetReviewLoad", mock.Anything, mock.Anything).Return(map[string]domain.ReviewLoad{
+		"u5": {OpenReviews: 1, Capacity: &capacity},
+	}, nil)
+	userRepo.On("ListActiveByTeam", mock.Anything, "payments", mock.Anything).Return([]domain.User{{ID: "p1", TeamName: "payments"}}, nil)
+
+	teams := repoMocks.NewMockTeamRepository(t)
+	teams.On("GetTeamPolicy", mock.Anything, "t").Return(domain.TeamPolicy{}, nil)
+	teams.On("ListFallbackTeams", mock.Anything, "t").Return([]string{"payments"}, nil)
+	teams.On("GetTeamByName", mock.Anything, "t").Return(domain.Team{Name: "t", Members: []domain.User{
+		{ID: "u1", IsActive: true},
+		{ID: "u2", IsActive: true},
+		{ID: "u3", IsActive: false},
+		{ID: "u4", IsActive: true},
+		{ID: "u5", IsActive: true},
+		{ID: "u6", IsActive: true},
+		{ID: "u7", IsActive: true},
+	}}, nil)
+	teams.On("GetTeamByName", mock.Anything, "payments").Return(domain.Team{Name: "payments", Members: []domain.User{
+		{ID: "p1", IsActive: true},
+	}}, nil)
+	uow := newUnitOfWork(t)
+
+	svc := NewPullRequestService(prRepo, userRepo, teams, uow, &metricsStub{})
+	preview, err := svc.PreviewAssignment(context.Background(), domain.PullRequest{AuthorID: "u1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(preview.AssignedReviewers) != 2 || preview.AssignedReviewers[0] != "u2" || preview.AssignedReviewers[1] != "u6" || preview.Understaffed {
+		t.Fatalf("unexpected reviewers: %+v", preview)
+	}
+
+	expected := map[string]domain.RejectionReason{
+		"u1": domain.RejectionAuthor,
+		"u3": domain.RejectionInactive,
+		"u4": domain.RejectionOutOfOffice,
+		"u5": domain.RejectionAtCapacity,
+		"u7": domain.RejectionNotSelected,
+		"p1": domain.RejectionNotSelected,
+	}
+	if len(preview.Rejected) != len(expected) {
+		t.Fatalf("expected %d rejected candidates, got %+v", len(expected), preview.Rejected)
+	}
+	for _, c := range preview.Rejected {
+		if expected[c.UserID] != c.Reason {
+			t.Fatalf("%s: expected reason %q, got %q", c.UserID, expected[c.UserID], c.Reason)
+		}
+	}
+}
+
+func TestPullRequestService_PreviewAssignment_AuthorNotFound(t *testing.T) {
+	userRepo := repoMocks.NewMockUserRepository(t)
+	userRepo.On("GetUserByID", mock.Anything, "u1").Return(domain.User{}, domain.NewDomainError(domain.ErrorCodeNotFound, "missing"))
+
+	svc := NewPullRequestService(repoMocks.NewMockPullRequestRepository(t), userRepo, defaultTeams(t), newUnitOfWork(t), &metricsStub{})
+	_, err := svc.PreviewAssignment(context.Background(), domain.PullRequest{AuthorID: "u1"})
+	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeNotFound {
+		t.Fatalf("expected NOT_FOUND, got %v", err)
+	}
+}
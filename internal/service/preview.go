@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"pr-reviewer/internal/domain"
+)
+
+// PreviewAssignment runs the same selection as Create for pr without writing
+// anything and explains why members of the author's team and its fallback
+// teams were not picked.
+func (s *pullRequestService) PreviewAssignment(ctx context.Context, pr domain.PullRequest) (*domain.AssignmentPreview, error) {
+	r := s.repos()
+	author, err := s.getAuthor(ctx, r, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	a, err := s.assign(ctx, r, pr, author, now)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &domain.AssignmentPreview{
+		AuthorID:           author.ID,
+		AssignedReviewers:  userIDs(a.reviewers),
+		CrossTeamReviewers: a.borrowed,
+		Understaffed:       a.understaffed,
+	}
+
+	fallbacks, err := r.teams.ListFallbackTeams(ctx, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	for _, teamName := range append([]string{author.TeamName}, fallbacks...) {
+		rejected, err := s.rejectedCandidates(ctx, r, teamName, author.ID, preview.AssignedReviewers, now)
+		if err != nil {
+			return nil, err
+		}
+		preview.Rejected = append(preview.Rejected, rejected...)
+	}
+	return preview, nil
+}
+
+// rejectedCandidates lists the members of a team that were not picked, each
+// with the first reason that ruled them out.
+func (s *pullRequestService) rejectedCandidates(ctx context.Context, r repos, teamName, authorID string, picked []string, at time.Time) ([]domain.RejectedCandidate, error) {
+	team, err := r.teams.GetTeamByName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	active, err := r.users.ListActiveByTeam(ctx, teamName, at)
+	if err != nil {
+		return nil, err
+	}
+	load, err := r.users.GetReviewLoad(ctx, userIDs(active))
+	if err != nil {
+		return nil, err
+	}
+
+	var rejected []domain.RejectedCandidate
+	for _, member := range team.Members {
+		if contains(picked, member.ID) {
+			continue
+		}
+
+		reason := domain.RejectionNotSelected
+		switch {
+		case member.ID == authorID:
+			reason = domain.RejectionAuthor
+		case !member.IsActive:
+			reason = domain.RejectionInactive
+		case !containsUser(active, member.ID):
+			reason = domain.RejectionOutOfOffice
+		default:
+			if l, ok := load[member.ID]; ok && !l.HasSpareCapacity() {
+				reason = domain.RejectionAtCapacity
+			}
+		}
+		rejected = append(rejected, domain.RejectedCandidate{
+			UserID:   member.ID,
+			TeamName: teamName,
+			Reason:   reason,
+		})
+	}
+	return rejected, nil
+}
@@ -10,14 +10,23 @@ import (
 type TeamService interface {
 	AddTeam(ctx context.Context, team domain.Team) (*domain.Team, error)
 	GetTeam(ctx context.Context, teamName string) (*domain.Team, error)
+	SetDefaultReviewCapacity(ctx context.Context, teamName string, capacity *int) (*domain.Team, error)
+	SetFallbackTeams(ctx context.Context, teamName string, fallbacks []string) (*domain.Team, error)
+	SetPolicy(ctx context.Context, teamName string, policy domain.TeamPolicy) (*domain.Team, error)
 }
 
 type teamService struct {
-	repo repository.TeamRepository
+	repo  repository.TeamRepository
+	users repository.UserRepository
+	uow   repository.UnitOfWork
 }
 
-func NewTeamService(repo repository.TeamRepository) TeamService {
-	return &teamService{repo: repo}
+func NewTeamService(repo repository.TeamRepository, users repository.UserRepository, uow repository.UnitOfWork) TeamService {
+	return &teamService{
+		repo:  repo,
+		users: users,
+		uow:   uow,
+	}
 }
 
 func (s *teamService) AddTeam(ctx context.Context, team domain.Team) (*domain.Team, error) {
@@ -44,5 +53,88 @@ func (s *teamService) GetTeam(ctx context.Context, teamName string) (*domain.Tea
 		}
 		return nil, err
 	}
+
+	if err := s.attachLoad(ctx, &team); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (s *teamService) SetDefaultReviewCapacity(ctx context.Context, teamName string, capacity *int) (*domain.Team, error) {
+	team, err := s.repo.SetDefaultReviewCapacity(ctx, teamName, capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachLoad(ctx, &team); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+// SetFallbackTeams replaces the list in one transaction, so readers never see
+// it half written and concurrent calls do not interleave their rows.
+func (s *teamService) SetFallbackTeams(ctx context.Context, teamName string, fallbacks []string) (*domain.Team, error) {
+	var team domain.Team
+	err := s.uow.Do(ctx, func(tx repository.Tx) error {
+		if err := ensureTeam(ctx, tx, teamName, "team not found"); err != nil {
+			return err
+		}
+		for _, fallback := range fallbacks {
+			if err := ensureTeam(ctx, tx, fallback, "fallback team "+fallback+" not found"); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		team, err = tx.SetFallbackTeams(ctx, teamName, fallbacks)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachLoad(ctx, &team); err != nil {
+		return nil, err
+	}
+	return &team, nil
+}
+
+func (s *teamService) SetPolicy(ctx context.Context, teamName string, policy domain.TeamPolicy) (*domain.Team, error) {
+	team, err := s.repo.SetTeamPolicy(ctx, teamName, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachLoad(ctx, &team); err != nil {
+		return nil, err
+	}
 	return &team, nil
 }
+
+func ensureTeam(ctx context.Context, teams repository.TeamRepository, teamName, notFoundMsg string) error {
+	if _, err := teams.GetTeamByName(ctx, teamName); err != nil {
+		if derr, ok := domain.AsDomainError(err); ok && derr.Code == domain.ErrorCodeNotFound {
+			return domain.NewDomainError(domain.ErrorCodeNotFound, notFoundMsg)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *teamService) attachLoad(ctx context.Context, team *domain.Team) error {
+	ids := make([]string, 0, len(team.Members))
+	for _, m := range team.Members {
+		ids = append(ids, m.ID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	load, err := s.users.GetReviewLoad(ctx, ids)
+	if err != nil {
+		return err
+	}
+	team.Load = load
+	return nil
+}
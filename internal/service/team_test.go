@@ -16,7 +16,7 @@ func TestTeamService_AddTeam_Success(t *testing.T) {
 	repo.On("GetTeamByName", mock.Anything, "backend").Return(domain.Team{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
 	repo.On("UpsertTeam", mock.Anything, mock.MatchedBy(func(team domain.Team) bool { return team.Name == "backend" })).Return(domain.Team{Name: "backend"}, nil)
 
-	svc := NewTeamService(repo)
+	svc := NewTeamService(repo, repoMocks.NewMockUserRepository(t), newUnitOfWork(t))
 
 	team, err := svc.AddTeam(context.Background(), domain.Team{Name: "backend"})
 	if err != nil {
@@ -30,7 +30,7 @@ func TestTeamService_AddTeam_Success(t *testing.T) {
 func TestTeamService_AddTeam_Exists(t *testing.T) {
 	repo := repoMocks.NewMockTeamRepository(t)
 	repo.On("GetTeamByName", mock.Anything, "backend").Return(domain.Team{Name: "backend"}, nil)
-	svc := NewTeamService(repo)
+	svc := NewTeamService(repo, repoMocks.NewMockUserRepository(t), newUnitOfWork(t))
 
 	_, err := svc.AddTeam(context.Background(), domain.Team{Name: "backend"})
 	if err == nil {
@@ -44,7 +44,7 @@ func TestTeamService_AddTeam_Exists(t *testing.T) {
 func TestTeamService_AddTeam_GetError(t *testing.T) {
 	repo := repoMocks.NewMockTeamRepository(t)
 	repo.On("GetTeamByName", mock.Anything, "backend").Return(domain.Team{}, errors.New("db down"))
-	svc := NewTeamService(repo)
+	svc := NewTeamService(repo, repoMocks.NewMockUserRepository(t), newUnitOfWork(t))
 
 	_, err := svc.AddTeam(context.Background(), domain.Team{Name: "backend"})
 	if err == nil || err.Error() != "db down" {
@@ -56,7 +56,7 @@ func TestTeamService_AddTeam_UpsertError(t *testing.T) {
 	repo := repoMocks.NewMockTeamRepository(t)
 	repo.On("GetTeamByName", mock.Anything, "backend").Return(domain.Team{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
 	repo.On("UpsertTeam", mock.Anything, mock.Anything).Return(domain.Team{}, errors.New("fail"))
-	svc := NewTeamService(repo)
+	svc := NewTeamService(repo, repoMocks.NewMockUserRepository(t), newUnitOfWork(t))
 
 	_, err := svc.AddTeam(context.Background(), domain.Team{Name: "backend"})
 	if err == nil || err.Error() != "fail" {
@@ -67,7 +67,7 @@ func TestTeamService_AddTeam_UpsertError(t *testing.T) {
 func TestTeamService_GetTeam_Success(t *testing.T) {
 	repo := repoMocks.NewMockTeamRepository(t)
 	repo.On("GetTeamByName", mock.Anything, "backend").Return(domain.Team{Name: "backend"}, nil)
-	svc := NewTeamService(repo)
+	svc := NewTeamService(repo, repoMocks.NewMockUserRepository(t), newUnitOfWork(t))
 
 	team, err := svc.GetTeam(context.Background(), "backend")
 	if err != nil {
@@ -81,7 +81,7 @@ func TestTeamService_GetTeam_Success(t *testing.T) {
 func TestTeamService_GetTeam_NotFound(t *testing.T) {
 	repo := repoMocks.NewMockTeamRepository(t)
 	repo.On("GetTeamByName", mock.Anything, "unknown").Return(domain.Team{}, domain.NewDomainError(domain.ErrorCodeNotFound, "missing"))
-	svc := NewTeamService(repo)
+	svc := NewTeamService(repo, repoMocks.NewMockUserRepository(t), newUnitOfWork(t))
 
 	_, err := svc.GetTeam(context.Background(), "unknown")
 	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeNotFound {
@@ -92,10 +92,88 @@ func TestTeamService_GetTeam_NotFound(t *testing.T) {
 func TestTeamService_GetTeam_OtherError(t *testing.T) {
 	repo := repoMocks.NewMockTeamRepository(t)
 	repo.On("GetTeamByName", mock.Anything, "backend").Return(domain.Team{}, errors.New("boom"))
-	svc := NewTeamService(repo)
+	svc := NewTeamService(repo, repoMocks.NewMockUserRepository(t), newUnitOfWork(t))
 
 	_, err := svc.GetTeam(context.Background(), "backend")
 	if err == nil || err.Error() != "boom" {
 		t.Fatalf("expected boom error, got %v", err)
 	}
 }
+
+func TestTeamService_GetTeam_AttachesLoad(t *testing.T) {
+	capacity := 3
+	repo := repoMocks.NewMockTeamRepository(t)
+	repo.On("GetTeamByName", mock.Anything, "backend").Return(domain.Team{
+		Name:    "backend",
+		Members: []domain.User{{ID: "u1"}, {ID: "u2"}},
+	}, nil)
+	userRepo := repoMocks.NewMockUserRepository(t)
+	userRepo.On("GetReviewLoad", mock.Anything, []string{"u1", "u2"}).Return(map[string]domain.ReviewLoad{
+		"u1": {OpenReviews: 2, Capacity: &capacity},
+		"u2": {OpenReviews: 0},
+	}, nil)
+	svc := NewTeamService(repo, userRepo, newUnitOfWork(t))
+
+	team, err := svc.GetTeam(context.Background(), "backend")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if team.Load["u1"].OpenReviews != 2 || *team.Load["u1"].Capacity != 3 {
+		t.Fatalf("unexpected load for u1: %+v", team.Load["u1"])
+	}
+}
+
+func TestTeamService_SetDefaultReviewCapacity_NotFound(t *testing.T) {
+	capacity := 2
+	repo := repoMocks.NewMockTeamRepository(t)
+	repo.On("SetDefaultReviewCapacity", mock.Anything, "ghost", &capacity).Return(domain.Team{}, domain.NewDomainError(domain.ErrorCodeNotFound, "team not found"))
+	svc := NewTeamService(repo, repoMocks.NewMockUserRepository(t), newUnitOfWork(t))
+
+	_, err := svc.SetDefaultReviewCapacity(context.Background(), "ghost", &capacity)
+	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeNotFound {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestTeamService_SetFallbackTeams_UnknownFallback(t *testing.T) {
+	tx, uow := newTx(t)
+	tx.On("GetTeamByName", mock.Anything, "backend").Return(domain.Team{Name: "backend"}, nil)
+	tx.On("GetTeamByName", mock.Anything, "ghost").Return(domain.Team{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
+
+	svc := NewTeamService(repoMocks.NewMockTeamRepository(t), repoMocks.NewMockUserRepository(t), uow)
+	_, err := svc.SetFallbackTeams(context.Background(), "backend", []string{"ghost"})
+	derr, ok := domain.AsDomainError(err)
+	if !ok || derr.Code != domain.ErrorCodeNotFound || derr.Message != "fallback team ghost not found" {
+		t.Fatalf("expected fallback not found, got %v", err)
+	}
+}
+
+func TestTeamService_SetFallbackTeams_Success(t *testing.T) {
+	tx, uow := newTx(t)
+	tx.On("GetTeamByName", mock.Anything, "backend").Return(domain.Team{Name: "backend"}, nil)
+	tx.On("GetTeamByName", mock.Anything, "payments").Return(domain.Team{Name: "payments"}, nil)
+	tx.On("SetFallbackTeams", mock.Anything, "backend", []string{"payments"}).Return(domain.Team{Name: "backend", FallbackTeams: []string{"payments"}}, nil)
+	tx.On("Commit", mock.Anything).Return(nil)
+
+	svc := NewTeamService(repoMocks.NewMockTeamRepository(t), repoMocks.NewMockUserRepository(t), uow)
+	team, err := svc.SetFallbackTeams(context.Background(), "backend", []string{"payments"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(team.FallbackTeams) != 1 || team.FallbackTeams[0] != "payments" {
+		t.Fatalf("unexpected fallbacks: %v", team.FallbackTeams)
+	}
+}
+
+func TestTeamService_SetFallbackTeams_RollsBackOnError(t *testing.T) {
+	tx, uow := newTx(t)
+	tx.On("GetTeamByName", mock.Anything, mock.Anything).Return(domain.Team{}, nil)
+	tx.On("SetFallbackTeams", mock.Anything, "backend", []string{"payments", "platform"}).Return(domain.Team{}, errors.New("connection reset"))
+
+	svc := NewTeamService(repoMocks.NewMockTeamRepository(t), repoMocks.NewMockUserRepository(t), uow)
+	if _, err := svc.SetFallbackTeams(context.Background(), "backend", []string{"payments", "platform"}); err == nil {
+		t.Fatalf("expected the write error")
+	}
+	tx.AssertCalled(t, "Rollback", mock.Anything)
+	tx.AssertNotCalled(t, "Commit", mock.Anything)
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/tracing"
+)
+
+// Span attributes naming the entity a service call works on.
+const (
+	teamNameKey      = attribute.Key("team.name")
+	userIDKey        = attribute.Key("user.id")
+	pullRequestIDKey = attribute.Key("pull_request.id")
+)
+
+// traced runs fn inside a span called name.
+func traced[T any](ctx context.Context, name string, fn func(context.Context) (T, error), attrs ...attribute.KeyValue) (T, error) {
+	ctx, span := tracing.Start(ctx, name, trace.WithAttributes(attrs...))
+	v, err := fn(ctx)
+	tracing.End(span, err)
+	return v, err
+}
+
+type tracedTeamService struct {
+	next TeamService
+}
+
+// NewTracedTeamService wraps every call to next in a span.
+func NewTracedTeamService(next TeamService) TeamService {
+	return &tracedTeamService{next: next}
+}
+
+func (s *tracedTeamService) AddTeam(ctx context.Context, team domain.Team) (*domain.Team, error) {
+	return traced(ctx, "TeamService.AddTeam", func(ctx context.Context) (*domain.Team, error) {
+		return s.next.AddTeam(ctx, team)
+	}, teamNameKey.String(team.Name))
+}
+
+func (s *tracedTeamService) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	return traced(ctx, "TeamService.GetTeam", func(ctx context.Context) (*domain.Team, error) {
+		return s.next.GetTeam(ctx, teamName)
+	}, teamNameKey.String(teamName))
+}
+
+func (s *tracedTeamService) SetDefaultReviewCapacity(ctx context.Context, teamName string, capacity *int) (*domain.Team, error) {
+	return traced(ctx, "TeamService.SetDefaultReviewCapacity", func(ctx context.Context) (*domain.Team, error) {
+		return s.next.SetDefaultReviewCapacity(ctx, teamName, capacity)
+	}, teamNameKey.String(teamName))
+}
+
+func (s *tracedTeamService) SetFallbackTeams(ctx context.Context, teamName string, fallbacks []string) (*domain.Team, error) {
+	return traced(ctx, "TeamService.SetFallbackTeams", func(ctx context.Context) (*domain.Team, error) {
+		return s.next.SetFallbackTeams(ctx, teamName, fallbacks)
+	}, teamNameKey.String(teamName))
+}
+
+func (s *tracedTeamService) SetPolicy(ctx context.Context, teamName string, policy domain.TeamPolicy) (*domain.Team, error) {
+	return traced(ctx, "TeamService.SetPolicy", func(ctx context.Context) (*domain.Team, error) {
+		return s.next.SetPolicy(ctx, teamName, policy)
+	}, teamNameKey.String(teamName))
+}
+
+type tracedUserService struct {
+	next UserService
+}
+
+// NewTracedUserService wraps every call to next in a span.
+func NewTracedUserService(next UserService) UserService {
+	return &tracedUserService{next: next}
+}
+
+func (s *tracedUserService) SetActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
+	return traced(ctx, "UserService.SetActive", func(ctx context.Context) (*domain.User, error) {
+		return s.next.SetActive(ctx, userID, isActive)
+	}, userIDKey.String(userID))
+}
+
+func (s *tracedUserService) GetReviewPullRequests(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
+	return traced(ctx, "UserService.GetReviewPullRequests", func(ctx context.Context) ([]domain.PullRequestShort, error) {
+		return s.next.GetReviewPullRequests(ctx, userID)
+	}, userIDKey.String(userID))
+}
+
+func (s *tracedUserService) SetWorkingHours(ctx context.Context, userID, timezone string, hours *domain.WorkingHours) (*domain.User, error) {
+	return traced(ctx, "UserService.SetWorkingHours", func(ctx context.Context) (*domain.User, error) {
+		return s.next.SetWorkingHours(ctx, userID, timezone, hours)
+	}, userIDKey.String(userID))
+}
+
+func (s *tracedUserService) SetReviewCapacity(ctx context.Context, userID string, capacity *int) (*domain.User, error) {
+	return traced(ctx, "UserService.SetReviewCapacity", func(ctx context.Context) (*domain.User, error) {
+		return s.next.SetReviewCapacity(ctx, userID, capacity)
+	}, userIDKey.String(userID))
+}
+
+func (s *tracedUserService) SetSeniority(ctx context.Context, userID string, seniority domain.Seniority) (*domain.User, error) {
+	return traced(ctx, "UserService.SetSeniority", func(ctx context.Context) (*domain.User, error) {
+		return s.next.SetSeniority(ctx, userID, seniority)
+	}, userIDKey.String(userID))
+}
+
+func (s *tracedUserService) AddUnavailability(ctx context.Context, period domain.Unavailability) (*domain.Unavailability, error) {
+	return traced(ctx, "UserService.AddUnavailability", func(ctx context.Context) (*domain.Unavailability, error) {
+		return s.next.AddUnavailability(ctx, period)
+	}, userIDKey.String(period.UserID))
+}
+
+func (s *tracedUserService) ListUnavailability(ctx context.Context, userID string) ([]domain.Unavailability, error) {
+	return traced(ctx, "UserService.ListUnavailability", func(ctx context.Context) ([]domain.Unavailability, error) {
+		return s.next.ListUnavailability(ctx, userID)
+	}, userIDKey.String(userID))
+}
+
+func (s *tracedUserService) DeleteUnavailability(ctx context.Context, userID string, periodID int64) error {
+	_, err := traced(ctx, "UserService.DeleteUnavailability", func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, s.next.DeleteUnavailability(ctx, userID, periodID)
+	}, userIDKey.String(userID))
+	return err
+}
+
+func (s *tracedUserService) GetTags(ctx context.Context, userID string) ([]string, error) {
+	return traced(ctx, "UserService.GetTags", func(ctx context.Context) ([]string, error) {
+		return s.next.GetTags(ctx, userID)
+	}, userIDKey.String(userID))
+}
+
+func (s *tracedUserService) SetTags(ctx context.Context, userID string, tags []string) ([]string, error) {
+	return traced(ctx, "UserService.SetTags", func(ctx context.Context) ([]string, error) {
+		return s.next.SetTags(ctx, userID, tags)
+	}, userIDKey.String(userID))
+}
+
+type tracedPullRequestService struct {
+	next PullRequestService
+}
+
+// NewTracedPullRequestService wraps every call to next in a span. The
+// service itself adds child spans around reviewer selection.
+func NewTracedPullRequestService(next PullRequestService) PullRequestService {
+	return &tracedPullRequestService{next: next}
+}
+
+func (s *tracedPullRequestService) Create(ctx context.Context, pr domain.PullRequest) (*domain.PullRequest, error) {
+	return traced(ctx, "PullRequestService.Create", func(ctx context.Context) (*domain.PullRequest, error) {
+		return s.next.Create(ctx, pr)
+	}, pullRequestIDKey.String(pr.ID))
+}
+
+func (s *tracedPullRequestService) GetPullRequest(ctx context.Context, prID string) (*domain.PullRequest, error) {
+	return traced(ctx, "PullRequestService.GetPullRequest", func(ctx context.Context) (*domain.PullRequest, error) {
+		return s.next.GetPullRequest(ctx, prID)
+	}, pullRequestIDKey.String(prID))
+}
+
+func (s *tracedPullRequestService) Merge(ctx context.Context, prID string, ifVersions []int64) (*domain.PullRequest, error) {
+	return traced(ctx, "PullRequestService.Merge", func(ctx context.Context) (*domain.PullRequest, error) {
+		return s.next.Merge(ctx, prID, ifVersions)
+	}, pullRequestIDKey.String(prID))
+}
+
+func (s *tracedPullRequestService) Reassign(ctx context.Context, prID, oldReviewerID string, ifVersions []int64) (*domain.PullRequest, string, error) {
+	var replacedBy string
+	pr, err := traced(ctx, "PullRequestService.Reassign", func(ctx context.Context) (*domain.PullRequest, error) {
+		pr, candidate, err := s.next.Reassign(ctx, prID, oldReviewerID, ifVersions)
+		replacedBy = candidate
+		return pr, err
+	}, pullRequestIDKey.String(prID), userIDKey.String(oldReviewerID))
+	return pr, replacedBy, err
+}
+
+func (s *tracedPullRequestService) PreviewAssignment(ctx context.Context, pr domain.PullRequest) (*domain.AssignmentPreview, error) {
+	return traced(ctx, "PullRequestService.PreviewAssignment", func(ctx context.Context) (*domain.AssignmentPreview, error) {
+		return s.next.PreviewAssignment(ctx, pr)
+	}, userIDKey.String(pr.AuthorID))
+}
+
+type tracedCodeOwnerService struct {
+	next CodeOwnerService
+}
+
+// NewTracedCodeOwnerService wraps every call to next in a span.
+func NewTracedCodeOwnerService(next CodeOwnerService) CodeOwnerService {
+	return &tracedCodeOwnerService{next: next}
+}
+
+func (s *tracedCodeOwnerService) ReplaceRules(ctx context.Context, rules []domain.CodeOwnerRule) ([]domain.CodeOwnerRule, error) {
+	return traced(ctx, "CodeOwnerService.ReplaceRules", func(ctx context.Context) ([]domain.CodeOwnerRule, error) {
+		return s.next.ReplaceRules(ctx, rules)
+	}, attribute.Int("code_owners.rules", len(rules)))
+}
+
+func (s *tracedCodeOwnerService) ListRules(ctx context.Context) ([]domain.CodeOwnerRule, error) {
+	return traced(ctx, "CodeOwnerService.ListRules", func(ctx context.Context) ([]domain.CodeOwnerRule, error) {
+		return s.next.ListRules(ctx)
+	})
+}
@@ -10,6 +10,14 @@ import (
 type UserService interface {
 	SetActive(ctx context.Context, userID string, isActive bool) (*domain.User, error)
 	GetReviewPullRequests(ctx context.Context, userID string) ([]domain.PullRequestShort, error)
+	SetWorkingHours(ctx context.Context, userID, timezone string, hours *domain.WorkingHours) (*domain.User, error)
+	SetReviewCapacity(ctx context.Context, userID string, capacity *int) (*domain.User, error)
+	SetSeniority(ctx context.Context, userID string, seniority domain.Seniority) (*domain.User, error)
+	AddUnavailability(ctx context.Context, period domain.Unavailability) (*domain.Unavailability, error)
+	ListUnavailability(ctx context.Context, userID string) ([]domain.Unavailability, error)
+	DeleteUnavailability(ctx context.Context, userID string, periodID int64) error
+	GetTags(ctx context.Context, userID string) ([]string, error)
+	SetTags(ctx context.Context, userID string, tags []string) ([]string, error)
 }
 
 type userService struct {
@@ -48,3 +56,103 @@ func (s *userService) GetReviewPullRequests(ctx context.Context, userID string)
 	}
 	return s.pullRequests.ListByReviewer(ctx, userID)
 }
+
+func (s *userService) SetWorkingHours(ctx context.Context, userID, timezone string, hours *domain.WorkingHours) (*domain.User, error) {
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.users.SetWorkingHours(ctx, userID, timezone, hours)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (s *userService) SetReviewCapacity(ctx context.Context, userID string, capacity *int) (*domain.User, error) {
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.users.SetReviewCapacity(ctx, userID, capacity)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (s *userService) SetSeniority(ctx context.Context, userID string, seniority domain.Seniority) (*domain.User, error) {
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.users.SetSeniority(ctx, userID, seniority)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (s *userService) AddUnavailability(ctx context.Context, period domain.Unavailability) (*domain.Unavailability, error) {
+	if err := s.ensureUser(ctx, period.UserID); err != nil {
+		return nil, err
+	}
+
+	created, err := s.users.AddUnavailability(ctx, period)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (s *userService) ListUnavailability(ctx context.Context, userID string) ([]domain.Unavailability, error) {
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.users.ListUnavailability(ctx, userID)
+}
+
+func (s *userService) DeleteUnavailability(ctx context.Context, userID string, periodID int64) error {
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return err
+	}
+	return s.users.DeleteUnavailability(ctx, userID, periodID)
+}
+
+func (s *userService) GetTags(ctx context.Context, userID string) ([]string, error) {
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	tags, err := s.users.ListUserTags(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	return tags[userID], nil
+}
+
+func (s *userService) SetTags(ctx context.Context, userID string, tags []string) ([]string, error) {
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.users.SetUserTags(ctx, userID, tags); err != nil {
+		return nil, err
+	}
+
+	stored, err := s.users.ListUserTags(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	return stored[userID], nil
+}
+
+func (s *userService) ensureUser(ctx context.Context, userID string) error {
+	if _, err := s.users.GetUserByID(ctx, userID); err != nil {
+		if derr, ok := domain.AsDomainError(err); ok && derr.Code == domain.ErrorCodeNotFound {
+			return domain.NewDomainError(domain.ErrorCodeNotFound, "user not found")
+		}
+		return err
+	}
+	return nil
+}
@@ -108,3 +108,71 @@ func TestUserService_GetReview_ListError(t *testing.T) {
 		t.Fatalf("expected list fail error, got %v", err)
 	}
 }
+
+func TestUserService_AddUnavailability_Success(t *testing.T) {
+	userRepo := repoMocks.NewMockUserRepository(t)
+	userRepo.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1"}, nil)
+	userRepo.On("AddUnavailability", mock.Anything, mock.AnythingOfType("domain.Unavailability")).Return(domain.Unavailability{ID: 1, UserID: "u1"}, nil)
+	prRepo := repoMocks.NewMockPullRequestRepository(t)
+	svc := NewUserService(userRepo, prRepo)
+
+	period, err := svc.AddUnavailability(context.Background(), domain.Unavailability{UserID: "u1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if period.ID != 1 {
+		t.Fatalf("expected period id 1, got %d", period.ID)
+	}
+}
+
+func TestUserService_AddUnavailability_UserNotFound(t *testing.T) {
+	userRepo := repoMocks.NewMockUserRepository(t)
+	userRepo.On("GetUserByID", mock.Anything, "u1").Return(domain.User{}, domain.NewDomainError(domain.ErrorCodeNotFound, "nope"))
+	prRepo := repoMocks.NewMockPullRequestRepository(t)
+	svc := NewUserService(userRepo, prRepo)
+
+	_, err := svc.AddUnavailability(context.Background(), domain.Unavailability{UserID: "u1"})
+	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeNotFound || derr.Message != "user not found" {
+		t.Fatalf("expected not found domain error, got %v", err)
+	}
+}
+
+func TestUserService_DeleteUnavailability_NotFound(t *testing.T) {
+	userRepo := repoMocks.NewMockUserRepository(t)
+	userRepo.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1"}, nil)
+	userRepo.On("DeleteUnavailability", mock.Anything, "u1", int64(5)).Return(domain.NewDomainError(domain.ErrorCodeNotFound, "unavailability period not found"))
+	prRepo := repoMocks.NewMockPullRequestRepository(t)
+	svc := NewUserService(userRepo, prRepo)
+
+	err := svc.DeleteUnavailability(context.Background(), "u1", 5)
+	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeNotFound {
+		t.Fatalf("expected not found domain error, got %v", err)
+	}
+}
+
+func TestUserService_SetTags_Success(t *testing.T) {
+	userRepo := repoMocks.NewMockUserRepository(t)
+	userRepo.On("GetUserByID", mock.Anything, "u1").Return(domain.User{ID: "u1"}, nil)
+	userRepo.On("SetUserTags", mock.Anything, "u1", []string{"go", "sql"}).Return(nil)
+	userRepo.On("ListUserTags", mock.Anything, []string{"u1"}).Return(map[string][]string{"u1": {"go", "sql"}}, nil)
+	svc := NewUserService(userRepo, repoMocks.NewMockPullRequestRepository(t))
+
+	tags, err := svc.SetTags(context.Background(), "u1", []string{"go", "sql"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tags) != 2 || tags[0] != "go" {
+		t.Fatalf("unexpected tags: %v", tags)
+	}
+}
+
+func TestUserService_GetTags_UserNotFound(t *testing.T) {
+	userRepo := repoMocks.NewMockUserRepository(t)
+	userRepo.On("GetUserByID", mock.Anything, "u1").Return(domain.User{}, domain.NewDomainError(domain.ErrorCodeNotFound, "not found"))
+	svc := NewUserService(userRepo, repoMocks.NewMockPullRequestRepository(t))
+
+	_, err := svc.GetTags(context.Background(), "u1")
+	if derr, ok := domain.AsDomainError(err); !ok || derr.Code != domain.ErrorCodeNotFound || derr.Message != "user not found" {
+		t.Fatalf("expected not found domain error, got %v", err)
+	}
+}
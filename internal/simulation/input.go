@@ -0,0 +1,159 @@
+package simulation
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"pr-reviewer/internal/domain"
+)
+
+type teamInput struct {
+	Name                  string        `json:"team_name"`
+	DefaultReviewCapacity *int          `json:"default_review_capacity"`
+	RequireSeniorReviewer bool          `json:"require_senior_reviewer"`
+	FallbackTeams         []string      `json:"fallback_teams"`
+	Members               []memberInput `json:"members"`
+}
+
+type memberInput struct {
+	ID             string `json:"user_id"`
+	Username       string `json:"username"`
+	IsActive       *bool  `json:"is_active"`
+	Timezone       string `json:"timezone"`
+	WorkStart      string `json:"work_start"`
+	WorkEnd        string `json:"work_end"`
+	ReviewCapacity *int   `json:"review_capacity"`
+	Seniority      string `json:"seniority"`
+}
+
+// ReadTeams decodes a JSON array of teams in the /team/add format extended
+// with fallback_teams. Members are active unless is_active is false.
+func ReadTeams(r io.Reader) ([]domain.Team, error) {
+	var input []teamInput
+	if err := json.NewDecoder(r).Decode(&input); err != nil {
+		return nil, fmt.Errorf("decode teams: %w", err)
+	}
+
+	teams := make([]domain.Team, 0, len(input))
+	for _, t := range input {
+		if t.Name == "" {
+			return nil, errors.New("team_name is required")
+		}
+		team := domain.Team{
+			Name:                  t.Name,
+			DefaultReviewCapacity: t.DefaultReviewCapacity,
+			Policy:                domain.TeamPolicy{RequireSeniorReviewer: t.RequireSeniorReviewer},
+			FallbackTeams:         t.FallbackTeams,
+		}
+		for _, m := range t.Members {
+			member, err := m.toUser()
+			if err != nil {
+				return nil, fmt.Errorf("team %s: %w", t.Name, err)
+			}
+			team.Members = append(team.Members, member)
+		}
+		teams = append(teams, team)
+	}
+	return teams, nil
+}
+
+func (m memberInput) toUser() (domain.User, error) {
+	if m.ID == "" {
+		return domain.User{}, errors.New("user_id is required")
+	}
+	u := domain.User{
+		ID:             m.ID,
+		Username:       m.Username,
+		IsActive:       m.IsActive == nil || *m.IsActive,
+		Timezone:       m.Timezone,
+		ReviewCapacity: m.ReviewCapacity,
+		Seniority:      domain.Seniority(m.Seniority),
+	}
+	if u.Seniority != "" && !u.Seniority.Valid() {
+		return domain.User{}, fmt.Errorf("user %s: invalid seniority %q", m.ID, m.Seniority)
+	}
+	if m.WorkStart != "" || m.WorkEnd != "" {
+		start, err := parseClock(m.WorkStart)
+		if err != nil {
+			return domain.User{}, fmt.Errorf("user %s: invalid work_start: %w", m.ID, err)
+		}
+		end, err := parseClock(m.WorkEnd)
+		if err != nil {
+			return domain.User{}, fmt.Errorf("user %s: invalid work_end: %w", m.ID, err)
+		}
+		u.WorkingHours = &domain.WorkingHours{StartMinute: start, EndMinute: end}
+	}
+	return u, nil
+}
+
+func parseClock(v string) (int, error) {
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, errors.New("expected HH:MM")
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+var eventsHeader = []string{"at", "event", "pull_request_id", "user_id"}
+
+// ReadEvents decodes a CSV stream with the header
+// "at,event,pull_request_id,user_id", where at is an RFC 3339 timestamp.
+func ReadEvents(r io.Reader) ([]Event, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(eventsHeader)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("read header: %w", err)
+	}
+	if strings.Join(header, ",") != strings.Join(eventsHeader, ",") {
+		return nil, fmt.Errorf("unexpected header %q, want %q", strings.Join(header, ","), strings.Join(eventsHeader, ","))
+	}
+
+	var events []Event
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return events, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		at, err := time.Parse(time.RFC3339, record[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid time %q", line, record[0])
+		}
+		e := Event{At: at, Kind: EventKind(record[1]), PullRequestID: record[2], UserID: record[3]}
+		if err := e.validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		events = append(events, e)
+	}
+}
+
+func (e Event) validate() error {
+	switch e.Kind {
+	case EventCreate:
+		if e.PullRequestID == "" || e.UserID == "" {
+			return errors.New("create needs pull_request_id and user_id")
+		}
+	case EventMerge:
+		if e.PullRequestID == "" {
+			return errors.New("merge needs pull_request_id")
+		}
+	case EventDeactivate, EventActivate:
+		if e.UserID == "" {
+			return fmt.Errorf("%s needs user_id", e.Kind)
+		}
+	default:
+		return fmt.Errorf("unknown event %q", e.Kind)
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+// Package simulation replays a stream of pull request events through the real
+// service layer backed by an in-memory repository, so selection strategies can
+// be compared before they are enabled in production.
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/repositorymemory"
+	"pr-reviewer/internal/service"
+)
+
+type EventKind string
+
+const (
+	EventCreate     EventKind = "create"
+	EventMerge      EventKind = "merge"
+	EventDeactivate EventKind = "deactivate"
+	EventActivate   EventKind = "activate"
+)
+
+// Event is one step of the simulated stream. Create uses UserID as the author
+// and Merge only needs PullRequestID; Deactivate and Activate only need UserID.
+type Event struct {
+	At            time.Time
+	Kind          EventKind
+	PullRequestID string
+	UserID        string
+}
+
+type Strategy struct {
+	Name   string
+	Policy service.SelectionPolicy
+}
+
+// Strategies returns the named selection strategies. pairingWindow is used by
+// the strategies that penalize repeated author-reviewer pairs; reviewers is
+// the number of reviewers each of them assigns, zero meaning the service
+// default.
+func Strategies(names []string, pairingWindow time.Duration, reviewers int) ([]Strategy, error) {
+	catalog := map[string]service.SelectionPolicy{
+		"default":               {},
+		"working-hours":         {PreferWorkingHours: true},
+		"pairing":               {PairingWindow: pairingWindow},
+		"working-hours+pairing": {PreferWorkingHours: true, PairingWindow: pairingWindow},
+	}
+
+	var strategies []Strategy
+	for _, name := range names {
+		policy, ok := catalog[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown strategy %q", name)
+		}
+		policy.Reviewers = reviewers
+		strategies = append(strategies, Strategy{Name: name, Policy: policy})
+	}
+	return strategies, nil
+}
+
+type Result struct {
+	Strategy string
+	Metrics
+	// ByTeam splits the metrics by team: pull requests and their slots count
+	// towards the author's team, reviews towards the reviewer's.
+	ByTeam map[string]*Metrics
+
+	teamOf map[string]string
+}
+
+type Metrics struct {
+	// Reviews is the number of review assignments each member received.
+	Reviews      map[string]int
+	PullRequests int
+	Understaffed int
+	// Slots counts reviewer slots requested on creation and reassignment;
+	// NoCandidate counts the ones that could not be filled.
+	Slots       int
+	NoCandidate int
+}
+
+func newMetrics() *Metrics {
+	return &Metrics{Reviews: make(map[string]int)}
+}
+
+func (m Metrics) NoCandidateRate() float64 {
+	if m.Slots == 0 {
+		return 0
+	}
+	return float64(m.NoCandidate) / float64(m.Slots)
+}
+
+func (m Metrics) Gini() float64 {
+	return Gini(m.counts())
+}
+
+// Percentile returns the review count at percentile p (0..1) across members.
+func (m Metrics) Percentile(p float64) int {
+	counts := m.counts()
+	if len(counts) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p*float64(len(counts)))) - 1
+	return counts[max(i, 0)]
+}
+
+func (m Metrics) counts() []int {
+	counts := make([]int, 0, len(m.Reviews))
+	for _, n := range m.Reviews {
+		counts = append(counts, n)
+	}
+	slices.Sort(counts)
+	return counts
+}
+
+// Gini returns the Gini coefficient of counts: 0 when everyone has the same
+// count, approaching 1 when a single member has all of it.
+func Gini(counts []int) float64 {
+	sorted := slices.Clone(counts)
+	slices.Sort(sorted)
+
+	var sum, weighted float64
+	for i, c := range sorted {
+		sum += float64(c)
+		weighted += float64(i+1) * float64(c)
+	}
+	if sum == 0 {
+		return 0
+	}
+	n := float64(len(sorted))
+	return 2*weighted/(n*sum) - (n+1)/n
+}
+
+// Run replays events against a fresh in-memory store using strategy.
+func Run(ctx context.Context, teams []domain.Team, events []Event, strategy Strategy) (Result, error) {
+	store := repositorymemory.NewStore()
+	teamRepo := repositorymemory.NewTeamRepository(store)
+	userRepo := repositorymemory.NewUserRepository(store)
+	prRepo := repositorymemory.NewPullRequestRepository(store)
+	uow := repositorymemory.NewUnitOfWork(store)
+
+	var now time.Time
+	prs := service.NewPullRequestService(prRepo, userRepo, teamRepo, uow, nil,
+		service.WithClock(func() time.Time { return now }),
+		service.WithSelectionPolicy(strategy.Policy),
+	)
+	users := service.NewUserService(userRepo, prRepo)
+
+	result := Result{
+		Strategy: strategy.Name,
+		Metrics:  *newMetrics(),
+		ByTeam:   make(map[string]*Metrics, len(teams)),
+		teamOf:   make(map[string]string),
+	}
+	for _, t := range teams {
+		if _, err := teamRepo.UpsertTeam(ctx, t); err != nil {
+			return Result{}, err
+		}
+		team := newMetrics()
+		for _, m := range t.Members {
+			result.Reviews[m.ID] = 0
+			team.Reviews[m.ID] = 0
+			result.teamOf[m.ID] = t.Name
+		}
+		result.ByTeam[t.Name] = team
+	}
+	for _, t := range teams {
+		if len(t.FallbackTeams) == 0 {
+			continue
+		}
+		if _, err := teamRepo.SetFallbackTeams(ctx, t.Name, t.FallbackTeams); err != nil {
+			return Result{}, err
+		}
+	}
+
+	for i, e := range events {
+		now = e.At
+		if err := result.apply(ctx, prs, users, e, strategy.Policy.ReviewerCount()); err != nil {
+			return Result{}, fmt.Errorf("event %d (%s): %w", i+1, e.Kind, err)
+		}
+	}
+	return result, nil
+}
+
+// apply replays e; reviewers is the number of slots a new pull request asks
+// for.
+func (r *Result) apply(ctx context.Context, prs service.PullRequestService, users service.UserService, e Event, reviewers int) error {
+	switch e.Kind {
+	case EventCreate:
+		pr, err := prs.Create(ctx, domain.PullRequest{ID: e.PullRequestID, Name: e.PullRequestID, AuthorID: e.UserID})
+		if err != nil {
+			return err
+		}
+		r.record(pr.AuthorID, func(m *Metrics) {
+			m.PullRequests++
+			m.Slots += reviewers
+			m.NoCandidate += max(reviewers-len(pr.AssignedReviewers), 0)
+			if pr.Understaffed {
+				m.Understaffed++
+			}
+		})
+		for _, id := range pr.AssignedReviewers {
+			r.addReview(id)
+		}
+	case EventMerge:
+		if _, err := prs.Merge(ctx, e.PullRequestID, nil); err != nil {
+			return err
+		}
+	case EventActivate:
+		if _, err := users.SetActive(ctx, e.UserID, true); err != nil {
+			return err
+		}
+	case EventDeactivate:
+		if _, err := users.SetActive(ctx, e.UserID, false); err != nil {
+			return err
+		}
+		return r.handOver(ctx, prs, users, e.UserID)
+	default:
+		return fmt.Errorf("unknown event %q", e.Kind)
+	}
+	return nil
+}
+
+// handOver reassigns the open reviews of a deactivated user.
+func (r *Result) handOver(ctx context.Context, prs service.PullRequestService, users service.UserService, userID string) error {
+	reviews, err := users.GetReviewPullRequests(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, pr := range reviews {
+		if pr.Status != domain.PullRequestStatusOpen {
+			continue
+		}
+		r.record(pr.AuthorID, func(m *Metrics) { m.Slots++ })
+		_, replacedBy, err := prs.Reassign(ctx, pr.ID, userID, nil)
+		if derr, ok := domain.AsDomainError(err); ok && derr.Code == domain.ErrorCodeNoCandidate {
+			r.record(pr.AuthorID, func(m *Metrics) { m.NoCandidate++ })
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		r.addReview(replacedBy)
+	}
+	return nil
+}
+
+// record applies fn to the overall metrics and to those of userID's team.
+func (r *Result) record(userID string, fn func(*Metrics)) {
+	fn(&r.Metrics)
+	if team, ok := r.ByTeam[r.teamOf[userID]]; ok {
+		fn(team)
+	}
+}
+
+func (r *Result) addReview(reviewerID string) {
+	r.record(reviewerID, func(m *Metrics) { m.Reviews[reviewerID]++ })
+}
+
+// Members returns the member IDs in a stable order for reporting.
+func (r Result) Members() []string {
+	return slices.Sorted(maps.Keys(r.Reviews))
+}
+
+// Teams returns the team names in a stable order for reporting.
+func (r Result) Teams() []string {
+	return slices.Sorted(maps.Keys(r.ByTeam))
+}
@@ -0,0 +1,220 @@
+package simulation
+
+import (
+	"context"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/service"
+)
+
+func TestGini(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts []int
+		want   float64
+	}{
+		{name: "empty", counts: nil, want: 0},
+		{name: "all zero", counts: []int{0, 0, 0}, want: 0},
+		{name: "equal", counts: []int{5, 5, 5, 5}, want: 0},
+		{name: "one has everything", counts: []int{0, 0, 0, 8}, want: 0.75},
+		{name: "unsorted input", counts: []int{3, 1, 2}, want: 2.0 / 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Gini(tt.counts); math.Abs(got-tt.want) > 1e-9 {
+				t.Fatalf("expected %.4f, got %.4f", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReadEvents(t *testing.T) {
+	events, err := ReadEvents(strings.NewReader(`at,event,pull_request_id,user_id
+2025-01-06T10:00:00Z,create,pr-1,u1
+2025-01-06T11:00:00Z,deactivate,,u2
+2025-01-06T12:00:00Z,merge,pr-1,
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 3 || events[0].Kind != EventCreate || events[1].UserID != "u2" || events[2].PullRequestID != "pr-1" {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	for _, content := range []string{
+		"when,event,pull_request_id,user_id\n",
+		"at,event,pull_request_id,user_id\nyesterday,create,pr-1,u1\n",
+		"at,event,pull_request_id,user_id\n2025-01-06T10:00:00Z,close,pr-1,u1\n",
+		"at,event,pull_request_id,user_id\n2025-01-06T10:00:00Z,create,,u1\n",
+	} {
+		if _, err := ReadEvents(strings.NewReader(content)); err == nil {
+			t.Fatalf("expected error for %q", content)
+		}
+	}
+}
+
+func TestReadTeams(t *testing.T) {
+	teams, err := ReadTeams(strings.NewReader(`[
+		{"team_name": "backend", "default_review_capacity": 2, "fallback_teams": ["platform"], "members": [
+			{"user_id": "u1", "seniority": "senior", "timezone": "Europe/Moscow", "work_start": "09:00", "work_end": "18:00"},
+			{"user_id": "u2", "is_active": false}
+		]}
+	]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	team := teams[0]
+	if team.Name != "backend" || *team.DefaultReviewCapacity != 2 || team.FallbackTeams[0] != "platform" {
+		t.Fatalf("unexpected team: %+v", team)
+	}
+	if !team.Members[0].IsActive || team.Members[0].WorkingHours.StartMinute != 9*60 || team.Members[1].IsActive {
+		t.Fatalf("unexpected members: %+v", team.Members)
+	}
+
+	if _, err := ReadTeams(strings.NewReader(`[{"team_name": "backend", "members": [{"user_id": "u1", "seniority": "lead"}]}]`)); err == nil {
+		t.Fatalf("expected invalid seniority error")
+	}
+}
+
+func TestRun(t *testing.T) {
+	capacity := 1
+	teams := []domain.Team{{
+		Name:                  "backend",
+		DefaultReviewCapacity: &capacity,
+		Members: []domain.User{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+		},
+	}}
+	at := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+	events := []Event{
+		{At: at, Kind: EventCreate, PullRequestID: "pr-1", UserID: "u1"},
+		// u2 and u3 are at capacity, so pr-2 gets nobody.
+		{At: at, Kind: EventCreate, PullRequestID: "pr-2", UserID: "u1"},
+		{At: at, Kind: EventMerge, PullRequestID: "pr-1"},
+		{At: at, Kind: EventCreate, PullRequestID: "pr-3", UserID: "u2"},
+		// u1 and u3 review pr-3 and u2 is its author, so nobody takes over.
+		{At: at, Kind: EventDeactivate, UserID: "u3"},
+	}
+
+	result, err := Run(context.Background(), teams, events, Strategy{Name: "default"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.PullRequests != 3 || result.Understaffed != 1 {
+		t.Fatalf("unexpected totals: %+v", result)
+	}
+	if result.Slots != 7 || result.NoCandidate != 3 {
+		t.Fatalf("expected 3 of 7 slots without candidate, got %d of %d", result.NoCandidate, result.Slots)
+	}
+	if result.Reviews["u1"] != 1 || result.Reviews["u2"] != 1 || result.Reviews["u3"] != 2 {
+		t.Fatalf("unexpected reviews: %v", result.Reviews)
+	}
+}
+
+func TestRun_CountsSlotsForReviewerCount(t *testing.T) {
+	teams := []domain.Team{{
+		Name: "backend",
+		Members: []domain.User{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+			{ID: "u4", IsActive: true},
+		},
+	}}
+	at := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+	events := []Event{
+		{At: at, Kind: EventCreate, PullRequestID: "pr-1", UserID: "u1"},
+	}
+
+	for _, tt := range []struct {
+		reviewers, slots, noCandidate int
+	}{
+		{reviewers: 1, slots: 1, noCandidate: 0},
+		{reviewers: 3, slots: 3, noCandidate: 0},
+		// Only three members can review u1's pull request.
+		{reviewers: 4, slots: 4, noCandidate: 1},
+	} {
+		result, err := Run(context.Background(), teams, events, Strategy{Name: "default", Policy: service.SelectionPolicy{Reviewers: tt.reviewers}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Slots != tt.slots || result.NoCandidate != tt.noCandidate {
+			t.Fatalf("%d reviewers: expected %d of %d slots without candidate, got %d of %d", tt.reviewers, tt.noCandidate, tt.slots, result.NoCandidate, result.Slots)
+		}
+	}
+}
+
+func TestRun_SplitsMetricsByTeam(t *testing.T) {
+	teams := []domain.Team{
+		{Name: "backend", Members: []domain.User{
+			{ID: "u1", IsActive: true},
+			{ID: "u2", IsActive: true},
+			{ID: "u3", IsActive: true},
+		}},
+		// mobile has nobody to review its author's pull requests.
+		{Name: "mobile", Members: []domain.User{{ID: "m1", IsActive: true}}},
+		// platform borrows its reviewers from backend.
+		{Name: "platform", FallbackTeams: []string{"backend"}, Members: []domain.User{{ID: "p1", IsActive: true}}},
+	}
+	at := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+	events := []Event{
+		{At: at, Kind: EventCreate, PullRequestID: "pr-1", UserID: "u1"},
+		{At: at, Kind: EventCreate, PullRequestID: "pr-2", UserID: "m1"},
+		{At: at, Kind: EventCreate, PullRequestID: "pr-3", UserID: "p1"},
+	}
+
+	result, err := Run(context.Background(), teams, events, Strategy{Name: "default"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := result.Teams(); !slices.Equal(got, []string{"backend", "mobile", "platform"}) {
+		t.Fatalf("unexpected teams: %v", got)
+	}
+
+	backend, mobile, platform := result.ByTeam["backend"], result.ByTeam["mobile"], result.ByTeam["platform"]
+	if backend.PullRequests != 1 || backend.Slots != 2 || backend.NoCandidate != 0 || backend.Understaffed != 0 {
+		t.Fatalf("unexpected backend metrics: %+v", backend)
+	}
+	if mobile.PullRequests != 1 || mobile.Slots != 2 || mobile.NoCandidate != 2 || mobile.Understaffed != 1 {
+		t.Fatalf("unexpected mobile metrics: %+v", mobile)
+	}
+	if platform.PullRequests != 1 || platform.Slots != 2 || platform.NoCandidate != 0 {
+		t.Fatalf("unexpected platform metrics: %+v", platform)
+	}
+
+	// Borrowed reviews count towards the reviewer's team.
+	backendReviews := 0
+	for _, n := range backend.Reviews {
+		backendReviews += n
+	}
+	if backendReviews != 4 || len(backend.Reviews) != 3 {
+		t.Fatalf("expected 4 reviews across backend members, got %v", backend.Reviews)
+	}
+	if !maps.Equal(mobile.Reviews, map[string]int{"m1": 0}) || !maps.Equal(platform.Reviews, map[string]int{"p1": 0}) {
+		t.Fatalf("unexpected reviews: mobile %v, platform %v", mobile.Reviews, platform.Reviews)
+	}
+	if result.Slots != 6 || result.NoCandidate != 2 {
+		t.Fatalf("expected the totals to add up, got %d of %d slots without candidate", result.NoCandidate, result.Slots)
+	}
+}
+
+func TestStrategies(t *testing.T) {
+	strategies, err := Strategies([]string{"default", "working-hours+pairing"}, time.Hour, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strategies[0].Policy != (service.SelectionPolicy{Reviewers: 3}) || !strategies[1].Policy.PreferWorkingHours || strategies[1].Policy.PairingWindow != time.Hour {
+		t.Fatalf("unexpected strategies: %+v", strategies)
+	}
+	if _, err := Strategies([]string{"random"}, time.Hour, 0); err == nil {
+		t.Fatalf("expected unknown strategy error")
+	}
+}
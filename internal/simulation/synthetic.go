@@ -0,0 +1,116 @@
+package simulation
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"pr-reviewer/internal/domain"
+)
+
+// SyntheticConfig describes a generated workload.
+type SyntheticConfig struct {
+	Teams          int
+	TeamSize       int
+	ReviewCapacity int
+	PullRequests   int
+	Start          time.Time
+	Interval       time.Duration
+	// MergeAfter is how many later creations a pull request stays open for.
+	MergeAfter int
+	// DeactivationRate is the chance that an event step deactivates someone,
+	// who comes back after DeactivationSteps steps.
+	DeactivationRate  float64
+	DeactivationSteps int
+	Seed              uint64
+}
+
+var syntheticZones = []string{"UTC", "Europe/Moscow", "America/New_York", "Asia/Tokyo"}
+
+var syntheticSeniority = []domain.Seniority{domain.SeniorityJunior, domain.SeniorityMiddle, domain.SenioritySenior}
+
+// SyntheticTeams builds cfg.Teams teams spread over several timezones, each
+// falling back to the next one.
+func SyntheticTeams(cfg SyntheticConfig) []domain.Team {
+	teams := make([]domain.Team, 0, cfg.Teams)
+	for t := 0; t < cfg.Teams; t++ {
+		capacity := cfg.ReviewCapacity
+		team := domain.Team{Name: fmt.Sprintf("team-%d", t+1)}
+		if capacity > 0 {
+			team.DefaultReviewCapacity = &capacity
+		}
+		if cfg.Teams > 1 {
+			team.FallbackTeams = []string{fmt.Sprintf("team-%d", (t+1)%cfg.Teams+1)}
+		}
+		for m := 0; m < cfg.TeamSize; m++ {
+			id := fmt.Sprintf("t%d-u%d", t+1, m+1)
+			team.Members = append(team.Members, domain.User{
+				ID:           id,
+				Username:     id,
+				IsActive:     true,
+				Timezone:     syntheticZones[m%len(syntheticZones)],
+				WorkingHours: &domain.WorkingHours{StartMinute: 9 * 60, EndMinute: 18 * 60},
+				Seniority:    syntheticSeniority[m%len(syntheticSeniority)],
+			})
+		}
+		teams = append(teams, team)
+	}
+	return teams
+}
+
+// SyntheticEvents generates a reproducible stream of creations, merges and
+// temporary deactivations for teams.
+func SyntheticEvents(teams []domain.Team, cfg SyntheticConfig) []Event {
+	rng := rand.New(rand.NewPCG(cfg.Seed, 0))
+
+	var members []string
+	for _, t := range teams {
+		for _, m := range t.Members {
+			members = append(members, m.ID)
+		}
+	}
+	if len(members) == 0 {
+		return nil
+	}
+
+	var (
+		events   []Event
+		open     []string
+		inactive = make(map[string]int)
+	)
+	at := cfg.Start
+	for i := 0; i < cfg.PullRequests; i++ {
+		at = at.Add(cfg.Interval)
+
+		for _, id := range slices.Sorted(maps.Keys(inactive)) {
+			if inactive[id] <= i {
+				delete(inactive, id)
+				events = append(events, Event{At: at, Kind: EventActivate, UserID: id})
+			}
+		}
+		// Someone always stays active to author pull requests.
+		if cfg.DeactivationRate > 0 && len(inactive) < len(members)-1 && rng.Float64() < cfg.DeactivationRate {
+			id := members[rng.IntN(len(members))]
+			if _, ok := inactive[id]; !ok {
+				inactive[id] = i + max(cfg.DeactivationSteps, 1)
+				events = append(events, Event{At: at, Kind: EventDeactivate, UserID: id})
+			}
+		}
+
+		author := members[rng.IntN(len(members))]
+		for _, ok := inactive[author]; ok; _, ok = inactive[author] {
+			author = members[rng.IntN(len(members))]
+		}
+		prID := fmt.Sprintf("pr-%d", i+1)
+		events = append(events, Event{At: at, Kind: EventCreate, PullRequestID: prID, UserID: author})
+		open = append(open, prID)
+
+		if cfg.MergeAfter > 0 && len(open) > cfg.MergeAfter {
+			events = append(events, Event{At: at, Kind: EventMerge, PullRequestID: open[0]})
+			open = open[1:]
+		}
+	}
+	return events
+}
@@ -0,0 +1,35 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+
+	"pr-reviewer/internal/domain"
+	"pr-reviewer/internal/tracing"
+	"pr-reviewer/internal/tracing/tracingtest"
+)
+
+func TestEnd(t *testing.T) {
+	spans := tracingtest.Record(t)
+
+	_, span := tracing.Start(context.Background(), "domain")
+	tracing.End(span, domain.NewDomainError(domain.ErrorCodeNotFound, "missing"))
+	_, span = tracing.Start(context.Background(), "failure")
+	tracing.End(span, errors.New("boom"))
+
+	domainSpan := tracingtest.Find(t, spans, "domain")
+	if domainSpan.Status.Code != codes.Unset || len(domainSpan.Events) != 0 {
+		t.Fatalf("a domain error must not fail the span, got %+v", domainSpan.Status)
+	}
+	if len(domainSpan.Attributes) != 1 || domainSpan.Attributes[0] != tracing.ErrorCodeKey.String("NOT_FOUND") {
+		t.Fatalf("expected the error code attribute, got %v", domainSpan.Attributes)
+	}
+
+	failed := tracingtest.Find(t, spans, "failure")
+	if failed.Status.Code != codes.Error || failed.Status.Description != "boom" || len(failed.Events) != 1 {
+		t.Fatalf("expected a failed span with the error recorded, got %+v", failed)
+	}
+}
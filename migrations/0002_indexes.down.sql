@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_pr_reviewers_reviewer;
+DROP INDEX IF EXISTS idx_pr_author;
+DROP INDEX IF EXISTS idx_pr_status;
+DROP INDEX IF EXISTS idx_users_team_active;
@@ -0,0 +1 @@
+DROP VIEW IF EXISTS pr_status_counts;
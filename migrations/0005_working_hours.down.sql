@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS work_end_minute;
+ALTER TABLE users DROP COLUMN IF EXISTS work_start_minute;
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
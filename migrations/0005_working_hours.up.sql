@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS work_start_minute INT CHECK (work_start_minute BETWEEN 0 AND 1439);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS work_end_minute INT CHECK (work_end_minute BETWEEN 0 AND 1439);
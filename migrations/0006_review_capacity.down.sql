@@ -0,0 +1,3 @@
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS understaffed;
+ALTER TABLE users DROP COLUMN IF EXISTS review_capacity;
+ALTER TABLE teams DROP COLUMN IF EXISTS default_review_capacity;
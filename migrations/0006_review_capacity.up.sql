@@ -0,0 +1,3 @@
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS default_review_capacity INT CHECK (default_review_capacity >= 0);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS review_capacity INT CHECK (review_capacity >= 0);
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS understaffed BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS code_owner_rules (
+    position INT PRIMARY KEY,
+    pattern TEXT NOT NULL,
+    owners TEXT NOT NULL DEFAULT ''
+);
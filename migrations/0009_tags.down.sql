@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS pull_request_tags;
+DROP TABLE IF EXISTS user_tags;
@@ -0,0 +1,4 @@
+ALTER TABLE teams DROP COLUMN IF EXISTS require_senior_reviewer;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_seniority_check;
+ALTER TABLE users DROP COLUMN IF EXISTS seniority;
@@ -0,0 +1,11 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS seniority TEXT NOT NULL DEFAULT 'middle';
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'users_seniority_check') THEN
+        ALTER TABLE users ADD CONSTRAINT users_seniority_check CHECK (seniority IN ('junior', 'middle', 'senior'));
+    END IF;
+END
+$$;
+
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS require_senior_reviewer BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_pull_requests_author_created ON pull_requests(author_id, created_at);
@@ -0,0 +1,8 @@
+// Package migrations holds the PostgreSQL schema migrations, embedded into
+// the binary so that the image does not need the .sql files next to it.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -39,6 +39,86 @@ func (_m *MockPullRequestRepository) EXPECT() *MockPullRequestRepository_Expecte
 	return &MockPullRequestRepository_Expecter{mock: &_m.Mock}
 }
 
+// CountRecentPairings provides a mock function for the type MockPullRequestRepository
+func (_mock *MockPullRequestRepository) CountRecentPairings(ctx context.Context, authorID string, reviewerIDs []string, since time.Time) (map[string]int, error) {
+	ret := _mock.Called(ctx, authorID, reviewerIDs, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountRecentPairings")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, time.Time) (map[string]int, error)); ok {
+		return returnFunc(ctx, authorID, reviewerIDs, since)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, time.Time) map[string]int); ok {
+		r0 = returnFunc(ctx, authorID, reviewerIDs, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string, time.Time) error); ok {
+		r1 = returnFunc(ctx, authorID, reviewerIDs, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPullRequestRepository_CountRecentPairings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountRecentPairings'
+type MockPullRequestRepository_CountRecentPairings_Call struct {
+	*mock.Call
+}
+
+// CountRecentPairings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authorID string
+//   - reviewerIDs []string
+//   - since time.Time
+func (_e *MockPullRequestRepository_Expecter) CountRecentPairings(ctx interface{}, authorID interface{}, reviewerIDs interface{}, since interface{}) *MockPullRequestRepository_CountRecentPairings_Call {
+	return &MockPullRequestRepository_CountRecentPairings_Call{Call: _e.mock.On("CountRecentPairings", ctx, authorID, reviewerIDs, since)}
+}
+
+func (_c *MockPullRequestRepository_CountRecentPairings_Call) Run(run func(ctx context.Context, authorID string, reviewerIDs []string, since time.Time)) *MockPullRequestRepository_CountRecentPairings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPullRequestRepository_CountRecentPairings_Call) Return(m map[string]int, err error) *MockPullRequestRepository_CountRecentPairings_Call {
+	_c.Call.Return(m, err)
+	return _c
+}
+
+func (_c *MockPullRequestRepository_CountRecentPairings_Call) RunAndReturn(run func(ctx context.Context, authorID string, reviewerIDs []string, since time.Time) (map[string]int, error)) *MockPullRequestRepository_CountRecentPairings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreatePullRequest provides a mock function for the type MockPullRequestRepository
 func (_mock *MockPullRequestRepository) CreatePullRequest(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error) {
 	ret := _mock.Called(ctx, pr)
@@ -312,8 +392,8 @@ func (_c *MockPullRequestRepository_MergePullRequest_Call) RunAndReturn(run func
 }
 
 // ReassignReviewer provides a mock function for the type MockPullRequestRepository
-func (_mock *MockPullRequestRepository) ReassignReviewer(ctx context.Context, prID string, oldReviewerID string, newReviewerID string) (domain.PullRequest, error) {
-	ret := _mock.Called(ctx, prID, oldReviewerID, newReviewerID)
+func (_mock *MockPullRequestRepository) ReassignReviewer(ctx context.Context, prID string, oldReviewerID string, newReviewerID string, sourceTeam string) (domain.PullRequest, error) {
+	ret := _mock.Called(ctx, prID, oldReviewerID, newReviewerID, sourceTeam)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ReassignReviewer")
@@ -321,16 +401,16 @@ func (_mock *MockPullRequestRepository) ReassignReviewer(ctx context.Context, pr
 
 	var r0 domain.PullRequest
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (domain.PullRequest, error)); ok {
-		return returnFunc(ctx, prID, oldReviewerID, newReviewerID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (domain.PullRequest, error)); ok {
+		return returnFunc(ctx, prID, oldReviewerID, newReviewerID, sourceTeam)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) domain.PullRequest); ok {
-		r0 = returnFunc(ctx, prID, oldReviewerID, newReviewerID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) domain.PullRequest); ok {
+		r0 = returnFunc(ctx, prID, oldReviewerID, newReviewerID, sourceTeam)
 	} else {
 		r0 = ret.Get(0).(domain.PullRequest)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = returnFunc(ctx, prID, oldReviewerID, newReviewerID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = returnFunc(ctx, prID, oldReviewerID, newReviewerID, sourceTeam)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -347,11 +427,12 @@ type MockPullRequestRepository_ReassignReviewer_Call struct {
 //   - prID string
 //   - oldReviewerID string
 //   - newReviewerID string
-func (_e *MockPullRequestRepository_Expecter) ReassignReviewer(ctx interface{}, prID interface{}, oldReviewerID interface{}, newReviewerID interface{}) *MockPullRequestRepository_ReassignReviewer_Call {
-	return &MockPullRequestRepository_ReassignReviewer_Call{Call: _e.mock.On("ReassignReviewer", ctx, prID, oldReviewerID, newReviewerID)}
+//   - sourceTeam string
+func (_e *MockPullRequestRepository_Expecter) ReassignReviewer(ctx interface{}, prID interface{}, oldReviewerID interface{}, newReviewerID interface{}, sourceTeam interface{}) *MockPullRequestRepository_ReassignReviewer_Call {
+	return &MockPullRequestRepository_ReassignReviewer_Call{Call: _e.mock.On("ReassignReviewer", ctx, prID, oldReviewerID, newReviewerID, sourceTeam)}
 }
 
-func (_c *MockPullRequestRepository_ReassignReviewer_Call) Run(run func(ctx context.Context, prID string, oldReviewerID string, newReviewerID string)) *MockPullRequestRepository_ReassignReviewer_Call {
+func (_c *MockPullRequestRepository_ReassignReviewer_Call) Run(run func(ctx context.Context, prID string, oldReviewerID string, newReviewerID string, sourceTeam string)) *MockPullRequestRepository_ReassignReviewer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -369,11 +450,16 @@ func (_c *MockPullRequestRepository_ReassignReviewer_Call) Run(run func(ctx cont
 		if args[3] != nil {
 			arg3 = args[3].(string)
 		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4,
 		)
 	})
 	return _c
@@ -384,7 +470,7 @@ func (_c *MockPullRequestRepository_ReassignReviewer_Call) Return(pullRequest do
 	return _c
 }
 
-func (_c *MockPullRequestRepository_ReassignReviewer_Call) RunAndReturn(run func(ctx context.Context, prID string, oldReviewerID string, newReviewerID string) (domain.PullRequest, error)) *MockPullRequestRepository_ReassignReviewer_Call {
+func (_c *MockPullRequestRepository_ReassignReviewer_Call) RunAndReturn(run func(ctx context.Context, prID string, oldReviewerID string, newReviewerID string, sourceTeam string) (domain.PullRequest, error)) *MockPullRequestRepository_ReassignReviewer_Call {
 	_c.Call.Return(run)
 	return _c
 }
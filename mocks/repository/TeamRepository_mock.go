@@ -104,6 +104,356 @@ func (_c *MockTeamRepository_GetTeamByName_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
+// GetTeamPolicy provides a mock function for the type MockTeamRepository
+func (_mock *MockTeamRepository) GetTeamPolicy(ctx context.Context, teamName string) (domain.TeamPolicy, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamPolicy")
+	}
+
+	var r0 domain.TeamPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.TeamPolicy, error)); ok {
+		return returnFunc(ctx, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.TeamPolicy); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		r0 = ret.Get(0).(domain.TeamPolicy)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTeamRepository_GetTeamPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamPolicy'
+type MockTeamRepository_GetTeamPolicy_Call struct {
+	*mock.Call
+}
+
+// GetTeamPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockTeamRepository_Expecter) GetTeamPolicy(ctx interface{}, teamName interface{}) *MockTeamRepository_GetTeamPolicy_Call {
+	return &MockTeamRepository_GetTeamPolicy_Call{Call: _e.mock.On("GetTeamPolicy", ctx, teamName)}
+}
+
+func (_c *MockTeamRepository_GetTeamPolicy_Call) Run(run func(ctx context.Context, teamName string)) *MockTeamRepository_GetTeamPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamRepository_GetTeamPolicy_Call) Return(teamPolicy domain.TeamPolicy, err error) *MockTeamRepository_GetTeamPolicy_Call {
+	_c.Call.Return(teamPolicy, err)
+	return _c
+}
+
+func (_c *MockTeamRepository_GetTeamPolicy_Call) RunAndReturn(run func(ctx context.Context, teamName string) (domain.TeamPolicy, error)) *MockTeamRepository_GetTeamPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFallbackTeams provides a mock function for the type MockTeamRepository
+func (_mock *MockTeamRepository) ListFallbackTeams(ctx context.Context, teamName string) ([]string, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFallbackTeams")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(ctx, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTeamRepository_ListFallbackTeams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFallbackTeams'
+type MockTeamRepository_ListFallbackTeams_Call struct {
+	*mock.Call
+}
+
+// ListFallbackTeams is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockTeamRepository_Expecter) ListFallbackTeams(ctx interface{}, teamName interface{}) *MockTeamRepository_ListFallbackTeams_Call {
+	return &MockTeamRepository_ListFallbackTeams_Call{Call: _e.mock.On("ListFallbackTeams", ctx, teamName)}
+}
+
+func (_c *MockTeamRepository_ListFallbackTeams_Call) Run(run func(ctx context.Context, teamName string)) *MockTeamRepository_ListFallbackTeams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamRepository_ListFallbackTeams_Call) Return(ss []string, err error) *MockTeamRepository_ListFallbackTeams_Call {
+	_c.Call.Return(ss, err)
+	return _c
+}
+
+func (_c *MockTeamRepository_ListFallbackTeams_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]string, error)) *MockTeamRepository_ListFallbackTeams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDefaultReviewCapacity provides a mock function for the type MockTeamRepository
+func (_mock *MockTeamRepository) SetDefaultReviewCapacity(ctx context.Context, teamName string, capacity *int) (domain.Team, error) {
+	ret := _mock.Called(ctx, teamName, capacity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDefaultReviewCapacity")
+	}
+
+	var r0 domain.Team
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *int) (domain.Team, error)); ok {
+		return returnFunc(ctx, teamName, capacity)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *int) domain.Team); ok {
+		r0 = returnFunc(ctx, teamName, capacity)
+	} else {
+		r0 = ret.Get(0).(domain.Team)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *int) error); ok {
+		r1 = returnFunc(ctx, teamName, capacity)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTeamRepository_SetDefaultReviewCapacity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDefaultReviewCapacity'
+type MockTeamRepository_SetDefaultReviewCapacity_Call struct {
+	*mock.Call
+}
+
+// SetDefaultReviewCapacity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - capacity *int
+func (_e *MockTeamRepository_Expecter) SetDefaultReviewCapacity(ctx interface{}, teamName interface{}, capacity interface{}) *MockTeamRepository_SetDefaultReviewCapacity_Call {
+	return &MockTeamRepository_SetDefaultReviewCapacity_Call{Call: _e.mock.On("SetDefaultReviewCapacity", ctx, teamName, capacity)}
+}
+
+func (_c *MockTeamRepository_SetDefaultReviewCapacity_Call) Run(run func(ctx context.Context, teamName string, capacity *int)) *MockTeamRepository_SetDefaultReviewCapacity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *int
+		if args[2] != nil {
+			arg2 = args[2].(*int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamRepository_SetDefaultReviewCapacity_Call) Return(team domain.Team, err error) *MockTeamRepository_SetDefaultReviewCapacity_Call {
+	_c.Call.Return(team, err)
+	return _c
+}
+
+func (_c *MockTeamRepository_SetDefaultReviewCapacity_Call) RunAndReturn(run func(ctx context.Context, teamName string, capacity *int) (domain.Team, error)) *MockTeamRepository_SetDefaultReviewCapacity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetFallbackTeams provides a mock function for the type MockTeamRepository
+func (_mock *MockTeamRepository) SetFallbackTeams(ctx context.Context, teamName string, fallbacks []string) (domain.Team, error) {
+	ret := _mock.Called(ctx, teamName, fallbacks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFallbackTeams")
+	}
+
+	var r0 domain.Team
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) (domain.Team, error)); ok {
+		return returnFunc(ctx, teamName, fallbacks)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) domain.Team); ok {
+		r0 = returnFunc(ctx, teamName, fallbacks)
+	} else {
+		r0 = ret.Get(0).(domain.Team)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, teamName, fallbacks)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTeamRepository_SetFallbackTeams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFallbackTeams'
+type MockTeamRepository_SetFallbackTeams_Call struct {
+	*mock.Call
+}
+
+// SetFallbackTeams is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - fallbacks []string
+func (_e *MockTeamRepository_Expecter) SetFallbackTeams(ctx interface{}, teamName interface{}, fallbacks interface{}) *MockTeamRepository_SetFallbackTeams_Call {
+	return &MockTeamRepository_SetFallbackTeams_Call{Call: _e.mock.On("SetFallbackTeams", ctx, teamName, fallbacks)}
+}
+
+func (_c *MockTeamRepository_SetFallbackTeams_Call) Run(run func(ctx context.Context, teamName string, fallbacks []string)) *MockTeamRepository_SetFallbackTeams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamRepository_SetFallbackTeams_Call) Return(team domain.Team, err error) *MockTeamRepository_SetFallbackTeams_Call {
+	_c.Call.Return(team, err)
+	return _c
+}
+
+func (_c *MockTeamRepository_SetFallbackTeams_Call) RunAndReturn(run func(ctx context.Context, teamName string, fallbacks []string) (domain.Team, error)) *MockTeamRepository_SetFallbackTeams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTeamPolicy provides a mock function for the type MockTeamRepository
+func (_mock *MockTeamRepository) SetTeamPolicy(ctx context.Context, teamName string, policy domain.TeamPolicy) (domain.Team, error) {
+	ret := _mock.Called(ctx, teamName, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTeamPolicy")
+	}
+
+	var r0 domain.Team
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TeamPolicy) (domain.Team, error)); ok {
+		return returnFunc(ctx, teamName, policy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TeamPolicy) domain.Team); ok {
+		r0 = returnFunc(ctx, teamName, policy)
+	} else {
+		r0 = ret.Get(0).(domain.Team)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, domain.TeamPolicy) error); ok {
+		r1 = returnFunc(ctx, teamName, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTeamRepository_SetTeamPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTeamPolicy'
+type MockTeamRepository_SetTeamPolicy_Call struct {
+	*mock.Call
+}
+
+// SetTeamPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - policy domain.TeamPolicy
+func (_e *MockTeamRepository_Expecter) SetTeamPolicy(ctx interface{}, teamName interface{}, policy interface{}) *MockTeamRepository_SetTeamPolicy_Call {
+	return &MockTeamRepository_SetTeamPolicy_Call{Call: _e.mock.On("SetTeamPolicy", ctx, teamName, policy)}
+}
+
+func (_c *MockTeamRepository_SetTeamPolicy_Call) Run(run func(ctx context.Context, teamName string, policy domain.TeamPolicy)) *MockTeamRepository_SetTeamPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 domain.TeamPolicy
+		if args[2] != nil {
+			arg2 = args[2].(domain.TeamPolicy)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamRepository_SetTeamPolicy_Call) Return(team domain.Team, err error) *MockTeamRepository_SetTeamPolicy_Call {
+	_c.Call.Return(team, err)
+	return _c
+}
+
+func (_c *MockTeamRepository_SetTeamPolicy_Call) RunAndReturn(run func(ctx context.Context, teamName string, policy domain.TeamPolicy) (domain.Team, error)) *MockTeamRepository_SetTeamPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpsertTeam provides a mock function for the type MockTeamRepository
 func (_mock *MockTeamRepository) UpsertTeam(ctx context.Context, team domain.Team) (domain.Team, error) {
 	ret := _mock.Called(ctx, team)
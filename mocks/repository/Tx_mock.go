@@ -39,6 +39,72 @@ func (_m *MockTx) EXPECT() *MockTx_Expecter {
 	return &MockTx_Expecter{mock: &_m.Mock}
 }
 
+// AddUnavailability provides a mock function for the type MockTx
+func (_mock *MockTx) AddUnavailability(ctx context.Context, period domain.Unavailability) (domain.Unavailability, error) {
+	ret := _mock.Called(ctx, period)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddUnavailability")
+	}
+
+	var r0 domain.Unavailability
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Unavailability) (domain.Unavailability, error)); ok {
+		return returnFunc(ctx, period)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Unavailability) domain.Unavailability); ok {
+		r0 = returnFunc(ctx, period)
+	} else {
+		r0 = ret.Get(0).(domain.Unavailability)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Unavailability) error); ok {
+		r1 = returnFunc(ctx, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTx_AddUnavailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddUnavailability'
+type MockTx_AddUnavailability_Call struct {
+	*mock.Call
+}
+
+// AddUnavailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - period domain.Unavailability
+func (_e *MockTx_Expecter) AddUnavailability(ctx interface{}, period interface{}) *MockTx_AddUnavailability_Call {
+	return &MockTx_AddUnavailability_Call{Call: _e.mock.On("AddUnavailability", ctx, period)}
+}
+
+func (_c *MockTx_AddUnavailability_Call) Run(run func(ctx context.Context, period domain.Unavailability)) *MockTx_AddUnavailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Unavailability
+		if args[1] != nil {
+			arg1 = args[1].(domain.Unavailability)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTx_AddUnavailability_Call) Return(unavailability domain.Unavailability, err error) *MockTx_AddUnavailability_Call {
+	_c.Call.Return(unavailability, err)
+	return _c
+}
+
+func (_c *MockTx_AddUnavailability_Call) RunAndReturn(run func(ctx context.Context, period domain.Unavailability) (domain.Unavailability, error)) *MockTx_AddUnavailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Commit provides a mock function for the type MockTx
 func (_mock *MockTx) Commit(ctx context.Context) error {
 	ret := _mock.Called(ctx)
@@ -90,6 +156,86 @@ func (_c *MockTx_Commit_Call) RunAndReturn(run func(ctx context.Context) error)
 	return _c
 }
 
+// CountRecentPairings provides a mock function for the type MockTx
+func (_mock *MockTx) CountRecentPairings(ctx context.Context, authorID string, reviewerIDs []string, since time.Time) (map[string]int, error) {
+	ret := _mock.Called(ctx, authorID, reviewerIDs, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountRecentPairings")
+	}
+
+	var r0 map[string]int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, time.Time) (map[string]int, error)); ok {
+		return returnFunc(ctx, authorID, reviewerIDs, since)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string, time.Time) map[string]int); ok {
+		r0 = returnFunc(ctx, authorID, reviewerIDs, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string, time.Time) error); ok {
+		r1 = returnFunc(ctx, authorID, reviewerIDs, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTx_CountRecentPairings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountRecentPairings'
+type MockTx_CountRecentPairings_Call struct {
+	*mock.Call
+}
+
+// CountRecentPairings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authorID string
+//   - reviewerIDs []string
+//   - since time.Time
+func (_e *MockTx_Expecter) CountRecentPairings(ctx interface{}, authorID interface{}, reviewerIDs interface{}, since interface{}) *MockTx_CountRecentPairings_Call {
+	return &MockTx_CountRecentPairings_Call{Call: _e.mock.On("CountRecentPairings", ctx, authorID, reviewerIDs, since)}
+}
+
+func (_c *MockTx_CountRecentPairings_Call) Run(run func(ctx context.Context, authorID string, reviewerIDs []string, since time.Time)) *MockTx_CountRecentPairings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTx_CountRecentPairings_Call) Return(m map[string]int, err error) *MockTx_CountRecentPairings_Call {
+	_c.Call.Return(m, err)
+	return _c
+}
+
+func (_c *MockTx_CountRecentPairings_Call) RunAndReturn(run func(ctx context.Context, authorID string, reviewerIDs []string, since time.Time) (map[string]int, error)) *MockTx_CountRecentPairings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreatePullRequest provides a mock function for the type MockTx
 func (_mock *MockTx) CreatePullRequest(ctx context.Context, pr domain.PullRequest) (domain.PullRequest, error) {
 	ret := _mock.Called(ctx, pr)
@@ -156,6 +302,69 @@ func (_c *MockTx_CreatePullRequest_Call) RunAndReturn(run func(ctx context.Conte
 	return _c
 }
 
+// DeleteUnavailability provides a mock function for the type MockTx
+func (_mock *MockTx) DeleteUnavailability(ctx context.Context, userID string, periodID int64) error {
+	ret := _mock.Called(ctx, userID, periodID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUnavailability")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = returnFunc(ctx, userID, periodID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTx_DeleteUnavailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUnavailability'
+type MockTx_DeleteUnavailability_Call struct {
+	*mock.Call
+}
+
+// DeleteUnavailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - periodID int64
+func (_e *MockTx_Expecter) DeleteUnavailability(ctx interface{}, userID interface{}, periodID interface{}) *MockTx_DeleteUnavailability_Call {
+	return &MockTx_DeleteUnavailability_Call{Call: _e.mock.On("DeleteUnavailability", ctx, userID, periodID)}
+}
+
+func (_c *MockTx_DeleteUnavailability_Call) Run(run func(ctx context.Context, userID string, periodID int64)) *MockTx_DeleteUnavailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTx_DeleteUnavailability_Call) Return(err error) *MockTx_DeleteUnavailability_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTx_DeleteUnavailability_Call) RunAndReturn(run func(ctx context.Context, userID string, periodID int64) error) *MockTx_DeleteUnavailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetPullRequestByID provides a mock function for the type MockTx
 func (_mock *MockTx) GetPullRequestByID(ctx context.Context, prID string) (domain.PullRequest, error) {
 	ret := _mock.Called(ctx, prID)
@@ -187,80 +396,1085 @@ type MockTx_GetPullRequestByID_Call struct {
 	*mock.Call
 }
 
-// GetPullRequestByID is a helper method to define mock.On call
+// GetPullRequestByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *MockTx_Expecter) GetPullRequestByID(ctx interface{}, prID interface{}) *MockTx_GetPullRequestByID_Call {
+	return &MockTx_GetPullRequestByID_Call{Call: _e.mock.On("GetPullRequestByID", ctx, prID)}
+}
+
+func (_c *MockTx_GetPullRequestByID_Call) Run(run func(ctx context.Context, prID string)) *MockTx_GetPullRequestByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTx_GetPullRequestByID_Call) Return(pullRequest domain.PullRequest, err error) *MockTx_GetPullRequestByID_Call {
+	_c.Call.Return(pullRequest, err)
+	return _c
+}
+
+func (_c *MockTx_GetPullRequestByID_Call) RunAndReturn(run func(ctx context.Context, prID string) (domain.PullRequest, error)) *MockTx_GetPullRequestByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPullRequestForUpdate provides a mock function for the type MockTx
+func (_mock *MockTx) GetPullRequestForUpdate(ctx context.Context, prID string) (domain.PullRequest, error) {
+	ret := _mock.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPullRequestForUpdate")
+	}
+
+	var r0 domain.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.PullRequest, error)); ok {
+		return returnFunc(ctx, prID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.PullRequest); ok {
+		r0 = returnFunc(ctx, prID)
+	} else {
+		r0 = ret.Get(0).(domain.PullRequest)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTx_GetPullRequestForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPullRequestForUpdate'
+type MockTx_GetPullRequestForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetPullRequestForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *MockTx_Expecter) GetPullRequestForUpdate(ctx interface{}, prID interface{}) *MockTx_GetPullRequestForUpdate_Call {
+	return &MockTx_GetPullRequestForUpdate_Call{Call: _e.mock.On("GetPullRequestForUpdate", ctx, prID)}
+}
+
+func (_c *MockTx_GetPullRequestForUpdate_Call) Run(run func(ctx context.Context, prID string)) *MockTx_GetPullRequestForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTx_GetPullRequestForUpdate_Call) Return(pullRequest domain.PullRequest, err error) *MockTx_GetPullRequestForUpdate_Call {
+	_c.Call.Return(pullRequest, err)
+	return _c
+}
+
+func (_c *MockTx_GetPullRequestForUpdate_Call) RunAndReturn(run func(ctx context.Context, prID string) (domain.PullRequest, error)) *MockTx_GetPullRequestForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReviewLoad provides a mock function for the type MockTx
+func (_mock *MockTx) GetReviewLoad(ctx context.Context, userIDs []string) (map[string]domain.ReviewLoad, error) {
+	ret := _mock.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviewLoad")
+	}
+
+	var r0 map[string]domain.ReviewLoad
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (map[string]domain.ReviewLoad, error)); ok {
+		return returnFunc(ctx, userIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) map[string]domain.ReviewLoad); ok {
+		r0 = returnFunc(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]domain.ReviewLoad)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTx_GetReviewLoad_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReviewLoad'
+type MockTx_GetReviewLoad_Call struct {
+	*mock.Call
+}
+
+// GetReviewLoad is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []string
+func (_e *MockTx_Expecter) GetReviewLoad(ctx interface{}, userIDs interface{}) *MockTx_GetReviewLoad_Call {
+	return &MockTx_GetReviewLoad_Call{Call: _e.mock.On("GetReviewLoad", ctx, userIDs)}
+}
+
+func (_c *MockTx_GetReviewLoad_Call) Run(run func(ctx context.Context, userIDs []string)) *MockTx_GetReviewLoad_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTx_GetReviewLoad_Call) Return(m map[string]domain.ReviewLoad, err error) *MockTx_GetReviewLoad_Call {
+	_c.Call.Return(m, err)
+	return _c
+}
+
+func (_c *MockTx_GetReviewLoad_Call) RunAndReturn(run func(ctx context.Context, userIDs []string) (map[string]domain.ReviewLoad, error)) *MockTx_GetReviewLoad_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamByName provides a mock function for the type MockTx
+func (_mock *MockTx) GetTeamByName(ctx context.Context, teamName string) (domain.Team, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamByName")
+	}
+
+	var r0 domain.Team
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.Team, error)); ok {
+		return returnFunc(ctx, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.Team); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		r0 = ret.Get(0).(domain.Team)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTx_GetTeamByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamByName'
+type MockTx_GetTeamByName_Call struct {
+	*mock.Call
+}
+
+// GetTeamByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockTx_Expecter) GetTeamByName(ctx interface{}, teamName interface{}) *MockTx_GetTeamByName_Call {
+	return &MockTx_GetTeamByName_Call{Call: _e.mock.On("GetTeamByName", ctx, teamName)}
+}
+
+func (_c *MockTx_GetTeamByName_Call) Run(run func(ctx context.Context, teamName string)) *MockTx_GetTeamByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTx_GetTeamByName_Call) Return(team domain.Team, err error) *MockTx_GetTeamByName_Call {
+	_c.Call.Return(team, err)
+	return _c
+}
+
+func (_c *MockTx_GetTeamByName_Call) RunAndReturn(run func(ctx context.Context, teamName string) (domain.Team, error)) *MockTx_GetTeamByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeamPolicy provides a mock function for the type MockTx
+func (_mock *MockTx) GetTeamPolicy(ctx context.Context, teamName string) (domain.TeamPolicy, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamPolicy")
+	}
+
+	var r0 domain.TeamPolicy
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.TeamPolicy, error)); ok {
+		return returnFunc(ctx, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.TeamPolicy); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		r0 = ret.Get(0).(domain.TeamPolicy)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTx_GetTeamPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamPolicy'
+type MockTx_GetTeamPolicy_Call struct {
+	*mock.Call
+}
+
+// GetTeamPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockTx_Expecter) GetTeamPolicy(ctx interface{}, teamName interface{}) *MockTx_GetTeamPolicy_Call {
+	return &MockTx_GetTeamPolicy_Call{Call: _e.mock.On("GetTeamPolicy", ctx, teamName)}
+}
+
+func (_c *MockTx_GetTeamPolicy_Call) Run(run func(ctx context.Context, teamName string)) *MockTx_GetTeamPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTx_GetTeamPolicy_Call) Return(teamPolicy domain.TeamPolicy, err error) *MockTx_GetTeamPolicy_Call {
+	_c.Call.Return(teamPolicy, err)
+	return _c
+}
+
+func (_c *MockTx_GetTeamPolicy_Call) RunAndReturn(run func(ctx context.Context, teamName string) (domain.TeamPolicy, error)) *MockTx_GetTeamPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByID provides a mock function for the type MockTx
+func (_mock *MockTx) GetUserByID(ctx context.Context, userID string) (domain.User, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.User, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTx_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type MockTx_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockTx_Expecter) GetUserByID(ctx interface{}, userID interface{}) *MockTx_GetUserByID_Call {
+	return &MockTx_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, userID)}
+}
+
+func (_c *MockTx_GetUserByID_Call) Run(run func(ctx context.Context, userID string)) *MockTx_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTx_GetUserByID_Call) Return(user domain.User, err error) *MockTx_GetUserByID_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockTx_GetUserByID_Call) RunAndReturn(run func(ctx context.Context, userID string) (domain.User, error)) *MockTx_GetUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListActiveByTeam provides a mock function for the type MockTx
+func (_mock *MockTx) ListActiveByTeam(ctx context.Context, teamName string, at time.Time) ([]domain.User, error) {
+	ret := _mock.Called(ctx, teamName, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActiveByTeam")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]domain.User, error)); ok {
+		return returnFunc(ctx, teamName, at)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) []domain.User); ok {
+		r0 = returnFunc(ctx, teamName, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, teamName, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTx_ListActiveByTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListActiveByTeam'
+type MockTx_ListActiveByTeam_Call struct {
+	*mock.Call
+}
+
+// ListActiveByTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - at time.Time
+func (_e *MockTx_Expecter) ListActiveByTeam(ctx interface{}, teamName interface{}, at interface{}) *MockTx_ListActiveByTeam_Call {
+	return &MockTx_ListActiveByTeam_Call{Call: _e.mock.On("ListActiveByTeam", ctx, teamName, at)}
+}
+
+func (_c *MockTx_ListActiveByTeam_Call) Run(run func(ctx context.Context, teamName string, at time.Time)) *MockTx_ListActiveByTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTx_ListActiveByTeam_Call) Return(users []domain.User, err error) *MockTx_ListActiveByTeam_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockTx_ListActiveByTeam_Call) RunAndReturn(run func(ctx context.Context, teamName string, at time.Time) ([]domain.User, error)) *MockTx_ListActiveByTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByReviewer provides a mock function for the type MockTx
+func (_mock *MockTx) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error) {
+	ret := _mock.Called(ctx, reviewerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByReviewer")
+	}
+
+	var r0 []domain.PullRequestShort
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.PullRequestShort, error)); ok {
+		return returnFunc(ctx, reviewerID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.PullRequestShort); ok {
+		r0 = returnFunc(ctx, reviewerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PullRequestShort)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, reviewerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTx_ListByReviewer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByReviewer'
+type MockTx_ListByReviewer_Call struct {
+	*mock.Call
+}
+
+// ListByReviewer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reviewerID string
+func (_e *MockTx_Expecter) ListByReviewer(ctx interface{}, reviewerID interface{}) *MockTx_ListByReviewer_Call {
+	return &MockTx_ListByReviewer_Call{Call: _e.mock.On("ListByReviewer", ctx, reviewerID)}
+}
+
+func (_c *MockTx_ListByReviewer_Call) Run(run func(ctx context.Context, reviewerID string)) *MockTx_ListByReviewer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTx_ListByReviewer_Call) Return(pullRequestShorts []domain.PullRequestShort, err error) *MockTx_ListByReviewer_Call {
+	_c.Call.Return(pullRequestShorts, err)
+	return _c
+}
+
+func (_c *MockTx_ListByReviewer_Call) RunAndReturn(run func(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error)) *MockTx_ListByReviewer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCodeOwnerRules provides a mock function for the type MockTx
+func (_mock *MockTx) ListCodeOwnerRules(ctx context.Context) ([]domain.CodeOwnerRule, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCodeOwnerRules")
+	}
+
+	var r0 []domain.CodeOwnerRule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]domain.CodeOwnerRule, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []domain.CodeOwnerRule); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CodeOwnerRule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTx_ListCodeOwnerRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCodeOwnerRules'
+type MockTx_ListCodeOwnerRules_Call struct {
+	*mock.Call
+}
+
+// ListCodeOwnerRules is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTx_Expecter) ListCodeOwnerRules(ctx interface{}) *MockTx_ListCodeOwnerRules_Call {
+	return &MockTx_ListCodeOwnerRules_Call{Call: _e.mock.On("ListCodeOwnerRules", ctx)}
+}
+
+func (_c *MockTx_ListCodeOwnerRules_Call) Run(run func(ctx context.Context)) *MockTx_ListCodeOwnerRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTx_ListCodeOwnerRules_Call) Return(codeOwnerRules []domain.CodeOwnerRule, err error) *MockTx_ListCodeOwnerRules_Call {
+	_c.Call.Return(codeOwnerRules, err)
+	return _c
+}
+
+func (_c *MockTx_ListCodeOwnerRules_Call) RunAndReturn(run func(ctx context.Context) ([]domain.CodeOwnerRule, error)) *MockTx_ListCodeOwnerRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListFallbackTeams provides a mock function for the type MockTx
+func (_mock *MockTx) ListFallbackTeams(ctx context.Context, teamName string) ([]string, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFallbackTeams")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(ctx, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTx_ListFallbackTeams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListFallbackTeams'
+type MockTx_ListFallbackTeams_Call struct {
+	*mock.Call
+}
+
+// ListFallbackTeams is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockTx_Expecter) ListFallbackTeams(ctx interface{}, teamName interface{}) *MockTx_ListFallbackTeams_Call {
+	return &MockTx_ListFallbackTeams_Call{Call: _e.mock.On("ListFallbackTeams", ctx, teamName)}
+}
+
+func (_c *MockTx_ListFallbackTeams_Call) Run(run func(ctx context.Context, teamName string)) *MockTx_ListFallbackTeams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTx_ListFallbackTeams_Call) Return(ss []string, err error) *MockTx_ListFallbackTeams_Call {
+	_c.Call.Return(ss, err)
+	return _c
+}
+
+func (_c *MockTx_ListFallbackTeams_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]string, error)) *MockTx_ListFallbackTeams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUnavailability provides a mock function for the type MockTx
+func (_mock *MockTx) ListUnavailability(ctx context.Context, userID string) ([]domain.Unavailability, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnavailability")
+	}
+
+	var r0 []domain.Unavailability
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.Unavailability, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.Unavailability); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Unavailability)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTx_ListUnavailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnavailability'
+type MockTx_ListUnavailability_Call struct {
+	*mock.Call
+}
+
+// ListUnavailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockTx_Expecter) ListUnavailability(ctx interface{}, userID interface{}) *MockTx_ListUnavailability_Call {
+	return &MockTx_ListUnavailability_Call{Call: _e.mock.On("ListUnavailability", ctx, userID)}
+}
+
+func (_c *MockTx_ListUnavailability_Call) Run(run func(ctx context.Context, userID string)) *MockTx_ListUnavailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTx_ListUnavailability_Call) Return(unavailabilitys []domain.Unavailability, err error) *MockTx_ListUnavailability_Call {
+	_c.Call.Return(unavailabilitys, err)
+	return _c
+}
+
+func (_c *MockTx_ListUnavailability_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]domain.Unavailability, error)) *MockTx_ListUnavailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserTags provides a mock function for the type MockTx
+func (_mock *MockTx) ListUserTags(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	ret := _mock.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserTags")
+	}
+
+	var r0 map[string][]string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (map[string][]string, error)); ok {
+		return returnFunc(ctx, userIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) map[string][]string); ok {
+		r0 = returnFunc(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTx_ListUserTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserTags'
+type MockTx_ListUserTags_Call struct {
+	*mock.Call
+}
+
+// ListUserTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []string
+func (_e *MockTx_Expecter) ListUserTags(ctx interface{}, userIDs interface{}) *MockTx_ListUserTags_Call {
+	return &MockTx_ListUserTags_Call{Call: _e.mock.On("ListUserTags", ctx, userIDs)}
+}
+
+func (_c *MockTx_ListUserTags_Call) Run(run func(ctx context.Context, userIDs []string)) *MockTx_ListUserTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTx_ListUserTags_Call) Return(m map[string][]string, err error) *MockTx_ListUserTags_Call {
+	_c.Call.Return(m, err)
+	return _c
+}
+
+func (_c *MockTx_ListUserTags_Call) RunAndReturn(run func(ctx context.Context, userIDs []string) (map[string][]string, error)) *MockTx_ListUserTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergePullRequest provides a mock function for the type MockTx
+func (_mock *MockTx) MergePullRequest(ctx context.Context, prID string, mergedAt time.Time) (domain.PullRequest, error) {
+	ret := _mock.Called(ctx, prID, mergedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergePullRequest")
+	}
+
+	var r0 domain.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) (domain.PullRequest, error)); ok {
+		return returnFunc(ctx, prID, mergedAt)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) domain.PullRequest); ok {
+		r0 = returnFunc(ctx, prID, mergedAt)
+	} else {
+		r0 = ret.Get(0).(domain.PullRequest)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, prID, mergedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTx_MergePullRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergePullRequest'
+type MockTx_MergePullRequest_Call struct {
+	*mock.Call
+}
+
+// MergePullRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+//   - mergedAt time.Time
+func (_e *MockTx_Expecter) MergePullRequest(ctx interface{}, prID interface{}, mergedAt interface{}) *MockTx_MergePullRequest_Call {
+	return &MockTx_MergePullRequest_Call{Call: _e.mock.On("MergePullRequest", ctx, prID, mergedAt)}
+}
+
+func (_c *MockTx_MergePullRequest_Call) Run(run func(ctx context.Context, prID string, mergedAt time.Time)) *MockTx_MergePullRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTx_MergePullRequest_Call) Return(pullRequest domain.PullRequest, err error) *MockTx_MergePullRequest_Call {
+	_c.Call.Return(pullRequest, err)
+	return _c
+}
+
+func (_c *MockTx_MergePullRequest_Call) RunAndReturn(run func(ctx context.Context, prID string, mergedAt time.Time) (domain.PullRequest, error)) *MockTx_MergePullRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReassignReviewer provides a mock function for the type MockTx
+func (_mock *MockTx) ReassignReviewer(ctx context.Context, prID string, oldReviewerID string, newReviewerID string, sourceTeam string) (domain.PullRequest, error) {
+	ret := _mock.Called(ctx, prID, oldReviewerID, newReviewerID, sourceTeam)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReassignReviewer")
+	}
+
+	var r0 domain.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) (domain.PullRequest, error)); ok {
+		return returnFunc(ctx, prID, oldReviewerID, newReviewerID, sourceTeam)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) domain.PullRequest); ok {
+		r0 = returnFunc(ctx, prID, oldReviewerID, newReviewerID, sourceTeam)
+	} else {
+		r0 = ret.Get(0).(domain.PullRequest)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = returnFunc(ctx, prID, oldReviewerID, newReviewerID, sourceTeam)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTx_ReassignReviewer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReassignReviewer'
+type MockTx_ReassignReviewer_Call struct {
+	*mock.Call
+}
+
+// ReassignReviewer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+//   - oldReviewerID string
+//   - newReviewerID string
+//   - sourceTeam string
+func (_e *MockTx_Expecter) ReassignReviewer(ctx interface{}, prID interface{}, oldReviewerID interface{}, newReviewerID interface{}, sourceTeam interface{}) *MockTx_ReassignReviewer_Call {
+	return &MockTx_ReassignReviewer_Call{Call: _e.mock.On("ReassignReviewer", ctx, prID, oldReviewerID, newReviewerID, sourceTeam)}
+}
+
+func (_c *MockTx_ReassignReviewer_Call) Run(run func(ctx context.Context, prID string, oldReviewerID string, newReviewerID string, sourceTeam string)) *MockTx_ReassignReviewer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTx_ReassignReviewer_Call) Return(pullRequest domain.PullRequest, err error) *MockTx_ReassignReviewer_Call {
+	_c.Call.Return(pullRequest, err)
+	return _c
+}
+
+func (_c *MockTx_ReassignReviewer_Call) RunAndReturn(run func(ctx context.Context, prID string, oldReviewerID string, newReviewerID string, sourceTeam string) (domain.PullRequest, error)) *MockTx_ReassignReviewer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceCodeOwnerRules provides a mock function for the type MockTx
+func (_mock *MockTx) ReplaceCodeOwnerRules(ctx context.Context, rules []domain.CodeOwnerRule) error {
+	ret := _mock.Called(ctx, rules)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceCodeOwnerRules")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.CodeOwnerRule) error); ok {
+		r0 = returnFunc(ctx, rules)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTx_ReplaceCodeOwnerRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceCodeOwnerRules'
+type MockTx_ReplaceCodeOwnerRules_Call struct {
+	*mock.Call
+}
+
+// ReplaceCodeOwnerRules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - rules []domain.CodeOwnerRule
+func (_e *MockTx_Expecter) ReplaceCodeOwnerRules(ctx interface{}, rules interface{}) *MockTx_ReplaceCodeOwnerRules_Call {
+	return &MockTx_ReplaceCodeOwnerRules_Call{Call: _e.mock.On("ReplaceCodeOwnerRules", ctx, rules)}
+}
+
+func (_c *MockTx_ReplaceCodeOwnerRules_Call) Run(run func(ctx context.Context, rules []domain.CodeOwnerRule)) *MockTx_ReplaceCodeOwnerRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []domain.CodeOwnerRule
+		if args[1] != nil {
+			arg1 = args[1].([]domain.CodeOwnerRule)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTx_ReplaceCodeOwnerRules_Call) Return(err error) *MockTx_ReplaceCodeOwnerRules_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTx_ReplaceCodeOwnerRules_Call) RunAndReturn(run func(ctx context.Context, rules []domain.CodeOwnerRule) error) *MockTx_ReplaceCodeOwnerRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rollback provides a mock function for the type MockTx
+func (_mock *MockTx) Rollback(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rollback")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTx_Rollback_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rollback'
+type MockTx_Rollback_Call struct {
+	*mock.Call
+}
+
+// Rollback is a helper method to define mock.On call
 //   - ctx context.Context
-//   - prID string
-func (_e *MockTx_Expecter) GetPullRequestByID(ctx interface{}, prID interface{}) *MockTx_GetPullRequestByID_Call {
-	return &MockTx_GetPullRequestByID_Call{Call: _e.mock.On("GetPullRequestByID", ctx, prID)}
+func (_e *MockTx_Expecter) Rollback(ctx interface{}) *MockTx_Rollback_Call {
+	return &MockTx_Rollback_Call{Call: _e.mock.On("Rollback", ctx)}
 }
 
-func (_c *MockTx_GetPullRequestByID_Call) Run(run func(ctx context.Context, prID string)) *MockTx_GetPullRequestByID_Call {
+func (_c *MockTx_Rollback_Call) Run(run func(ctx context.Context)) *MockTx_Rollback_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTx_GetPullRequestByID_Call) Return(pullRequest domain.PullRequest, err error) *MockTx_GetPullRequestByID_Call {
-	_c.Call.Return(pullRequest, err)
+func (_c *MockTx_Rollback_Call) Return(err error) *MockTx_Rollback_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockTx_GetPullRequestByID_Call) RunAndReturn(run func(ctx context.Context, prID string) (domain.PullRequest, error)) *MockTx_GetPullRequestByID_Call {
+func (_c *MockTx_Rollback_Call) RunAndReturn(run func(ctx context.Context) error) *MockTx_Rollback_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTeamByName provides a mock function for the type MockTx
-func (_mock *MockTx) GetTeamByName(ctx context.Context, teamName string) (domain.Team, error) {
-	ret := _mock.Called(ctx, teamName)
+// SetActive provides a mock function for the type MockTx
+func (_mock *MockTx) SetActive(ctx context.Context, userID string, isActive bool) (domain.User, error) {
+	ret := _mock.Called(ctx, userID, isActive)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTeamByName")
+		panic("no return value specified for SetActive")
 	}
 
-	var r0 domain.Team
+	var r0 domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.Team, error)); ok {
-		return returnFunc(ctx, teamName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) (domain.User, error)); ok {
+		return returnFunc(ctx, userID, isActive)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.Team); ok {
-		r0 = returnFunc(ctx, teamName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) domain.User); ok {
+		r0 = returnFunc(ctx, userID, isActive)
 	} else {
-		r0 = ret.Get(0).(domain.Team)
+		r0 = ret.Get(0).(domain.User)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, teamName)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = returnFunc(ctx, userID, isActive)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTx_GetTeamByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeamByName'
-type MockTx_GetTeamByName_Call struct {
+// MockTx_SetActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetActive'
+type MockTx_SetActive_Call struct {
 	*mock.Call
 }
 
-// GetTeamByName is a helper method to define mock.On call
+// SetActive is a helper method to define mock.On call
 //   - ctx context.Context
-//   - teamName string
-func (_e *MockTx_Expecter) GetTeamByName(ctx interface{}, teamName interface{}) *MockTx_GetTeamByName_Call {
-	return &MockTx_GetTeamByName_Call{Call: _e.mock.On("GetTeamByName", ctx, teamName)}
+//   - userID string
+//   - isActive bool
+func (_e *MockTx_Expecter) SetActive(ctx interface{}, userID interface{}, isActive interface{}) *MockTx_SetActive_Call {
+	return &MockTx_SetActive_Call{Call: _e.mock.On("SetActive", ctx, userID, isActive)}
 }
 
-func (_c *MockTx_GetTeamByName_Call) Run(run func(ctx context.Context, teamName string)) *MockTx_GetTeamByName_Call {
+func (_c *MockTx_SetActive_Call) Run(run func(ctx context.Context, userID string, isActive bool)) *MockTx_SetActive_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -270,63 +1484,69 @@ func (_c *MockTx_GetTeamByName_Call) Run(run func(ctx context.Context, teamName
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTx_GetTeamByName_Call) Return(team domain.Team, err error) *MockTx_GetTeamByName_Call {
-	_c.Call.Return(team, err)
+func (_c *MockTx_SetActive_Call) Return(user domain.User, err error) *MockTx_SetActive_Call {
+	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *MockTx_GetTeamByName_Call) RunAndReturn(run func(ctx context.Context, teamName string) (domain.Team, error)) *MockTx_GetTeamByName_Call {
+func (_c *MockTx_SetActive_Call) RunAndReturn(run func(ctx context.Context, userID string, isActive bool) (domain.User, error)) *MockTx_SetActive_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUserByID provides a mock function for the type MockTx
-func (_mock *MockTx) GetUserByID(ctx context.Context, userID string) (domain.User, error) {
-	ret := _mock.Called(ctx, userID)
+// SetDefaultReviewCapacity provides a mock function for the type MockTx
+func (_mock *MockTx) SetDefaultReviewCapacity(ctx context.Context, teamName string, capacity *int) (domain.Team, error) {
+	ret := _mock.Called(ctx, teamName, capacity)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetUserByID")
+		panic("no return value specified for SetDefaultReviewCapacity")
 	}
 
-	var r0 domain.User
+	var r0 domain.Team
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (domain.User, error)); ok {
-		return returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *int) (domain.Team, error)); ok {
+		return returnFunc(ctx, teamName, capacity)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) domain.User); ok {
-		r0 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *int) domain.Team); ok {
+		r0 = returnFunc(ctx, teamName, capacity)
 	} else {
-		r0 = ret.Get(0).(domain.User)
+		r0 = ret.Get(0).(domain.Team)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *int) error); ok {
+		r1 = returnFunc(ctx, teamName, capacity)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTx_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
-type MockTx_GetUserByID_Call struct {
+// MockTx_SetDefaultReviewCapacity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDefaultReviewCapacity'
+type MockTx_SetDefaultReviewCapacity_Call struct {
 	*mock.Call
 }
 
-// GetUserByID is a helper method to define mock.On call
+// SetDefaultReviewCapacity is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-func (_e *MockTx_Expecter) GetUserByID(ctx interface{}, userID interface{}) *MockTx_GetUserByID_Call {
-	return &MockTx_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, userID)}
+//   - teamName string
+//   - capacity *int
+func (_e *MockTx_Expecter) SetDefaultReviewCapacity(ctx interface{}, teamName interface{}, capacity interface{}) *MockTx_SetDefaultReviewCapacity_Call {
+	return &MockTx_SetDefaultReviewCapacity_Call{Call: _e.mock.On("SetDefaultReviewCapacity", ctx, teamName, capacity)}
 }
 
-func (_c *MockTx_GetUserByID_Call) Run(run func(ctx context.Context, userID string)) *MockTx_GetUserByID_Call {
+func (_c *MockTx_SetDefaultReviewCapacity_Call) Run(run func(ctx context.Context, teamName string, capacity *int)) *MockTx_SetDefaultReviewCapacity_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -336,65 +1556,69 @@ func (_c *MockTx_GetUserByID_Call) Run(run func(ctx context.Context, userID stri
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 *int
+		if args[2] != nil {
+			arg2 = args[2].(*int)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTx_GetUserByID_Call) Return(user domain.User, err error) *MockTx_GetUserByID_Call {
-	_c.Call.Return(user, err)
+func (_c *MockTx_SetDefaultReviewCapacity_Call) Return(team domain.Team, err error) *MockTx_SetDefaultReviewCapacity_Call {
+	_c.Call.Return(team, err)
 	return _c
 }
 
-func (_c *MockTx_GetUserByID_Call) RunAndReturn(run func(ctx context.Context, userID string) (domain.User, error)) *MockTx_GetUserByID_Call {
+func (_c *MockTx_SetDefaultReviewCapacity_Call) RunAndReturn(run func(ctx context.Context, teamName string, capacity *int) (domain.Team, error)) *MockTx_SetDefaultReviewCapacity_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListActiveByTeam provides a mock function for the type MockTx
-func (_mock *MockTx) ListActiveByTeam(ctx context.Context, teamName string) ([]domain.User, error) {
-	ret := _mock.Called(ctx, teamName)
+// SetFallbackTeams provides a mock function for the type MockTx
+func (_mock *MockTx) SetFallbackTeams(ctx context.Context, teamName string, fallbacks []string) (domain.Team, error) {
+	ret := _mock.Called(ctx, teamName, fallbacks)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListActiveByTeam")
+		panic("no return value specified for SetFallbackTeams")
 	}
 
-	var r0 []domain.User
+	var r0 domain.Team
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.User, error)); ok {
-		return returnFunc(ctx, teamName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) (domain.Team, error)); ok {
+		return returnFunc(ctx, teamName, fallbacks)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.User); ok {
-		r0 = returnFunc(ctx, teamName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) domain.Team); ok {
+		r0 = returnFunc(ctx, teamName, fallbacks)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.User)
-		}
+		r0 = ret.Get(0).(domain.Team)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, teamName)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, teamName, fallbacks)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTx_ListActiveByTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListActiveByTeam'
-type MockTx_ListActiveByTeam_Call struct {
+// MockTx_SetFallbackTeams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFallbackTeams'
+type MockTx_SetFallbackTeams_Call struct {
 	*mock.Call
 }
 
-// ListActiveByTeam is a helper method to define mock.On call
+// SetFallbackTeams is a helper method to define mock.On call
 //   - ctx context.Context
 //   - teamName string
-func (_e *MockTx_Expecter) ListActiveByTeam(ctx interface{}, teamName interface{}) *MockTx_ListActiveByTeam_Call {
-	return &MockTx_ListActiveByTeam_Call{Call: _e.mock.On("ListActiveByTeam", ctx, teamName)}
+//   - fallbacks []string
+func (_e *MockTx_Expecter) SetFallbackTeams(ctx interface{}, teamName interface{}, fallbacks interface{}) *MockTx_SetFallbackTeams_Call {
+	return &MockTx_SetFallbackTeams_Call{Call: _e.mock.On("SetFallbackTeams", ctx, teamName, fallbacks)}
 }
 
-func (_c *MockTx_ListActiveByTeam_Call) Run(run func(ctx context.Context, teamName string)) *MockTx_ListActiveByTeam_Call {
+func (_c *MockTx_SetFallbackTeams_Call) Run(run func(ctx context.Context, teamName string, fallbacks []string)) *MockTx_SetFallbackTeams_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -404,65 +1628,69 @@ func (_c *MockTx_ListActiveByTeam_Call) Run(run func(ctx context.Context, teamNa
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTx_ListActiveByTeam_Call) Return(users []domain.User, err error) *MockTx_ListActiveByTeam_Call {
-	_c.Call.Return(users, err)
+func (_c *MockTx_SetFallbackTeams_Call) Return(team domain.Team, err error) *MockTx_SetFallbackTeams_Call {
+	_c.Call.Return(team, err)
 	return _c
 }
 
-func (_c *MockTx_ListActiveByTeam_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]domain.User, error)) *MockTx_ListActiveByTeam_Call {
+func (_c *MockTx_SetFallbackTeams_Call) RunAndReturn(run func(ctx context.Context, teamName string, fallbacks []string) (domain.Team, error)) *MockTx_SetFallbackTeams_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListByReviewer provides a mock function for the type MockTx
-func (_mock *MockTx) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error) {
-	ret := _mock.Called(ctx, reviewerID)
+// SetReviewCapacity provides a mock function for the type MockTx
+func (_mock *MockTx) SetReviewCapacity(ctx context.Context, userID string, capacity *int) (domain.User, error) {
+	ret := _mock.Called(ctx, userID, capacity)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListByReviewer")
+		panic("no return value specified for SetReviewCapacity")
 	}
 
-	var r0 []domain.PullRequestShort
+	var r0 domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.PullRequestShort, error)); ok {
-		return returnFunc(ctx, reviewerID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *int) (domain.User, error)); ok {
+		return returnFunc(ctx, userID, capacity)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.PullRequestShort); ok {
-		r0 = returnFunc(ctx, reviewerID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *int) domain.User); ok {
+		r0 = returnFunc(ctx, userID, capacity)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.PullRequestShort)
-		}
+		r0 = ret.Get(0).(domain.User)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, reviewerID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *int) error); ok {
+		r1 = returnFunc(ctx, userID, capacity)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTx_ListByReviewer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByReviewer'
-type MockTx_ListByReviewer_Call struct {
+// MockTx_SetReviewCapacity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetReviewCapacity'
+type MockTx_SetReviewCapacity_Call struct {
 	*mock.Call
 }
 
-// ListByReviewer is a helper method to define mock.On call
+// SetReviewCapacity is a helper method to define mock.On call
 //   - ctx context.Context
-//   - reviewerID string
-func (_e *MockTx_Expecter) ListByReviewer(ctx interface{}, reviewerID interface{}) *MockTx_ListByReviewer_Call {
-	return &MockTx_ListByReviewer_Call{Call: _e.mock.On("ListByReviewer", ctx, reviewerID)}
+//   - userID string
+//   - capacity *int
+func (_e *MockTx_Expecter) SetReviewCapacity(ctx interface{}, userID interface{}, capacity interface{}) *MockTx_SetReviewCapacity_Call {
+	return &MockTx_SetReviewCapacity_Call{Call: _e.mock.On("SetReviewCapacity", ctx, userID, capacity)}
 }
 
-func (_c *MockTx_ListByReviewer_Call) Run(run func(ctx context.Context, reviewerID string)) *MockTx_ListByReviewer_Call {
+func (_c *MockTx_SetReviewCapacity_Call) Run(run func(ctx context.Context, userID string, capacity *int)) *MockTx_SetReviewCapacity_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -472,64 +1700,69 @@ func (_c *MockTx_ListByReviewer_Call) Run(run func(ctx context.Context, reviewer
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 *int
+		if args[2] != nil {
+			arg2 = args[2].(*int)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTx_ListByReviewer_Call) Return(pullRequestShorts []domain.PullRequestShort, err error) *MockTx_ListByReviewer_Call {
-	_c.Call.Return(pullRequestShorts, err)
+func (_c *MockTx_SetReviewCapacity_Call) Return(user domain.User, err error) *MockTx_SetReviewCapacity_Call {
+	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *MockTx_ListByReviewer_Call) RunAndReturn(run func(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error)) *MockTx_ListByReviewer_Call {
+func (_c *MockTx_SetReviewCapacity_Call) RunAndReturn(run func(ctx context.Context, userID string, capacity *int) (domain.User, error)) *MockTx_SetReviewCapacity_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MergePullRequest provides a mock function for the type MockTx
-func (_mock *MockTx) MergePullRequest(ctx context.Context, prID string, mergedAt time.Time) (domain.PullRequest, error) {
-	ret := _mock.Called(ctx, prID, mergedAt)
+// SetSeniority provides a mock function for the type MockTx
+func (_mock *MockTx) SetSeniority(ctx context.Context, userID string, seniority domain.Seniority) (domain.User, error) {
+	ret := _mock.Called(ctx, userID, seniority)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MergePullRequest")
+		panic("no return value specified for SetSeniority")
 	}
 
-	var r0 domain.PullRequest
+	var r0 domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) (domain.PullRequest, error)); ok {
-		return returnFunc(ctx, prID, mergedAt)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.Seniority) (domain.User, error)); ok {
+		return returnFunc(ctx, userID, seniority)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) domain.PullRequest); ok {
-		r0 = returnFunc(ctx, prID, mergedAt)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.Seniority) domain.User); ok {
+		r0 = returnFunc(ctx, userID, seniority)
 	} else {
-		r0 = ret.Get(0).(domain.PullRequest)
+		r0 = ret.Get(0).(domain.User)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
-		r1 = returnFunc(ctx, prID, mergedAt)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, domain.Seniority) error); ok {
+		r1 = returnFunc(ctx, userID, seniority)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTx_MergePullRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergePullRequest'
-type MockTx_MergePullRequest_Call struct {
+// MockTx_SetSeniority_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSeniority'
+type MockTx_SetSeniority_Call struct {
 	*mock.Call
 }
 
-// MergePullRequest is a helper method to define mock.On call
+// SetSeniority is a helper method to define mock.On call
 //   - ctx context.Context
-//   - prID string
-//   - mergedAt time.Time
-func (_e *MockTx_Expecter) MergePullRequest(ctx interface{}, prID interface{}, mergedAt interface{}) *MockTx_MergePullRequest_Call {
-	return &MockTx_MergePullRequest_Call{Call: _e.mock.On("MergePullRequest", ctx, prID, mergedAt)}
+//   - userID string
+//   - seniority domain.Seniority
+func (_e *MockTx_Expecter) SetSeniority(ctx interface{}, userID interface{}, seniority interface{}) *MockTx_SetSeniority_Call {
+	return &MockTx_SetSeniority_Call{Call: _e.mock.On("SetSeniority", ctx, userID, seniority)}
 }
 
-func (_c *MockTx_MergePullRequest_Call) Run(run func(ctx context.Context, prID string, mergedAt time.Time)) *MockTx_MergePullRequest_Call {
+func (_c *MockTx_SetSeniority_Call) Run(run func(ctx context.Context, userID string, seniority domain.Seniority)) *MockTx_SetSeniority_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -539,9 +1772,9 @@ func (_c *MockTx_MergePullRequest_Call) Run(run func(ctx context.Context, prID s
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 time.Time
+		var arg2 domain.Seniority
 		if args[2] != nil {
-			arg2 = args[2].(time.Time)
+			arg2 = args[2].(domain.Seniority)
 		}
 		run(
 			arg0,
@@ -552,57 +1785,56 @@ func (_c *MockTx_MergePullRequest_Call) Run(run func(ctx context.Context, prID s
 	return _c
 }
 
-func (_c *MockTx_MergePullRequest_Call) Return(pullRequest domain.PullRequest, err error) *MockTx_MergePullRequest_Call {
-	_c.Call.Return(pullRequest, err)
+func (_c *MockTx_SetSeniority_Call) Return(user domain.User, err error) *MockTx_SetSeniority_Call {
+	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *MockTx_MergePullRequest_Call) RunAndReturn(run func(ctx context.Context, prID string, mergedAt time.Time) (domain.PullRequest, error)) *MockTx_MergePullRequest_Call {
+func (_c *MockTx_SetSeniority_Call) RunAndReturn(run func(ctx context.Context, userID string, seniority domain.Seniority) (domain.User, error)) *MockTx_SetSeniority_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ReassignReviewer provides a mock function for the type MockTx
-func (_mock *MockTx) ReassignReviewer(ctx context.Context, prID string, oldReviewerID string, newReviewerID string) (domain.PullRequest, error) {
-	ret := _mock.Called(ctx, prID, oldReviewerID, newReviewerID)
+// SetTeamPolicy provides a mock function for the type MockTx
+func (_mock *MockTx) SetTeamPolicy(ctx context.Context, teamName string, policy domain.TeamPolicy) (domain.Team, error) {
+	ret := _mock.Called(ctx, teamName, policy)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ReassignReviewer")
+		panic("no return value specified for SetTeamPolicy")
 	}
 
-	var r0 domain.PullRequest
+	var r0 domain.Team
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (domain.PullRequest, error)); ok {
-		return returnFunc(ctx, prID, oldReviewerID, newReviewerID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TeamPolicy) (domain.Team, error)); ok {
+		return returnFunc(ctx, teamName, policy)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) domain.PullRequest); ok {
-		r0 = returnFunc(ctx, prID, oldReviewerID, newReviewerID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TeamPolicy) domain.Team); ok {
+		r0 = returnFunc(ctx, teamName, policy)
 	} else {
-		r0 = ret.Get(0).(domain.PullRequest)
+		r0 = ret.Get(0).(domain.Team)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = returnFunc(ctx, prID, oldReviewerID, newReviewerID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, domain.TeamPolicy) error); ok {
+		r1 = returnFunc(ctx, teamName, policy)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTx_ReassignReviewer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReassignReviewer'
-type MockTx_ReassignReviewer_Call struct {
+// MockTx_SetTeamPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTeamPolicy'
+type MockTx_SetTeamPolicy_Call struct {
 	*mock.Call
 }
 
-// ReassignReviewer is a helper method to define mock.On call
+// SetTeamPolicy is a helper method to define mock.On call
 //   - ctx context.Context
-//   - prID string
-//   - oldReviewerID string
-//   - newReviewerID string
-func (_e *MockTx_Expecter) ReassignReviewer(ctx interface{}, prID interface{}, oldReviewerID interface{}, newReviewerID interface{}) *MockTx_ReassignReviewer_Call {
-	return &MockTx_ReassignReviewer_Call{Call: _e.mock.On("ReassignReviewer", ctx, prID, oldReviewerID, newReviewerID)}
+//   - teamName string
+//   - policy domain.TeamPolicy
+func (_e *MockTx_Expecter) SetTeamPolicy(ctx interface{}, teamName interface{}, policy interface{}) *MockTx_SetTeamPolicy_Call {
+	return &MockTx_SetTeamPolicy_Call{Call: _e.mock.On("SetTeamPolicy", ctx, teamName, policy)}
 }
 
-func (_c *MockTx_ReassignReviewer_Call) Run(run func(ctx context.Context, prID string, oldReviewerID string, newReviewerID string)) *MockTx_ReassignReviewer_Call {
+func (_c *MockTx_SetTeamPolicy_Call) Run(run func(ctx context.Context, teamName string, policy domain.TeamPolicy)) *MockTx_SetTeamPolicy_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -612,125 +1844,133 @@ func (_c *MockTx_ReassignReviewer_Call) Run(run func(ctx context.Context, prID s
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 domain.TeamPolicy
 		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg2 = args[2].(domain.TeamPolicy)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTx_ReassignReviewer_Call) Return(pullRequest domain.PullRequest, err error) *MockTx_ReassignReviewer_Call {
-	_c.Call.Return(pullRequest, err)
+func (_c *MockTx_SetTeamPolicy_Call) Return(team domain.Team, err error) *MockTx_SetTeamPolicy_Call {
+	_c.Call.Return(team, err)
 	return _c
 }
 
-func (_c *MockTx_ReassignReviewer_Call) RunAndReturn(run func(ctx context.Context, prID string, oldReviewerID string, newReviewerID string) (domain.PullRequest, error)) *MockTx_ReassignReviewer_Call {
+func (_c *MockTx_SetTeamPolicy_Call) RunAndReturn(run func(ctx context.Context, teamName string, policy domain.TeamPolicy) (domain.Team, error)) *MockTx_SetTeamPolicy_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Rollback provides a mock function for the type MockTx
-func (_mock *MockTx) Rollback(ctx context.Context) error {
-	ret := _mock.Called(ctx)
+// SetUserTags provides a mock function for the type MockTx
+func (_mock *MockTx) SetUserTags(ctx context.Context, userID string, tags []string) error {
+	ret := _mock.Called(ctx, userID, tags)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Rollback")
+		panic("no return value specified for SetUserTags")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = returnFunc(ctx, userID, tags)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockTx_Rollback_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rollback'
-type MockTx_Rollback_Call struct {
+// MockTx_SetUserTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserTags'
+type MockTx_SetUserTags_Call struct {
 	*mock.Call
 }
 
-// Rollback is a helper method to define mock.On call
+// SetUserTags is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockTx_Expecter) Rollback(ctx interface{}) *MockTx_Rollback_Call {
-	return &MockTx_Rollback_Call{Call: _e.mock.On("Rollback", ctx)}
+//   - userID string
+//   - tags []string
+func (_e *MockTx_Expecter) SetUserTags(ctx interface{}, userID interface{}, tags interface{}) *MockTx_SetUserTags_Call {
+	return &MockTx_SetUserTags_Call{Call: _e.mock.On("SetUserTags", ctx, userID, tags)}
 }
 
-func (_c *MockTx_Rollback_Call) Run(run func(ctx context.Context)) *MockTx_Rollback_Call {
+func (_c *MockTx_SetUserTags_Call) Run(run func(ctx context.Context, userID string, tags []string)) *MockTx_SetUserTags_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTx_Rollback_Call) Return(err error) *MockTx_Rollback_Call {
+func (_c *MockTx_SetUserTags_Call) Return(err error) *MockTx_SetUserTags_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockTx_Rollback_Call) RunAndReturn(run func(ctx context.Context) error) *MockTx_Rollback_Call {
+func (_c *MockTx_SetUserTags_Call) RunAndReturn(run func(ctx context.Context, userID string, tags []string) error) *MockTx_SetUserTags_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetActive provides a mock function for the type MockTx
-func (_mock *MockTx) SetActive(ctx context.Context, userID string, isActive bool) (domain.User, error) {
-	ret := _mock.Called(ctx, userID, isActive)
+// SetWorkingHours provides a mock function for the type MockTx
+func (_mock *MockTx) SetWorkingHours(ctx context.Context, userID string, timezone string, hours *domain.WorkingHours) (domain.User, error) {
+	ret := _mock.Called(ctx, userID, timezone, hours)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetActive")
+		panic("no return value specified for SetWorkingHours")
 	}
 
 	var r0 domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) (domain.User, error)); ok {
-		return returnFunc(ctx, userID, isActive)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.WorkingHours) (domain.User, error)); ok {
+		return returnFunc(ctx, userID, timezone, hours)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) domain.User); ok {
-		r0 = returnFunc(ctx, userID, isActive)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.WorkingHours) domain.User); ok {
+		r0 = returnFunc(ctx, userID, timezone, hours)
 	} else {
 		r0 = ret.Get(0).(domain.User)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
-		r1 = returnFunc(ctx, userID, isActive)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *domain.WorkingHours) error); ok {
+		r1 = returnFunc(ctx, userID, timezone, hours)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTx_SetActive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetActive'
-type MockTx_SetActive_Call struct {
+// MockTx_SetWorkingHours_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWorkingHours'
+type MockTx_SetWorkingHours_Call struct {
 	*mock.Call
 }
 
-// SetActive is a helper method to define mock.On call
+// SetWorkingHours is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID string
-//   - isActive bool
-func (_e *MockTx_Expecter) SetActive(ctx interface{}, userID interface{}, isActive interface{}) *MockTx_SetActive_Call {
-	return &MockTx_SetActive_Call{Call: _e.mock.On("SetActive", ctx, userID, isActive)}
+//   - timezone string
+//   - hours *domain.WorkingHours
+func (_e *MockTx_Expecter) SetWorkingHours(ctx interface{}, userID interface{}, timezone interface{}, hours interface{}) *MockTx_SetWorkingHours_Call {
+	return &MockTx_SetWorkingHours_Call{Call: _e.mock.On("SetWorkingHours", ctx, userID, timezone, hours)}
 }
 
-func (_c *MockTx_SetActive_Call) Run(run func(ctx context.Context, userID string, isActive bool)) *MockTx_SetActive_Call {
+func (_c *MockTx_SetWorkingHours_Call) Run(run func(ctx context.Context, userID string, timezone string, hours *domain.WorkingHours)) *MockTx_SetWorkingHours_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -740,25 +1980,30 @@ func (_c *MockTx_SetActive_Call) Run(run func(ctx context.Context, userID string
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 bool
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(bool)
+			arg2 = args[2].(string)
+		}
+		var arg3 *domain.WorkingHours
+		if args[3] != nil {
+			arg3 = args[3].(*domain.WorkingHours)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTx_SetActive_Call) Return(user domain.User, err error) *MockTx_SetActive_Call {
+func (_c *MockTx_SetWorkingHours_Call) Return(user domain.User, err error) *MockTx_SetWorkingHours_Call {
 	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *MockTx_SetActive_Call) RunAndReturn(run func(ctx context.Context, userID string, isActive bool) (domain.User, error)) *MockTx_SetActive_Call {
+func (_c *MockTx_SetWorkingHours_Call) RunAndReturn(run func(ctx context.Context, userID string, timezone string, hours *domain.WorkingHours) (domain.User, error)) *MockTx_SetWorkingHours_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -99,3 +99,60 @@ func (_c *MockUnitOfWork_Begin_Call) RunAndReturn(run func(ctx context.Context)
 	_c.Call.Return(run)
 	return _c
 }
+
+// Do provides a mock function for the type MockUnitOfWork
+func (_mock *MockUnitOfWork) Do(ctx context.Context, fn func(tx repository.Tx) error) error {
+	ret := _mock.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Do")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(tx repository.Tx) error) error); ok {
+		r0 = returnFunc(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUnitOfWork_Do_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Do'
+type MockUnitOfWork_Do_Call struct {
+	*mock.Call
+}
+
+// Do is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(tx repository.Tx) error
+func (_e *MockUnitOfWork_Expecter) Do(ctx interface{}, fn interface{}) *MockUnitOfWork_Do_Call {
+	return &MockUnitOfWork_Do_Call{Call: _e.mock.On("Do", ctx, fn)}
+}
+
+func (_c *MockUnitOfWork_Do_Call) Run(run func(ctx context.Context, fn func(tx repository.Tx) error)) *MockUnitOfWork_Do_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 func(tx repository.Tx) error
+		if args[1] != nil {
+			arg1 = args[1].(func(tx repository.Tx) error)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUnitOfWork_Do_Call) Return(err error) *MockUnitOfWork_Do_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUnitOfWork_Do_Call) RunAndReturn(run func(ctx context.Context, fn func(tx repository.Tx) error) error) *MockUnitOfWork_Do_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -7,6 +7,7 @@ package repository
 import (
 	"context"
 	"pr-reviewer/internal/domain"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -38,6 +39,203 @@ func (_m *MockUserRepository) EXPECT() *MockUserRepository_Expecter {
 	return &MockUserRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddUnavailability provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) AddUnavailability(ctx context.Context, period domain.Unavailability) (domain.Unavailability, error) {
+	ret := _mock.Called(ctx, period)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddUnavailability")
+	}
+
+	var r0 domain.Unavailability
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Unavailability) (domain.Unavailability, error)); ok {
+		return returnFunc(ctx, period)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Unavailability) domain.Unavailability); ok {
+		r0 = returnFunc(ctx, period)
+	} else {
+		r0 = ret.Get(0).(domain.Unavailability)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Unavailability) error); ok {
+		r1 = returnFunc(ctx, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_AddUnavailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddUnavailability'
+type MockUserRepository_AddUnavailability_Call struct {
+	*mock.Call
+}
+
+// AddUnavailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - period domain.Unavailability
+func (_e *MockUserRepository_Expecter) AddUnavailability(ctx interface{}, period interface{}) *MockUserRepository_AddUnavailability_Call {
+	return &MockUserRepository_AddUnavailability_Call{Call: _e.mock.On("AddUnavailability", ctx, period)}
+}
+
+func (_c *MockUserRepository_AddUnavailability_Call) Run(run func(ctx context.Context, period domain.Unavailability)) *MockUserRepository_AddUnavailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Unavailability
+		if args[1] != nil {
+			arg1 = args[1].(domain.Unavailability)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_AddUnavailability_Call) Return(unavailability domain.Unavailability, err error) *MockUserRepository_AddUnavailability_Call {
+	_c.Call.Return(unavailability, err)
+	return _c
+}
+
+func (_c *MockUserRepository_AddUnavailability_Call) RunAndReturn(run func(ctx context.Context, period domain.Unavailability) (domain.Unavailability, error)) *MockUserRepository_AddUnavailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUnavailability provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) DeleteUnavailability(ctx context.Context, userID string, periodID int64) error {
+	ret := _mock.Called(ctx, userID, periodID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUnavailability")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = returnFunc(ctx, userID, periodID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_DeleteUnavailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUnavailability'
+type MockUserRepository_DeleteUnavailability_Call struct {
+	*mock.Call
+}
+
+// DeleteUnavailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - periodID int64
+func (_e *MockUserRepository_Expecter) DeleteUnavailability(ctx interface{}, userID interface{}, periodID interface{}) *MockUserRepository_DeleteUnavailability_Call {
+	return &MockUserRepository_DeleteUnavailability_Call{Call: _e.mock.On("DeleteUnavailability", ctx, userID, periodID)}
+}
+
+func (_c *MockUserRepository_DeleteUnavailability_Call) Run(run func(ctx context.Context, userID string, periodID int64)) *MockUserRepository_DeleteUnavailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_DeleteUnavailability_Call) Return(err error) *MockUserRepository_DeleteUnavailability_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_DeleteUnavailability_Call) RunAndReturn(run func(ctx context.Context, userID string, periodID int64) error) *MockUserRepository_DeleteUnavailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReviewLoad provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetReviewLoad(ctx context.Context, userIDs []string) (map[string]domain.ReviewLoad, error) {
+	ret := _mock.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviewLoad")
+	}
+
+	var r0 map[string]domain.ReviewLoad
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (map[string]domain.ReviewLoad, error)); ok {
+		return returnFunc(ctx, userIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) map[string]domain.ReviewLoad); ok {
+		r0 = returnFunc(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]domain.ReviewLoad)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetReviewLoad_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReviewLoad'
+type MockUserRepository_GetReviewLoad_Call struct {
+	*mock.Call
+}
+
+// GetReviewLoad is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []string
+func (_e *MockUserRepository_Expecter) GetReviewLoad(ctx interface{}, userIDs interface{}) *MockUserRepository_GetReviewLoad_Call {
+	return &MockUserRepository_GetReviewLoad_Call{Call: _e.mock.On("GetReviewLoad", ctx, userIDs)}
+}
+
+func (_c *MockUserRepository_GetReviewLoad_Call) Run(run func(ctx context.Context, userIDs []string)) *MockUserRepository_GetReviewLoad_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetReviewLoad_Call) Return(m map[string]domain.ReviewLoad, err error) *MockUserRepository_GetReviewLoad_Call {
+	_c.Call.Return(m, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetReviewLoad_Call) RunAndReturn(run func(ctx context.Context, userIDs []string) (map[string]domain.ReviewLoad, error)) *MockUserRepository_GetReviewLoad_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUserByID provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) GetUserByID(ctx context.Context, userID string) (domain.User, error) {
 	ret := _mock.Called(ctx, userID)
@@ -105,8 +303,8 @@ func (_c *MockUserRepository_GetUserByID_Call) RunAndReturn(run func(ctx context
 }
 
 // ListActiveByTeam provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) ListActiveByTeam(ctx context.Context, teamName string) ([]domain.User, error) {
-	ret := _mock.Called(ctx, teamName)
+func (_mock *MockUserRepository) ListActiveByTeam(ctx context.Context, teamName string, at time.Time) ([]domain.User, error) {
+	ret := _mock.Called(ctx, teamName, at)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListActiveByTeam")
@@ -114,18 +312,18 @@ func (_mock *MockUserRepository) ListActiveByTeam(ctx context.Context, teamName
 
 	var r0 []domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.User, error)); ok {
-		return returnFunc(ctx, teamName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]domain.User, error)); ok {
+		return returnFunc(ctx, teamName, at)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.User); ok {
-		r0 = returnFunc(ctx, teamName)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) []domain.User); ok {
+		r0 = returnFunc(ctx, teamName, at)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, teamName)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = returnFunc(ctx, teamName, at)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -140,11 +338,12 @@ type MockUserRepository_ListActiveByTeam_Call struct {
 // ListActiveByTeam is a helper method to define mock.On call
 //   - ctx context.Context
 //   - teamName string
-func (_e *MockUserRepository_Expecter) ListActiveByTeam(ctx interface{}, teamName interface{}) *MockUserRepository_ListActiveByTeam_Call {
-	return &MockUserRepository_ListActiveByTeam_Call{Call: _e.mock.On("ListActiveByTeam", ctx, teamName)}
+//   - at time.Time
+func (_e *MockUserRepository_Expecter) ListActiveByTeam(ctx interface{}, teamName interface{}, at interface{}) *MockUserRepository_ListActiveByTeam_Call {
+	return &MockUserRepository_ListActiveByTeam_Call{Call: _e.mock.On("ListActiveByTeam", ctx, teamName, at)}
 }
 
-func (_c *MockUserRepository_ListActiveByTeam_Call) Run(run func(ctx context.Context, teamName string)) *MockUserRepository_ListActiveByTeam_Call {
+func (_c *MockUserRepository_ListActiveByTeam_Call) Run(run func(ctx context.Context, teamName string, at time.Time)) *MockUserRepository_ListActiveByTeam_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -154,9 +353,14 @@ func (_c *MockUserRepository_ListActiveByTeam_Call) Run(run func(ctx context.Con
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -167,7 +371,143 @@ func (_c *MockUserRepository_ListActiveByTeam_Call) Return(users []domain.User,
 	return _c
 }
 
-func (_c *MockUserRepository_ListActiveByTeam_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]domain.User, error)) *MockUserRepository_ListActiveByTeam_Call {
+func (_c *MockUserRepository_ListActiveByTeam_Call) RunAndReturn(run func(ctx context.Context, teamName string, at time.Time) ([]domain.User, error)) *MockUserRepository_ListActiveByTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUnavailability provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) ListUnavailability(ctx context.Context, userID string) ([]domain.Unavailability, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnavailability")
+	}
+
+	var r0 []domain.Unavailability
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.Unavailability, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.Unavailability); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Unavailability)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_ListUnavailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnavailability'
+type MockUserRepository_ListUnavailability_Call struct {
+	*mock.Call
+}
+
+// ListUnavailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockUserRepository_Expecter) ListUnavailability(ctx interface{}, userID interface{}) *MockUserRepository_ListUnavailability_Call {
+	return &MockUserRepository_ListUnavailability_Call{Call: _e.mock.On("ListUnavailability", ctx, userID)}
+}
+
+func (_c *MockUserRepository_ListUnavailability_Call) Run(run func(ctx context.Context, userID string)) *MockUserRepository_ListUnavailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_ListUnavailability_Call) Return(unavailabilitys []domain.Unavailability, err error) *MockUserRepository_ListUnavailability_Call {
+	_c.Call.Return(unavailabilitys, err)
+	return _c
+}
+
+func (_c *MockUserRepository_ListUnavailability_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]domain.Unavailability, error)) *MockUserRepository_ListUnavailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserTags provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) ListUserTags(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	ret := _mock.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserTags")
+	}
+
+	var r0 map[string][]string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (map[string][]string, error)); ok {
+		return returnFunc(ctx, userIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) map[string][]string); ok {
+		r0 = returnFunc(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_ListUserTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserTags'
+type MockUserRepository_ListUserTags_Call struct {
+	*mock.Call
+}
+
+// ListUserTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userIDs []string
+func (_e *MockUserRepository_Expecter) ListUserTags(ctx interface{}, userIDs interface{}) *MockUserRepository_ListUserTags_Call {
+	return &MockUserRepository_ListUserTags_Call{Call: _e.mock.On("ListUserTags", ctx, userIDs)}
+}
+
+func (_c *MockUserRepository_ListUserTags_Call) Run(run func(ctx context.Context, userIDs []string)) *MockUserRepository_ListUserTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_ListUserTags_Call) Return(m map[string][]string, err error) *MockUserRepository_ListUserTags_Call {
+	_c.Call.Return(m, err)
+	return _c
+}
+
+func (_c *MockUserRepository_ListUserTags_Call) RunAndReturn(run func(ctx context.Context, userIDs []string) (map[string][]string, error)) *MockUserRepository_ListUserTags_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -243,3 +583,288 @@ func (_c *MockUserRepository_SetActive_Call) RunAndReturn(run func(ctx context.C
 	_c.Call.Return(run)
 	return _c
 }
+
+// SetReviewCapacity provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) SetReviewCapacity(ctx context.Context, userID string, capacity *int) (domain.User, error) {
+	ret := _mock.Called(ctx, userID, capacity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetReviewCapacity")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *int) (domain.User, error)); ok {
+		return returnFunc(ctx, userID, capacity)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *int) domain.User); ok {
+		r0 = returnFunc(ctx, userID, capacity)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *int) error); ok {
+		r1 = returnFunc(ctx, userID, capacity)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_SetReviewCapacity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetReviewCapacity'
+type MockUserRepository_SetReviewCapacity_Call struct {
+	*mock.Call
+}
+
+// SetReviewCapacity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - capacity *int
+func (_e *MockUserRepository_Expecter) SetReviewCapacity(ctx interface{}, userID interface{}, capacity interface{}) *MockUserRepository_SetReviewCapacity_Call {
+	return &MockUserRepository_SetReviewCapacity_Call{Call: _e.mock.On("SetReviewCapacity", ctx, userID, capacity)}
+}
+
+func (_c *MockUserRepository_SetReviewCapacity_Call) Run(run func(ctx context.Context, userID string, capacity *int)) *MockUserRepository_SetReviewCapacity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *int
+		if args[2] != nil {
+			arg2 = args[2].(*int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_SetReviewCapacity_Call) Return(user domain.User, err error) *MockUserRepository_SetReviewCapacity_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserRepository_SetReviewCapacity_Call) RunAndReturn(run func(ctx context.Context, userID string, capacity *int) (domain.User, error)) *MockUserRepository_SetReviewCapacity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetSeniority provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) SetSeniority(ctx context.Context, userID string, seniority domain.Seniority) (domain.User, error) {
+	ret := _mock.Called(ctx, userID, seniority)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSeniority")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.Seniority) (domain.User, error)); ok {
+		return returnFunc(ctx, userID, seniority)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.Seniority) domain.User); ok {
+		r0 = returnFunc(ctx, userID, seniority)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, domain.Seniority) error); ok {
+		r1 = returnFunc(ctx, userID, seniority)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_SetSeniority_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSeniority'
+type MockUserRepository_SetSeniority_Call struct {
+	*mock.Call
+}
+
+// SetSeniority is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - seniority domain.Seniority
+func (_e *MockUserRepository_Expecter) SetSeniority(ctx interface{}, userID interface{}, seniority interface{}) *MockUserRepository_SetSeniority_Call {
+	return &MockUserRepository_SetSeniority_Call{Call: _e.mock.On("SetSeniority", ctx, userID, seniority)}
+}
+
+func (_c *MockUserRepository_SetSeniority_Call) Run(run func(ctx context.Context, userID string, seniority domain.Seniority)) *MockUserRepository_SetSeniority_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 domain.Seniority
+		if args[2] != nil {
+			arg2 = args[2].(domain.Seniority)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_SetSeniority_Call) Return(user domain.User, err error) *MockUserRepository_SetSeniority_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserRepository_SetSeniority_Call) RunAndReturn(run func(ctx context.Context, userID string, seniority domain.Seniority) (domain.User, error)) *MockUserRepository_SetSeniority_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUserTags provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) SetUserTags(ctx context.Context, userID string, tags []string) error {
+	ret := _mock.Called(ctx, userID, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserTags")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = returnFunc(ctx, userID, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_SetUserTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUserTags'
+type MockUserRepository_SetUserTags_Call struct {
+	*mock.Call
+}
+
+// SetUserTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - tags []string
+func (_e *MockUserRepository_Expecter) SetUserTags(ctx interface{}, userID interface{}, tags interface{}) *MockUserRepository_SetUserTags_Call {
+	return &MockUserRepository_SetUserTags_Call{Call: _e.mock.On("SetUserTags", ctx, userID, tags)}
+}
+
+func (_c *MockUserRepository_SetUserTags_Call) Run(run func(ctx context.Context, userID string, tags []string)) *MockUserRepository_SetUserTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_SetUserTags_Call) Return(err error) *MockUserRepository_SetUserTags_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_SetUserTags_Call) RunAndReturn(run func(ctx context.Context, userID string, tags []string) error) *MockUserRepository_SetUserTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetWorkingHours provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) SetWorkingHours(ctx context.Context, userID string, timezone string, hours *domain.WorkingHours) (domain.User, error) {
+	ret := _mock.Called(ctx, userID, timezone, hours)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWorkingHours")
+	}
+
+	var r0 domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.WorkingHours) (domain.User, error)); ok {
+		return returnFunc(ctx, userID, timezone, hours)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.WorkingHours) domain.User); ok {
+		r0 = returnFunc(ctx, userID, timezone, hours)
+	} else {
+		r0 = ret.Get(0).(domain.User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *domain.WorkingHours) error); ok {
+		r1 = returnFunc(ctx, userID, timezone, hours)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_SetWorkingHours_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWorkingHours'
+type MockUserRepository_SetWorkingHours_Call struct {
+	*mock.Call
+}
+
+// SetWorkingHours is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - timezone string
+//   - hours *domain.WorkingHours
+func (_e *MockUserRepository_Expecter) SetWorkingHours(ctx interface{}, userID interface{}, timezone interface{}, hours interface{}) *MockUserRepository_SetWorkingHours_Call {
+	return &MockUserRepository_SetWorkingHours_Call{Call: _e.mock.On("SetWorkingHours", ctx, userID, timezone, hours)}
+}
+
+func (_c *MockUserRepository_SetWorkingHours_Call) Run(run func(ctx context.Context, userID string, timezone string, hours *domain.WorkingHours)) *MockUserRepository_SetWorkingHours_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *domain.WorkingHours
+		if args[3] != nil {
+			arg3 = args[3].(*domain.WorkingHours)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_SetWorkingHours_Call) Return(user domain.User, err error) *MockUserRepository_SetWorkingHours_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserRepository_SetWorkingHours_Call) RunAndReturn(run func(ctx context.Context, userID string, timezone string, hours *domain.WorkingHours) (domain.User, error)) *MockUserRepository_SetWorkingHours_Call {
+	_c.Call.Return(run)
+	return _c
+}
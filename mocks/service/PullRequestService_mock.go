@@ -106,12 +106,12 @@ func (_c *MockPullRequestService_Create_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
-// Merge provides a mock function for the type MockPullRequestService
-func (_mock *MockPullRequestService) Merge(ctx context.Context, prID string) (*domain.PullRequest, error) {
+// GetPullRequest provides a mock function for the type MockPullRequestService
+func (_mock *MockPullRequestService) GetPullRequest(ctx context.Context, prID string) (*domain.PullRequest, error) {
 	ret := _mock.Called(ctx, prID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Merge")
+		panic("no return value specified for GetPullRequest")
 	}
 
 	var r0 *domain.PullRequest
@@ -134,6 +134,74 @@ func (_mock *MockPullRequestService) Merge(ctx context.Context, prID string) (*d
 	return r0, r1
 }
 
+// MockPullRequestService_GetPullRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPullRequest'
+type MockPullRequestService_GetPullRequest_Call struct {
+	*mock.Call
+}
+
+// GetPullRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prID string
+func (_e *MockPullRequestService_Expecter) GetPullRequest(ctx interface{}, prID interface{}) *MockPullRequestService_GetPullRequest_Call {
+	return &MockPullRequestService_GetPullRequest_Call{Call: _e.mock.On("GetPullRequest", ctx, prID)}
+}
+
+func (_c *MockPullRequestService_GetPullRequest_Call) Run(run func(ctx context.Context, prID string)) *MockPullRequestService_GetPullRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPullRequestService_GetPullRequest_Call) Return(pullRequest *domain.PullRequest, err error) *MockPullRequestService_GetPullRequest_Call {
+	_c.Call.Return(pullRequest, err)
+	return _c
+}
+
+func (_c *MockPullRequestService_GetPullRequest_Call) RunAndReturn(run func(ctx context.Context, prID string) (*domain.PullRequest, error)) *MockPullRequestService_GetPullRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Merge provides a mock function for the type MockPullRequestService
+func (_mock *MockPullRequestService) Merge(ctx context.Context, prID string, ifVersions []int64) (*domain.PullRequest, error) {
+	ret := _mock.Called(ctx, prID, ifVersions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 *domain.PullRequest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []int64) (*domain.PullRequest, error)); ok {
+		return returnFunc(ctx, prID, ifVersions)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []int64) *domain.PullRequest); ok {
+		r0 = returnFunc(ctx, prID, ifVersions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PullRequest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []int64) error); ok {
+		r1 = returnFunc(ctx, prID, ifVersions)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
 // MockPullRequestService_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
 type MockPullRequestService_Merge_Call struct {
 	*mock.Call
@@ -142,11 +210,12 @@ type MockPullRequestService_Merge_Call struct {
 // Merge is a helper method to define mock.On call
 //   - ctx context.Context
 //   - prID string
-func (_e *MockPullRequestService_Expecter) Merge(ctx interface{}, prID interface{}) *MockPullRequestService_Merge_Call {
-	return &MockPullRequestService_Merge_Call{Call: _e.mock.On("Merge", ctx, prID)}
+//   - ifVersions []int64
+func (_e *MockPullRequestService_Expecter) Merge(ctx interface{}, prID interface{}, ifVersions interface{}) *MockPullRequestService_Merge_Call {
+	return &MockPullRequestService_Merge_Call{Call: _e.mock.On("Merge", ctx, prID, ifVersions)}
 }
 
-func (_c *MockPullRequestService_Merge_Call) Run(run func(ctx context.Context, prID string)) *MockPullRequestService_Merge_Call {
+func (_c *MockPullRequestService_Merge_Call) Run(run func(ctx context.Context, prID string, ifVersions []int64)) *MockPullRequestService_Merge_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -156,9 +225,14 @@ func (_c *MockPullRequestService_Merge_Call) Run(run func(ctx context.Context, p
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 []int64
+		if args[2] != nil {
+			arg2 = args[2].([]int64)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -169,14 +243,82 @@ func (_c *MockPullRequestService_Merge_Call) Return(pullRequest *domain.PullRequ
 	return _c
 }
 
-func (_c *MockPullRequestService_Merge_Call) RunAndReturn(run func(ctx context.Context, prID string) (*domain.PullRequest, error)) *MockPullRequestService_Merge_Call {
+func (_c *MockPullRequestService_Merge_Call) RunAndReturn(run func(ctx context.Context, prID string, ifVersions []int64) (*domain.PullRequest, error)) *MockPullRequestService_Merge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PreviewAssignment provides a mock function for the type MockPullRequestService
+func (_mock *MockPullRequestService) PreviewAssignment(ctx context.Context, pr domain.PullRequest) (*domain.AssignmentPreview, error) {
+	ret := _mock.Called(ctx, pr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewAssignment")
+	}
+
+	var r0 *domain.AssignmentPreview
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.PullRequest) (*domain.AssignmentPreview, error)); ok {
+		return returnFunc(ctx, pr)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.PullRequest) *domain.AssignmentPreview); ok {
+		r0 = returnFunc(ctx, pr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AssignmentPreview)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.PullRequest) error); ok {
+		r1 = returnFunc(ctx, pr)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPullRequestService_PreviewAssignment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewAssignment'
+type MockPullRequestService_PreviewAssignment_Call struct {
+	*mock.Call
+}
+
+// PreviewAssignment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pr domain.PullRequest
+func (_e *MockPullRequestService_Expecter) PreviewAssignment(ctx interface{}, pr interface{}) *MockPullRequestService_PreviewAssignment_Call {
+	return &MockPullRequestService_PreviewAssignment_Call{Call: _e.mock.On("PreviewAssignment", ctx, pr)}
+}
+
+func (_c *MockPullRequestService_PreviewAssignment_Call) Run(run func(ctx context.Context, pr domain.PullRequest)) *MockPullRequestService_PreviewAssignment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.PullRequest
+		if args[1] != nil {
+			arg1 = args[1].(domain.PullRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPullRequestService_PreviewAssignment_Call) Return(assignmentPreview *domain.AssignmentPreview, err error) *MockPullRequestService_PreviewAssignment_Call {
+	_c.Call.Return(assignmentPreview, err)
+	return _c
+}
+
+func (_c *MockPullRequestService_PreviewAssignment_Call) RunAndReturn(run func(ctx context.Context, pr domain.PullRequest) (*domain.AssignmentPreview, error)) *MockPullRequestService_PreviewAssignment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Reassign provides a mock function for the type MockPullRequestService
-func (_mock *MockPullRequestService) Reassign(ctx context.Context, prID string, oldReviewerID string) (*domain.PullRequest, string, error) {
-	ret := _mock.Called(ctx, prID, oldReviewerID)
+func (_mock *MockPullRequestService) Reassign(ctx context.Context, prID string, oldReviewerID string, ifVersions []int64) (*domain.PullRequest, string, error) {
+	ret := _mock.Called(ctx, prID, oldReviewerID, ifVersions)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Reassign")
@@ -185,23 +327,23 @@ func (_mock *MockPullRequestService) Reassign(ctx context.Context, prID string,
 	var r0 *domain.PullRequest
 	var r1 string
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.PullRequest, string, error)); ok {
-		return returnFunc(ctx, prID, oldReviewerID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []int64) (*domain.PullRequest, string, error)); ok {
+		return returnFunc(ctx, prID, oldReviewerID, ifVersions)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.PullRequest); ok {
-		r0 = returnFunc(ctx, prID, oldReviewerID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []int64) *domain.PullRequest); ok {
+		r0 = returnFunc(ctx, prID, oldReviewerID, ifVersions)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.PullRequest)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
-		r1 = returnFunc(ctx, prID, oldReviewerID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, []int64) string); ok {
+		r1 = returnFunc(ctx, prID, oldReviewerID, ifVersions)
 	} else {
 		r1 = ret.Get(1).(string)
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
-		r2 = returnFunc(ctx, prID, oldReviewerID)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, string, []int64) error); ok {
+		r2 = returnFunc(ctx, prID, oldReviewerID, ifVersions)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -217,11 +359,12 @@ type MockPullRequestService_Reassign_Call struct {
 //   - ctx context.Context
 //   - prID string
 //   - oldReviewerID string
-func (_e *MockPullRequestService_Expecter) Reassign(ctx interface{}, prID interface{}, oldReviewerID interface{}) *MockPullRequestService_Reassign_Call {
-	return &MockPullRequestService_Reassign_Call{Call: _e.mock.On("Reassign", ctx, prID, oldReviewerID)}
+//   - ifVersions []int64
+func (_e *MockPullRequestService_Expecter) Reassign(ctx interface{}, prID interface{}, oldReviewerID interface{}, ifVersions interface{}) *MockPullRequestService_Reassign_Call {
+	return &MockPullRequestService_Reassign_Call{Call: _e.mock.On("Reassign", ctx, prID, oldReviewerID, ifVersions)}
 }
 
-func (_c *MockPullRequestService_Reassign_Call) Run(run func(ctx context.Context, prID string, oldReviewerID string)) *MockPullRequestService_Reassign_Call {
+func (_c *MockPullRequestService_Reassign_Call) Run(run func(ctx context.Context, prID string, oldReviewerID string, ifVersions []int64)) *MockPullRequestService_Reassign_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -235,10 +378,15 @@ func (_c *MockPullRequestService_Reassign_Call) Run(run func(ctx context.Context
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 []int64
+		if args[3] != nil {
+			arg3 = args[3].([]int64)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -249,7 +397,7 @@ func (_c *MockPullRequestService_Reassign_Call) Return(pullRequest *domain.PullR
 	return _c
 }
 
-func (_c *MockPullRequestService_Reassign_Call) RunAndReturn(run func(ctx context.Context, prID string, oldReviewerID string) (*domain.PullRequest, string, error)) *MockPullRequestService_Reassign_Call {
+func (_c *MockPullRequestService_Reassign_Call) RunAndReturn(run func(ctx context.Context, prID string, oldReviewerID string, ifVersions []int64) (*domain.PullRequest, string, error)) *MockPullRequestService_Reassign_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -173,3 +173,225 @@ func (_c *MockTeamService_GetTeam_Call) RunAndReturn(run func(ctx context.Contex
 	_c.Call.Return(run)
 	return _c
 }
+
+// SetDefaultReviewCapacity provides a mock function for the type MockTeamService
+func (_mock *MockTeamService) SetDefaultReviewCapacity(ctx context.Context, teamName string, capacity *int) (*domain.Team, error) {
+	ret := _mock.Called(ctx, teamName, capacity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDefaultReviewCapacity")
+	}
+
+	var r0 *domain.Team
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *int) (*domain.Team, error)); ok {
+		return returnFunc(ctx, teamName, capacity)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *int) *domain.Team); ok {
+		r0 = returnFunc(ctx, teamName, capacity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Team)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *int) error); ok {
+		r1 = returnFunc(ctx, teamName, capacity)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTeamService_SetDefaultReviewCapacity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDefaultReviewCapacity'
+type MockTeamService_SetDefaultReviewCapacity_Call struct {
+	*mock.Call
+}
+
+// SetDefaultReviewCapacity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - capacity *int
+func (_e *MockTeamService_Expecter) SetDefaultReviewCapacity(ctx interface{}, teamName interface{}, capacity interface{}) *MockTeamService_SetDefaultReviewCapacity_Call {
+	return &MockTeamService_SetDefaultReviewCapacity_Call{Call: _e.mock.On("SetDefaultReviewCapacity", ctx, teamName, capacity)}
+}
+
+func (_c *MockTeamService_SetDefaultReviewCapacity_Call) Run(run func(ctx context.Context, teamName string, capacity *int)) *MockTeamService_SetDefaultReviewCapacity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *int
+		if args[2] != nil {
+			arg2 = args[2].(*int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamService_SetDefaultReviewCapacity_Call) Return(team *domain.Team, err error) *MockTeamService_SetDefaultReviewCapacity_Call {
+	_c.Call.Return(team, err)
+	return _c
+}
+
+func (_c *MockTeamService_SetDefaultReviewCapacity_Call) RunAndReturn(run func(ctx context.Context, teamName string, capacity *int) (*domain.Team, error)) *MockTeamService_SetDefaultReviewCapacity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetFallbackTeams provides a mock function for the type MockTeamService
+func (_mock *MockTeamService) SetFallbackTeams(ctx context.Context, teamName string, fallbacks []string) (*domain.Team, error) {
+	ret := _mock.Called(ctx, teamName, fallbacks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFallbackTeams")
+	}
+
+	var r0 *domain.Team
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) (*domain.Team, error)); ok {
+		return returnFunc(ctx, teamName, fallbacks)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) *domain.Team); ok {
+		r0 = returnFunc(ctx, teamName, fallbacks)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Team)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, teamName, fallbacks)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTeamService_SetFallbackTeams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFallbackTeams'
+type MockTeamService_SetFallbackTeams_Call struct {
+	*mock.Call
+}
+
+// SetFallbackTeams is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - fallbacks []string
+func (_e *MockTeamService_Expecter) SetFallbackTeams(ctx interface{}, teamName interface{}, fallbacks interface{}) *MockTeamService_SetFallbackTeams_Call {
+	return &MockTeamService_SetFallbackTeams_Call{Call: _e.mock.On("SetFallbackTeams", ctx, teamName, fallbacks)}
+}
+
+func (_c *MockTeamService_SetFallbackTeams_Call) Run(run func(ctx context.Context, teamName string, fallbacks []string)) *MockTeamService_SetFallbackTeams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamService_SetFallbackTeams_Call) Return(team *domain.Team, err error) *MockTeamService_SetFallbackTeams_Call {
+	_c.Call.Return(team, err)
+	return _c
+}
+
+func (_c *MockTeamService_SetFallbackTeams_Call) RunAndReturn(run func(ctx context.Context, teamName string, fallbacks []string) (*domain.Team, error)) *MockTeamService_SetFallbackTeams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetPolicy provides a mock function for the type MockTeamService
+func (_mock *MockTeamService) SetPolicy(ctx context.Context, teamName string, policy domain.TeamPolicy) (*domain.Team, error) {
+	ret := _mock.Called(ctx, teamName, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPolicy")
+	}
+
+	var r0 *domain.Team
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TeamPolicy) (*domain.Team, error)); ok {
+		return returnFunc(ctx, teamName, policy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TeamPolicy) *domain.Team); ok {
+		r0 = returnFunc(ctx, teamName, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Team)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, domain.TeamPolicy) error); ok {
+		r1 = returnFunc(ctx, teamName, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTeamService_SetPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetPolicy'
+type MockTeamService_SetPolicy_Call struct {
+	*mock.Call
+}
+
+// SetPolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - policy domain.TeamPolicy
+func (_e *MockTeamService_Expecter) SetPolicy(ctx interface{}, teamName interface{}, policy interface{}) *MockTeamService_SetPolicy_Call {
+	return &MockTeamService_SetPolicy_Call{Call: _e.mock.On("SetPolicy", ctx, teamName, policy)}
+}
+
+func (_c *MockTeamService_SetPolicy_Call) Run(run func(ctx context.Context, teamName string, policy domain.TeamPolicy)) *MockTeamService_SetPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 domain.TeamPolicy
+		if args[2] != nil {
+			arg2 = args[2].(domain.TeamPolicy)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamService_SetPolicy_Call) Return(team *domain.Team, err error) *MockTeamService_SetPolicy_Call {
+	_c.Call.Return(team, err)
+	return _c
+}
+
+func (_c *MockTeamService_SetPolicy_Call) RunAndReturn(run func(ctx context.Context, teamName string, policy domain.TeamPolicy) (*domain.Team, error)) *MockTeamService_SetPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
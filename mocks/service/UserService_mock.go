@@ -38,6 +38,137 @@ func (_m *MockUserService) EXPECT() *MockUserService_Expecter {
 	return &MockUserService_Expecter{mock: &_m.Mock}
 }
 
+// AddUnavailability provides a mock function for the type MockUserService
+func (_mock *MockUserService) AddUnavailability(ctx context.Context, period domain.Unavailability) (*domain.Unavailability, error) {
+	ret := _mock.Called(ctx, period)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddUnavailability")
+	}
+
+	var r0 *domain.Unavailability
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Unavailability) (*domain.Unavailability, error)); ok {
+		return returnFunc(ctx, period)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Unavailability) *domain.Unavailability); ok {
+		r0 = returnFunc(ctx, period)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Unavailability)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.Unavailability) error); ok {
+		r1 = returnFunc(ctx, period)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_AddUnavailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddUnavailability'
+type MockUserService_AddUnavailability_Call struct {
+	*mock.Call
+}
+
+// AddUnavailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - period domain.Unavailability
+func (_e *MockUserService_Expecter) AddUnavailability(ctx interface{}, period interface{}) *MockUserService_AddUnavailability_Call {
+	return &MockUserService_AddUnavailability_Call{Call: _e.mock.On("AddUnavailability", ctx, period)}
+}
+
+func (_c *MockUserService_AddUnavailability_Call) Run(run func(ctx context.Context, period domain.Unavailability)) *MockUserService_AddUnavailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Unavailability
+		if args[1] != nil {
+			arg1 = args[1].(domain.Unavailability)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_AddUnavailability_Call) Return(unavailability *domain.Unavailability, err error) *MockUserService_AddUnavailability_Call {
+	_c.Call.Return(unavailability, err)
+	return _c
+}
+
+func (_c *MockUserService_AddUnavailability_Call) RunAndReturn(run func(ctx context.Context, period domain.Unavailability) (*domain.Unavailability, error)) *MockUserService_AddUnavailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUnavailability provides a mock function for the type MockUserService
+func (_mock *MockUserService) DeleteUnavailability(ctx context.Context, userID string, periodID int64) error {
+	ret := _mock.Called(ctx, userID, periodID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUnavailability")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = returnFunc(ctx, userID, periodID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserService_DeleteUnavailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUnavailability'
+type MockUserService_DeleteUnavailability_Call struct {
+	*mock.Call
+}
+
+// DeleteUnavailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - periodID int64
+func (_e *MockUserService_Expecter) DeleteUnavailability(ctx interface{}, userID interface{}, periodID interface{}) *MockUserService_DeleteUnavailability_Call {
+	return &MockUserService_DeleteUnavailability_Call{Call: _e.mock.On("DeleteUnavailability", ctx, userID, periodID)}
+}
+
+func (_c *MockUserService_DeleteUnavailability_Call) Run(run func(ctx context.Context, userID string, periodID int64)) *MockUserService_DeleteUnavailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_DeleteUnavailability_Call) Return(err error) *MockUserService_DeleteUnavailability_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserService_DeleteUnavailability_Call) RunAndReturn(run func(ctx context.Context, userID string, periodID int64) error) *MockUserService_DeleteUnavailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetReviewPullRequests provides a mock function for the type MockUserService
 func (_mock *MockUserService) GetReviewPullRequests(ctx context.Context, userID string) ([]domain.PullRequestShort, error) {
 	ret := _mock.Called(ctx, userID)
@@ -106,6 +237,142 @@ func (_c *MockUserService_GetReviewPullRequests_Call) RunAndReturn(run func(ctx
 	return _c
 }
 
+// GetTags provides a mock function for the type MockUserService
+func (_mock *MockUserService) GetTags(ctx context.Context, userID string) ([]string, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTags")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_GetTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTags'
+type MockUserService_GetTags_Call struct {
+	*mock.Call
+}
+
+// GetTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockUserService_Expecter) GetTags(ctx interface{}, userID interface{}) *MockUserService_GetTags_Call {
+	return &MockUserService_GetTags_Call{Call: _e.mock.On("GetTags", ctx, userID)}
+}
+
+func (_c *MockUserService_GetTags_Call) Run(run func(ctx context.Context, userID string)) *MockUserService_GetTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_GetTags_Call) Return(ss []string, err error) *MockUserService_GetTags_Call {
+	_c.Call.Return(ss, err)
+	return _c
+}
+
+func (_c *MockUserService_GetTags_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]string, error)) *MockUserService_GetTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUnavailability provides a mock function for the type MockUserService
+func (_mock *MockUserService) ListUnavailability(ctx context.Context, userID string) ([]domain.Unavailability, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnavailability")
+	}
+
+	var r0 []domain.Unavailability
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]domain.Unavailability, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []domain.Unavailability); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Unavailability)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_ListUnavailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnavailability'
+type MockUserService_ListUnavailability_Call struct {
+	*mock.Call
+}
+
+// ListUnavailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockUserService_Expecter) ListUnavailability(ctx interface{}, userID interface{}) *MockUserService_ListUnavailability_Call {
+	return &MockUserService_ListUnavailability_Call{Call: _e.mock.On("ListUnavailability", ctx, userID)}
+}
+
+func (_c *MockUserService_ListUnavailability_Call) Run(run func(ctx context.Context, userID string)) *MockUserService_ListUnavailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_ListUnavailability_Call) Return(unavailabilitys []domain.Unavailability, err error) *MockUserService_ListUnavailability_Call {
+	_c.Call.Return(unavailabilitys, err)
+	return _c
+}
+
+func (_c *MockUserService_ListUnavailability_Call) RunAndReturn(run func(ctx context.Context, userID string) ([]domain.Unavailability, error)) *MockUserService_ListUnavailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetActive provides a mock function for the type MockUserService
 func (_mock *MockUserService) SetActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
 	ret := _mock.Called(ctx, userID, isActive)
@@ -179,3 +446,305 @@ func (_c *MockUserService_SetActive_Call) RunAndReturn(run func(ctx context.Cont
 	_c.Call.Return(run)
 	return _c
 }
+
+// SetReviewCapacity provides a mock function for the type MockUserService
+func (_mock *MockUserService) SetReviewCapacity(ctx context.Context, userID string, capacity *int) (*domain.User, error) {
+	ret := _mock.Called(ctx, userID, capacity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetReviewCapacity")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *int) (*domain.User, error)); ok {
+		return returnFunc(ctx, userID, capacity)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *int) *domain.User); ok {
+		r0 = returnFunc(ctx, userID, capacity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *int) error); ok {
+		r1 = returnFunc(ctx, userID, capacity)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_SetReviewCapacity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetReviewCapacity'
+type MockUserService_SetReviewCapacity_Call struct {
+	*mock.Call
+}
+
+// SetReviewCapacity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - capacity *int
+func (_e *MockUserService_Expecter) SetReviewCapacity(ctx interface{}, userID interface{}, capacity interface{}) *MockUserService_SetReviewCapacity_Call {
+	return &MockUserService_SetReviewCapacity_Call{Call: _e.mock.On("SetReviewCapacity", ctx, userID, capacity)}
+}
+
+func (_c *MockUserService_SetReviewCapacity_Call) Run(run func(ctx context.Context, userID string, capacity *int)) *MockUserService_SetReviewCapacity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *int
+		if args[2] != nil {
+			arg2 = args[2].(*int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_SetReviewCapacity_Call) Return(user *domain.User, err error) *MockUserService_SetReviewCapacity_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserService_SetReviewCapacity_Call) RunAndReturn(run func(ctx context.Context, userID string, capacity *int) (*domain.User, error)) *MockUserService_SetReviewCapacity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetSeniority provides a mock function for the type MockUserService
+func (_mock *MockUserService) SetSeniority(ctx context.Context, userID string, seniority domain.Seniority) (*domain.User, error) {
+	ret := _mock.Called(ctx, userID, seniority)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSeniority")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.Seniority) (*domain.User, error)); ok {
+		return returnFunc(ctx, userID, seniority)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.Seniority) *domain.User); ok {
+		r0 = returnFunc(ctx, userID, seniority)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, domain.Seniority) error); ok {
+		r1 = returnFunc(ctx, userID, seniority)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_SetSeniority_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSeniority'
+type MockUserService_SetSeniority_Call struct {
+	*mock.Call
+}
+
+// SetSeniority is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - seniority domain.Seniority
+func (_e *MockUserService_Expecter) SetSeniority(ctx interface{}, userID interface{}, seniority interface{}) *MockUserService_SetSeniority_Call {
+	return &MockUserService_SetSeniority_Call{Call: _e.mock.On("SetSeniority", ctx, userID, seniority)}
+}
+
+func (_c *MockUserService_SetSeniority_Call) Run(run func(ctx context.Context, userID string, seniority domain.Seniority)) *MockUserService_SetSeniority_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 domain.Seniority
+		if args[2] != nil {
+			arg2 = args[2].(domain.Seniority)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_SetSeniority_Call) Return(user *domain.User, err error) *MockUserService_SetSeniority_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserService_SetSeniority_Call) RunAndReturn(run func(ctx context.Context, userID string, seniority domain.Seniority) (*domain.User, error)) *MockUserService_SetSeniority_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTags provides a mock function for the type MockUserService
+func (_mock *MockUserService) SetTags(ctx context.Context, userID string, tags []string) ([]string, error) {
+	ret := _mock.Called(ctx, userID, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTags")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) ([]string, error)); ok {
+		return returnFunc(ctx, userID, tags)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) []string); ok {
+		r0 = returnFunc(ctx, userID, tags)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = returnFunc(ctx, userID, tags)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_SetTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTags'
+type MockUserService_SetTags_Call struct {
+	*mock.Call
+}
+
+// SetTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - tags []string
+func (_e *MockUserService_Expecter) SetTags(ctx interface{}, userID interface{}, tags interface{}) *MockUserService_SetTags_Call {
+	return &MockUserService_SetTags_Call{Call: _e.mock.On("SetTags", ctx, userID, tags)}
+}
+
+func (_c *MockUserService_SetTags_Call) Run(run func(ctx context.Context, userID string, tags []string)) *MockUserService_SetTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_SetTags_Call) Return(ss []string, err error) *MockUserService_SetTags_Call {
+	_c.Call.Return(ss, err)
+	return _c
+}
+
+func (_c *MockUserService_SetTags_Call) RunAndReturn(run func(ctx context.Context, userID string, tags []string) ([]string, error)) *MockUserService_SetTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetWorkingHours provides a mock function for the type MockUserService
+func (_mock *MockUserService) SetWorkingHours(ctx context.Context, userID string, timezone string, hours *domain.WorkingHours) (*domain.User, error) {
+	ret := _mock.Called(ctx, userID, timezone, hours)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWorkingHours")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.WorkingHours) (*domain.User, error)); ok {
+		return returnFunc(ctx, userID, timezone, hours)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.WorkingHours) *domain.User); ok {
+		r0 = returnFunc(ctx, userID, timezone, hours)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *domain.WorkingHours) error); ok {
+		r1 = returnFunc(ctx, userID, timezone, hours)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_SetWorkingHours_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWorkingHours'
+type MockUserService_SetWorkingHours_Call struct {
+	*mock.Call
+}
+
+// SetWorkingHours is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - timezone string
+//   - hours *domain.WorkingHours
+func (_e *MockUserService_Expecter) SetWorkingHours(ctx interface{}, userID interface{}, timezone interface{}, hours interface{}) *MockUserService_SetWorkingHours_Call {
+	return &MockUserService_SetWorkingHours_Call{Call: _e.mock.On("SetWorkingHours", ctx, userID, timezone, hours)}
+}
+
+func (_c *MockUserService_SetWorkingHours_Call) Run(run func(ctx context.Context, userID string, timezone string, hours *domain.WorkingHours)) *MockUserService_SetWorkingHours_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *domain.WorkingHours
+		if args[3] != nil {
+			arg3 = args[3].(*domain.WorkingHours)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_SetWorkingHours_Call) Return(user *domain.User, err error) *MockUserService_SetWorkingHours_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserService_SetWorkingHours_Call) RunAndReturn(run func(ctx context.Context, userID string, timezone string, hours *domain.WorkingHours) (*domain.User, error)) *MockUserService_SetWorkingHours_Call {
+	_c.Call.Return(run)
+	return _c
+}